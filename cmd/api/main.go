@@ -2,12 +2,22 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
+	"github.com/kdotwei/hpl-scoreboard/internal/auth"
 	"github.com/kdotwei/hpl-scoreboard/internal/db"
 	"github.com/kdotwei/hpl-scoreboard/internal/handler"
 	"github.com/kdotwei/hpl-scoreboard/internal/middleware"
@@ -23,8 +33,8 @@ func enableCORS(next http.Handler) http.Handler {
 		if origin == "http://localhost:5173" || origin == "http://localhost:3000" {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 		}
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, X-Signature-Key-Id, X-Signature-Timestamp, X-Signature-Nonce, X-Signature")
 
 		// Handle preflight OPTIONS request
 		if r.Method == "OPTIONS" {
@@ -36,6 +46,155 @@ func enableCORS(next http.Handler) http.Handler {
 	})
 }
 
+// newTokenMaker 依照 TOKEN_MAKER 設定建立對應的 token.Maker
+func newTokenMaker(makerType string, jwtSecretKey string) (token.Maker, error) {
+	switch makerType {
+	case "jwt":
+		return newJWTMaker(jwtSecretKey)
+	case "jwt-asymmetric":
+		pemData, err := os.ReadFile(os.Getenv("JWT_PRIVATE_KEY_FILE"))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read JWT_PRIVATE_KEY_FILE: %w", err)
+		}
+		privateKey, err := token.ParsePrivateKeyPEM(pemData)
+		if err != nil {
+			return nil, err
+		}
+		return token.NewAsymmetricJWTMaker(os.Getenv("JWT_KEY_ID"), privateKey)
+	case "paseto-local":
+		return token.NewPasetoLocalMaker(os.Getenv("PASETO_SYMMETRIC_KEY"))
+	case "paseto-public":
+		return token.NewPasetoPublicMaker(os.Getenv("PASETO_PRIVATE_KEY"))
+	default:
+		return nil, fmt.Errorf("unknown TOKEN_MAKER %q (expected jwt, jwt-asymmetric, paseto-local or paseto-public)", makerType)
+	}
+}
+
+// newJWTMaker 建立 HMAC JWTMaker。
+// 設定 JWT_KEYRING_FILE 時使用金鑰檔，收到 SIGHUP 會重新載入；
+// 設定 JWT_KEYS ("kid:secret,...", 第一把為簽章金鑰) 時使用該清單；
+// 否則只使用 JWT_SECRET_KEY。
+func newJWTMaker(jwtSecretKey string) (token.Maker, error) {
+	keyringFile := os.Getenv("JWT_KEYRING_FILE")
+	keyList := os.Getenv("JWT_KEYS")
+
+	var config token.KeyringConfig
+	var err error
+	switch {
+	case keyringFile != "":
+		config, err = token.LoadKeyringFile(keyringFile)
+	case keyList != "":
+		config, err = token.ParseKeyringList(keyList)
+	default:
+		return token.NewJWTMaker(jwtSecretKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keyring, err := token.NewKeyring(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyringFile != "" {
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		go func() {
+			for range reload {
+				config, err := token.LoadKeyringFile(keyringFile)
+				if err == nil {
+					err = keyring.Update(config)
+				}
+				if err != nil {
+					log.Printf("failed to reload JWT keyring, keeping current keys: %v", err)
+					continue
+				}
+				log.Printf("reloaded JWT keyring, signing with key %q", config.CurrentKeyID)
+			}
+		}()
+	}
+
+	return token.NewJWTMakerWithKeyring(keyring)
+}
+
+// newAuthenticator 依照 AUTH_BACKEND 建立 Login 使用的帳密驗證後端。
+// htpasswd 檔收到 SIGHUP 會重新載入。
+func newAuthenticator(backend string, svc service.Service) (auth.Authenticator, error) {
+	switch backend {
+	case "local":
+		return auth.NewLocalAuthenticator(svc), nil
+	case "htpasswd":
+		htpasswdFile := os.Getenv("HTPASSWD_FILE")
+		authenticator, err := auth.NewHtpasswdAuthenticator(htpasswdFile)
+		if err != nil {
+			return nil, err
+		}
+
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		go func() {
+			for range reload {
+				if err := authenticator.Reload(); err != nil {
+					log.Printf("failed to reload htpasswd file, keeping current users: %v", err)
+					continue
+				}
+				log.Printf("reloaded htpasswd file %s", htpasswdFile)
+			}
+		}()
+		return authenticator, nil
+	case "ldap":
+		return auth.NewLDAPAuthenticator(auth.LDAPConfig{
+			URL:            os.Getenv("LDAP_URL"),
+			BindDNTemplate: os.Getenv("LDAP_BIND_DN_TEMPLATE"),
+			StartTLS:       os.Getenv("LDAP_START_TLS") == "true",
+		})
+	default:
+		return nil, fmt.Errorf("unknown AUTH_BACKEND %q (expected local, htpasswd or ldap)", backend)
+	}
+}
+
+// newClientCertTLSConfig 建立要求用戶端憑證的 TLS 設定。
+// 交握時只驗證「有帶」的憑證，是否必須帶憑證由各路由的 middleware 決定。
+func newClientCertTLSConfig(clientCAFile string) (*tls.Config, error) {
+	pemData, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read TLS_CLIENT_CA_FILE: %w", err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(pemData) {
+		return nil, fmt.Errorf("no certificates found in %s", clientCAFile)
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientCAs:  clientCAs,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}, nil
+}
+
+// newClientCertMap 載入用戶端憑證對應檔，收到 SIGHUP 會重新載入
+func newClientCertMap(path string) (*auth.ClientCertMap, error) {
+	certMap, err := auth.NewClientCertMap(path)
+	if err != nil {
+		return nil, err
+	}
+
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if err := certMap.Reload(); err != nil {
+				log.Printf("failed to reload client certificate map, keeping current identities: %v", err)
+				continue
+			}
+			log.Printf("reloaded client certificate map %s", path)
+		}
+	}()
+	return certMap, nil
+}
+
 func main() {
 	// 載入 .env 檔案
 	err := godotenv.Load()
@@ -59,6 +218,101 @@ func main() {
 		jwtSecretKey = "12345678901234567890123456789012" // 預設值（僅用於開發）
 	}
 
+	// Token 格式：jwt (預設)、jwt-asymmetric、paseto-local 或 paseto-public
+	tokenMakerType := os.Getenv("TOKEN_MAKER")
+	if tokenMakerType == "" {
+		tokenMakerType = "jwt"
+	}
+
+	// Token 撤銷清單：postgres (預設，多個 instance 共用) 或 memory (單機)
+	revocationBackend := os.Getenv("REVOCATION_STORE")
+	if revocationBackend == "" {
+		revocationBackend = "postgres"
+	}
+
+	// 帳密驗證後端：local (預設)、htpasswd 或 ldap
+	authBackend := os.Getenv("AUTH_BACKEND")
+	if authBackend == "" {
+		authBackend = "local"
+	}
+
+	// TLS：同時設定憑證與私鑰時改以 HTTPS 提供服務
+	tlsCertFile := os.Getenv("TLS_CERT_FILE")
+	tlsKeyFile := os.Getenv("TLS_KEY_FILE")
+	// 設定 TLS_CLIENT_CA_FILE 時，送出成績的路由改為要求該 CA 簽發的用戶端憑證
+	tlsClientCAFile := os.Getenv("TLS_CLIENT_CA_FILE")
+	if tlsClientCAFile != "" && (tlsCertFile == "" || tlsKeyFile == "") {
+		log.Fatal("TLS_CLIENT_CA_FILE requires TLS_CERT_FILE and TLS_KEY_FILE")
+	}
+
+	// 未驗證 linux_username 的成績處理方式：flag (預設，照常收錄並標記) 或 reject
+	linuxUsernamePolicy := os.Getenv("LINUX_USERNAME_POLICY")
+	if linuxUsernamePolicy == "" {
+		linuxUsernamePolicy = "flag"
+	}
+
+	// run nonce 的有效時間 (Go duration，例如 24h)
+	runNonceTTL := service.DefaultRunNonceTTL
+	if ttl := os.Getenv("RUN_NONCE_TTL"); ttl != "" {
+		runNonceTTL, err = time.ParseDuration(ttl)
+		if err != nil || runNonceTTL <= 0 {
+			log.Fatalf("invalid RUN_NONCE_TTL %q", ttl)
+		}
+	}
+
+	// gflops 與 n、execution_time 推算值之間允許的相對誤差
+	gflopsTolerance := service.DefaultGflopsTolerance
+	if value := os.Getenv("GFLOPS_TOLERANCE"); value != "" {
+		gflopsTolerance, err = strconv.ParseFloat(value, 64)
+		if err != nil || gflopsTolerance <= 0 {
+			log.Fatalf("invalid GFLOPS_TOLERANCE %q", value)
+		}
+	}
+
+	// efficiency (gflops / Rpeak) 超過此門檻的成績需要審核後才列入排行榜
+	reviewEfficiency := service.DefaultReviewEfficiency
+	if value := os.Getenv("REVIEW_EFFICIENCY_THRESHOLD"); value != "" {
+		reviewEfficiency, err = strconv.ParseFloat(value, 64)
+		if err != nil || reviewEfficiency <= 0 || reviewEfficiency > 1 {
+			log.Fatalf("invalid REVIEW_EFFICIENCY_THRESHOLD %q", value)
+		}
+	}
+
+	// green 排行榜的最低效能門檻 (GFLOPS)，低於此值的成績不列入
+	var greenMinGflops float64
+	if value := os.Getenv("GREEN_MIN_GFLOPS"); value != "" {
+		greenMinGflops, err = strconv.ParseFloat(value, 64)
+		if err != nil || greenMinGflops < 0 || math.IsInf(greenMinGflops, 0) || math.IsNaN(greenMinGflops) {
+			log.Fatalf("invalid GREEN_MIN_GFLOPS %q", value)
+		}
+	}
+
+	// 比賽時間 (RFC 3339)，只在這段時間內核發 run nonce；未設定代表不限制
+	var competitionStart, competitionEnd time.Time
+	if start := os.Getenv("COMPETITION_START"); start != "" {
+		competitionStart, err = time.Parse(time.RFC3339, start)
+		if err != nil {
+			log.Fatalf("invalid COMPETITION_START %q: %v", start, err)
+		}
+	}
+	if end := os.Getenv("COMPETITION_END"); end != "" {
+		competitionEnd, err = time.Parse(time.RFC3339, end)
+		if err != nil {
+			log.Fatalf("invalid COMPETITION_END %q: %v", end, err)
+		}
+	}
+
+	// admin / judge 路由預設要求 Token 帶有 MFA 宣告，設為 false 可關閉
+	adminRequireMFA := os.Getenv("ADMIN_REQUIRE_MFA") != "false"
+
+	// 啟動時授予 admin 角色的帳號清單 (以逗號分隔)
+	var adminUsernames []string
+	for _, username := range strings.Split(os.Getenv("ADMIN_USERNAMES"), ",") {
+		if username = strings.TrimSpace(username); username != "" {
+			adminUsernames = append(adminUsernames, username)
+		}
+	}
+
 	// 2. 資料庫連線 (Database Layer)
 	connPool, err := pgxpool.New(context.Background(), dbSource)
 	if err != nil {
@@ -75,17 +329,85 @@ func main() {
 	log.Println("Connected to database successfully")
 
 	// 3. 依賴注入 (Dependency Injection)
-	store := db.New(connPool)
-	svc := service.NewService(store)
+	store := db.NewStore(connPool)
+
+	// 簽章請求的 nonce 與撤銷清單使用相同的後端
+	var revocations token.RevocationStore
+	var nonces middleware.NonceStore
+	switch revocationBackend {
+	case "postgres":
+		revocations = db.NewPostgresRevocationStore(store)
+		nonces = db.NewPostgresNonceStore(store)
+	case "memory":
+		revocations = token.NewMemoryRevocationStore()
+		nonces = middleware.NewMemoryNonceStore()
+	default:
+		log.Fatalf("unknown REVOCATION_STORE %q (expected postgres or memory)", revocationBackend)
+	}
+	token.StartRevocationCleanup(context.Background(), revocations, time.Hour)
+	middleware.StartNonceCleanup(context.Background(), nonces, middleware.SignatureMaxSkew)
+
+	serviceOptions := []service.Option{
+		service.WithRunNonceTTL(runNonceTTL),
+		service.WithCompetitionWindow(competitionStart, competitionEnd),
+		service.WithGflopsTolerance(gflopsTolerance),
+		service.WithReviewEfficiency(reviewEfficiency),
+		service.WithGreenMinGflops(greenMinGflops),
+	}
+	switch linuxUsernamePolicy {
+	case "flag":
+	case "reject":
+		serviceOptions = append(serviceOptions, service.RequireVerifiedLinuxUsername())
+	default:
+		log.Fatalf("unknown LINUX_USERNAME_POLICY %q (expected flag or reject)", linuxUsernamePolicy)
+	}
+
+	svc := service.NewService(store, revocations, serviceOptions...)
+
+	for _, username := range adminUsernames {
+		if err := svc.GrantRole(context.Background(), username, token.RoleAdmin); err != nil {
+			log.Fatalf("cannot grant admin role to %q: %v", username, err)
+		}
+	}
 
 	// 初始化 Token Maker
-	tokenMaker, err := token.NewJWTMaker(jwtSecretKey)
+	tokenMaker, err := newTokenMaker(tokenMakerType, jwtSecretKey)
 	if err != nil {
 		log.Fatal("cannot create token maker:", err)
 	}
 
+	authenticator, err := newAuthenticator(authBackend, svc)
+	if err != nil {
+		log.Fatal("cannot create authenticator:", err)
+	}
+
+	handlerOptions := []handler.Option{handler.WithAuthenticator(authenticator)}
+
+	// 設定 OIDC_ISSUER_URL 時啟用校園 SSO 登入
+	if issuerURL := os.Getenv("OIDC_ISSUER_URL"); issuerURL != "" {
+		var scopes []string
+		for _, scope := range strings.Split(os.Getenv("OIDC_SCOPES"), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+
+		oidcProvider, err := auth.NewOIDCProvider(context.Background(), auth.OIDCConfig{
+			IssuerURL:     issuerURL,
+			ClientID:      os.Getenv("OIDC_CLIENT_ID"),
+			ClientSecret:  os.Getenv("OIDC_CLIENT_SECRET"),
+			RedirectURL:   os.Getenv("OIDC_REDIRECT_URL"),
+			Scopes:        scopes,
+			UsernameClaim: os.Getenv("OIDC_USERNAME_CLAIM"),
+		})
+		if err != nil {
+			log.Fatal("cannot create OIDC provider:", err)
+		}
+		handlerOptions = append(handlerOptions, handler.WithOIDC(oidcProvider))
+	}
+
 	// 注入 Service 和 TokenMaker
-	h := handler.NewHandler(svc, tokenMaker)
+	h := handler.NewHandler(svc, tokenMaker, handlerOptions...)
 
 	// 4. 路由設定 (Router)
 	mux := http.NewServeMux()
@@ -93,19 +415,159 @@ func main() {
 	// [Route 1] Login (公開)
 	mux.HandleFunc("POST /api/v1/login", h.Login)
 
+	// [Route 1.1] Register (公開，僅限本地帳號；外部目錄的帳號於第一次登入時建立)
+	if authBackend == "local" {
+		mux.HandleFunc("POST /api/v1/users", h.CreateUser)
+	}
+
+	// [Route 1.1.1] OIDC SSO 登入 (公開，未設定 OIDC_ISSUER_URL 時回傳 404)
+	mux.HandleFunc("GET /api/v1/auth/oidc/login", h.OIDCLogin)
+	mux.HandleFunc("GET /api/v1/auth/oidc/callback", h.OIDCCallback)
+
+	// [Route 1.2] Refresh Token rotation (公開，需帶 Refresh Token)
+	mux.HandleFunc("POST /api/v1/tokens/refresh", h.RefreshToken)
+
+	authMiddleware := middleware.AuthMiddleware(tokenMaker, revocations)
+	requireAdmin := middleware.RequireRole(token.RoleAdmin)
+	requireJudge := middleware.RequireRole(token.RoleJudge, token.RoleAdmin)
+	requireMFA := func(next http.Handler) http.Handler { return next }
+	if adminRequireMFA {
+		requireMFA = middleware.RequireMFA()
+	}
+
+	// [Route 1.3] Logout (需要 Auth)
+	mux.Handle("POST /api/v1/logout", authMiddleware(http.HandlerFunc(h.Logout)))
+
+	// [Route 1.4] JWKS (公開，供其他服務驗證 Token)
+	mux.HandleFunc("GET /.well-known/jwks.json", h.JWKS)
+
 	// [Route 2] List Scores (公開)
 	mux.HandleFunc("GET /api/v1/scores", h.ListScores)
 
 	// [Route 2.1] List Scores with Pagination (公開)
 	mux.HandleFunc("GET /api/v1/scores/paginated", h.ListScoresWithPagination)
 
-	// [Route 3] Submit Score (需要 Auth)
-	authMiddleware := middleware.AuthMiddleware(tokenMaker)
-	mux.Handle("POST /api/v1/scores", authMiddleware(http.HandlerFunc(h.CreateScore)))
+	// [Route 2.2] Team Leaderboard (公開)
+	mux.HandleFunc("GET /api/v1/leaderboards/teams", h.ListTeamLeaderboard)
+
+	// [Route 2.2.1] Green Leaderboard: 依 GFLOPS/W 排名 (公開)
+	mux.HandleFunc("GET /api/v1/leaderboards/green", h.ListGreenLeaderboard)
+
+	// [Route 2.2.2] Benchmark Leaderboard: hpl、hpl-mxp、hpcg、stream 各自排名 (公開)
+	mux.HandleFunc("GET /api/v1/leaderboards/{benchmark}", h.ListBenchmarkLeaderboard)
+
+	// [Route 2.3] Systems: 列出 / 查詢系統規格與 Rpeak (公開)
+	mux.HandleFunc("GET /api/v1/systems", h.ListSystems)
+	mux.HandleFunc("GET /api/v1/systems/{id}", h.GetSystem)
+
+	// [Route 3] Submit Score (需要 Auth、具備 scores:submit 的 API Key 或 HMAC 簽章；設定 TLS_CLIENT_CA_FILE 時改為要求用戶端憑證)
+	submitMiddleware := middleware.AuthMiddleware(tokenMaker, revocations,
+		middleware.WithAPIKeys(svc, token.ScopeScoresSubmit),
+		middleware.WithSignedRequests(svc, nonces, token.ScopeScoresSubmit),
+	)
+	if tlsClientCAFile != "" {
+		// mTLS 模式：改以用戶端憑證對應的使用者或系統身分送出，不接受 Token 與 API Key
+		certMap, err := newClientCertMap(os.Getenv("CLIENT_CERT_MAP_FILE"))
+		if err != nil {
+			log.Fatal("cannot load client certificate map:", err)
+		}
+		submitMiddleware = middleware.RequireClientCert(certMap)
+	}
+	mux.Handle("POST /api/v1/scores", submitMiddleware(http.HandlerFunc(h.CreateScore)))
+
+	// [Route 3.0.1] Upload HPL.out: 每行結果建立一筆成績 (驗證方式與送出成績相同)
+	mux.Handle("POST /api/v1/scores/upload", submitMiddleware(http.HandlerFunc(h.UploadScores)))
+
+	// [Route 3.0.2] Start Run: 跑 HPL 前取得 run nonce (驗證方式與送出成績相同)
+	mux.Handle("POST /api/v1/runs", submitMiddleware(http.HandlerFunc(h.CreateRun)))
+
+	// [Route 3.1] API Keys: 建立 / 列出 / 撤銷 (需要 Auth，不接受 API Key)
+	mux.Handle("POST /api/v1/api-keys", authMiddleware(http.HandlerFunc(h.CreateAPIKey)))
+	mux.Handle("GET /api/v1/api-keys", authMiddleware(http.HandlerFunc(h.ListAPIKeys)))
+	mux.Handle("DELETE /api/v1/api-keys/{id}", authMiddleware(http.HandlerFunc(h.RevokeAPIKey)))
+
+	// [Route 3.1.1] Signing Keys: 建立 / 列出 / 撤銷 HMAC 簽章金鑰 (需要 Auth，不接受 API Key)
+	mux.Handle("POST /api/v1/signing-keys", authMiddleware(http.HandlerFunc(h.CreateSigningKey)))
+	mux.Handle("GET /api/v1/signing-keys", authMiddleware(http.HandlerFunc(h.ListSigningKeys)))
+	mux.Handle("DELETE /api/v1/signing-keys/{id}", authMiddleware(http.HandlerFunc(h.RevokeSigningKey)))
+
+	// [Route 3.2] MFA: TOTP 註冊 / 確認 / 停用 (需要 Auth，不接受 API Key)
+	mux.Handle("POST /api/v1/mfa/totp/enroll", authMiddleware(http.HandlerFunc(h.EnrollTOTP)))
+	mux.Handle("POST /api/v1/mfa/totp/confirm", authMiddleware(http.HandlerFunc(h.ConfirmTOTP)))
+	mux.Handle("POST /api/v1/mfa/totp/disable", authMiddleware(http.HandlerFunc(h.DisableTOTP)))
+
+	// [Route 3.3] SSH Keys: 以 ssh-keygen -Y sign 驗證叢集帳號 (需要 Auth，不接受 API Key)
+	mux.Handle("POST /api/v1/ssh-keys", authMiddleware(http.HandlerFunc(h.CreateSSHKey)))
+	mux.Handle("GET /api/v1/ssh-keys", authMiddleware(http.HandlerFunc(h.ListSSHKeys)))
+	mux.Handle("DELETE /api/v1/ssh-keys/{id}", authMiddleware(http.HandlerFunc(h.DeleteSSHKey)))
+	mux.Handle("POST /api/v1/ssh-keys/{id}/challenge", authMiddleware(http.HandlerFunc(h.CreateSSHKeyChallenge)))
+	mux.Handle("POST /api/v1/ssh-keys/{id}/verify", authMiddleware(http.HandlerFunc(h.VerifySSHKey)))
+
+	// [Route 3.4] Teams: 建立 / 邀請 / 加入 / 管理成員 (需要 Auth，不接受 API Key)
+	mux.Handle("POST /api/v1/teams", authMiddleware(http.HandlerFunc(h.CreateTeam)))
+	mux.Handle("GET /api/v1/teams", authMiddleware(http.HandlerFunc(h.ListTeams)))
+	mux.Handle("POST /api/v1/teams/join", authMiddleware(http.HandlerFunc(h.JoinTeam)))
+	mux.Handle("GET /api/v1/teams/{id}", authMiddleware(http.HandlerFunc(h.GetTeam)))
+	mux.Handle("DELETE /api/v1/teams/{id}", authMiddleware(http.HandlerFunc(h.DeleteTeam)))
+	mux.Handle("POST /api/v1/teams/{id}/invitations", authMiddleware(http.HandlerFunc(h.CreateTeamInvitation)))
+	mux.Handle("GET /api/v1/teams/{id}/invitations", authMiddleware(http.HandlerFunc(h.ListTeamInvitations)))
+	mux.Handle("DELETE /api/v1/teams/{id}/invitations/{invitation_id}", authMiddleware(http.HandlerFunc(h.RevokeTeamInvitation)))
+	mux.Handle("PUT /api/v1/teams/{id}/members/{username}/role", authMiddleware(http.HandlerFunc(h.UpdateTeamMemberRole)))
+	mux.Handle("DELETE /api/v1/teams/{id}/members/{username}", authMiddleware(http.HandlerFunc(h.RemoveTeamMember)))
+
+	// [Route 3.5] Systems: 登記 / 更新 / 刪除系統 (需要 Auth，不接受 API Key；更新與刪除限登記者)
+	mux.Handle("POST /api/v1/systems", authMiddleware(http.HandlerFunc(h.CreateSystem)))
+	mux.Handle("PUT /api/v1/systems/{id}", authMiddleware(http.HandlerFunc(h.UpdateSystem)))
+	mux.Handle("DELETE /api/v1/systems/{id}", authMiddleware(http.HandlerFunc(h.DeleteSystem)))
+
+	// [Route 4] Admin: 撤銷使用者所有 Token (需要 Auth + Admin + MFA)
+	mux.Handle("POST /api/v1/admin/users/{username}/revoke-tokens", authMiddleware(requireAdmin(requireMFA(http.HandlerFunc(h.RevokeUserTokens)))))
+
+	// [Route 4.1] Admin: 解除登入鎖定 (需要 Auth + Admin + MFA)
+	mux.Handle("POST /api/v1/admin/users/{username}/unlock", authMiddleware(requireAdmin(requireMFA(http.HandlerFunc(h.UnlockUser)))))
+
+	// [Route 4.1.1] Admin: 稽核紀錄 (需要 Auth + Admin + MFA)
+	mux.Handle("GET /api/v1/admin/audit-events", authMiddleware(requireAdmin(requireMFA(http.HandlerFunc(h.ListAuditEvents)))))
+
+	// [Route 4.1.2] Admin: 重設使用者 MFA (需要 Auth + Admin + MFA)
+	mux.Handle("DELETE /api/v1/admin/users/{username}/mfa", authMiddleware(requireAdmin(requireMFA(http.HandlerFunc(h.ResetUserMFA)))))
+
+	// [Route 4.1.3] Admin: 解除叢集帳號綁定 (需要 Auth + Admin + MFA)
+	mux.Handle("DELETE /api/v1/admin/linux-accounts/{linux_username}", authMiddleware(requireAdmin(requireMFA(http.HandlerFunc(h.RemoveLinuxAccount)))))
+
+	// [Route 4.2] Admin: 設定使用者角色 (需要 Auth + Admin + MFA)
+	mux.Handle("PUT /api/v1/admin/users/{username}/roles", authMiddleware(requireAdmin(requireMFA(http.HandlerFunc(h.UpdateUserRoles)))))
+
+	// [Route 4.3] Admin: 刪除成績 (需要 Auth + Admin + MFA)
+	mux.Handle("DELETE /api/v1/admin/scores/{id}", authMiddleware(requireAdmin(requireMFA(http.HandlerFunc(h.DeleteScore)))))
+
+	// [Route 4.4] Judge: 成績失格 (需要 Auth + Judge 或 Admin + MFA)
+	mux.Handle("POST /api/v1/admin/scores/{id}/disqualify", authMiddleware(requireJudge(requireMFA(http.HandlerFunc(h.DisqualifyScore)))))
+
+	// [Route 4.5] Judge: 成績審核佇列 (需要 Auth + Judge 或 Admin + MFA)
+	mux.Handle("GET /api/v1/admin/reviews", authMiddleware(requireJudge(requireMFA(http.HandlerFunc(h.ListReviewQueue)))))
+	mux.Handle("POST /api/v1/admin/scores/{id}/review", authMiddleware(requireJudge(requireMFA(http.HandlerFunc(h.ReviewScore)))))
 
 	// 5. 啟動伺服器
-	log.Printf("Server starting on %s", serverAddress)
-	if err := http.ListenAndServe(serverAddress, enableCORS(mux)); err != nil {
+	server := &http.Server{
+		Addr:    serverAddress,
+		Handler: enableCORS(mux),
+	}
+
+	if tlsCertFile == "" || tlsKeyFile == "" {
+		log.Printf("Server starting on %s", serverAddress)
+		err = server.ListenAndServe()
+	} else {
+		if tlsClientCAFile != "" {
+			server.TLSConfig, err = newClientCertTLSConfig(tlsClientCAFile)
+			if err != nil {
+				log.Fatal("cannot configure client certificates:", err)
+			}
+		}
+		log.Printf("Server starting on %s (TLS)", serverAddress)
+		err = server.ListenAndServeTLS(tlsCertFile, tlsKeyFile)
+	}
+	if err != nil {
 		log.Fatal("Server failed to start:", err)
 	}
 }
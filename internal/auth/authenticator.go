@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+)
+
+// ErrInvalidCredentials 代表帳號不存在或密碼錯誤，兩者對外必須回應相同訊息
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// Authenticator 驗證使用者的帳號密碼。
+// 驗證失敗時回傳包裝 ErrInvalidCredentials 的錯誤，其他錯誤代表後端無法使用。
+type Authenticator interface {
+	Authenticate(ctx context.Context, username string, password string) error
+}
+
+// UserAuthenticator 是 LocalAuthenticator 所需的 Service 方法
+type UserAuthenticator interface {
+	AuthenticateUser(ctx context.Context, username string, password string) (*db.User, error)
+}
+
+// LocalAuthenticator 以 users 資料表中的 bcrypt 密碼驗證
+type LocalAuthenticator struct {
+	users UserAuthenticator
+}
+
+func NewLocalAuthenticator(users UserAuthenticator) *LocalAuthenticator {
+	return &LocalAuthenticator{users: users}
+}
+
+func (a *LocalAuthenticator) Authenticate(ctx context.Context, username string, password string) error {
+	_, err := a.users.AuthenticateUser(ctx, username, password)
+	if errors.Is(err, service.ErrUserNotFound) || errors.Is(err, service.ErrIncorrectPassword) {
+		return fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+	return err
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalAuthenticator(t *testing.T) {
+	mockService := new(mocks.Service)
+	mockService.On("AuthenticateUser", context.Background(), "alice", "correct-horse").Return(&db.User{Username: "alice"}, nil)
+	mockService.On("AuthenticateUser", context.Background(), "alice", "wrong").Return(nil, service.ErrIncorrectPassword)
+	mockService.On("AuthenticateUser", context.Background(), "mallory", "wrong").Return(nil, service.ErrUserNotFound)
+	mockService.On("AuthenticateUser", context.Background(), "bob", "wrong").Return(nil, assert.AnError)
+
+	authenticator := NewLocalAuthenticator(mockService)
+
+	assert.NoError(t, authenticator.Authenticate(context.Background(), "alice", "correct-horse"))
+	assert.ErrorIs(t, authenticator.Authenticate(context.Background(), "alice", "wrong"), ErrInvalidCredentials)
+	assert.ErrorIs(t, authenticator.Authenticate(context.Background(), "mallory", "wrong"), ErrInvalidCredentials)
+
+	// 資料庫錯誤不能被當成帳密錯誤
+	err := authenticator.Authenticate(context.Background(), "bob", "wrong")
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NotErrorIs(t, err, ErrInvalidCredentials)
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/middleware"
+)
+
+// ClientCertMapping is one entry of a client certificate map file. Exactly
+// one of Subject (the RFC 2253 subject DN, e.g. "CN=node01,O=Cluster A") or
+// SAN (a DNS name, email address, URI or IP address) selects the certificate.
+type ClientCertMapping struct {
+	Subject  string `json:"subject,omitempty"`
+	SAN      string `json:"san,omitempty"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name,omitempty"`
+	Username string `json:"username"`
+}
+
+// ClientCertMap 依照 JSON 對應檔將用戶端憑證對應到使用者或系統身分，第一個符合的項目生效
+type ClientCertMap struct {
+	path string
+
+	mu       sync.RWMutex
+	mappings []ClientCertMapping
+}
+
+// NewClientCertMap loads the client certificate map file at path
+func NewClientCertMap(path string) (*ClientCertMap, error) {
+	m := &ClientCertMap{path: path}
+	if err := m.Reload(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Reload re-reads the map file. On error the current mappings are kept.
+func (m *ClientCertMap) Reload() error {
+	mappings, err := loadClientCertMapFile(m.path)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.mappings = mappings
+	m.mu.Unlock()
+	return nil
+}
+
+func loadClientCertMapFile(path string) ([]ClientCertMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read client certificate map: %w", err)
+	}
+
+	var mappings []ClientCertMapping
+	if err := json.Unmarshal(data, &mappings); err != nil {
+		return nil, fmt.Errorf("invalid client certificate map %s: %w", path, err)
+	}
+
+	for i := range mappings {
+		mapping := &mappings[i]
+		if (mapping.Subject == "") == (mapping.SAN == "") {
+			return nil, fmt.Errorf("client certificate map entry %d: set exactly one of subject or san", i)
+		}
+		if mapping.Username == "" {
+			return nil, fmt.Errorf("client certificate map entry %d: username is required", i)
+		}
+
+		switch mapping.Kind {
+		case middleware.ClientIdentityUser:
+			// 使用者身分一律以帳號名稱記錄
+			mapping.Name = mapping.Username
+		case middleware.ClientIdentitySystem:
+			if mapping.Name == "" {
+				return nil, fmt.Errorf("client certificate map entry %d: system identities need a name", i)
+			}
+		default:
+			return nil, fmt.Errorf("client certificate map entry %d: unknown kind %q (expected user or system)", i, mapping.Kind)
+		}
+	}
+	return mappings, nil
+}
+
+// ResolveClientCert implements middleware.ClientCertResolver
+func (m *ClientCertMap) ResolveClientCert(cert *x509.Certificate) (*middleware.ClientIdentity, error) {
+	subject := cert.Subject.String()
+	sans := certificateSANs(cert)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, mapping := range m.mappings {
+		if (mapping.Subject != "" && mapping.Subject == subject) || (mapping.SAN != "" && sans[mapping.SAN]) {
+			return &middleware.ClientIdentity{
+				Kind:     mapping.Kind,
+				Name:     mapping.Name,
+				Username: mapping.Username,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", middleware.ErrUnknownClientCert, subject)
+}
+
+func certificateSANs(cert *x509.Certificate) map[string]bool {
+	sans := make(map[string]bool)
+	for _, name := range cert.DNSNames {
+		sans[name] = true
+	}
+	for _, email := range cert.EmailAddresses {
+		sans[email] = true
+	}
+	for _, uri := range cert.URIs {
+		sans[uri.String()] = true
+	}
+	for _, ip := range cert.IPAddresses {
+		sans[ip.String()] = true
+	}
+	return sans
+}
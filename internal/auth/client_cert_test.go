@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeClientCertMapFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "client-certs.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestClientCertMap(t *testing.T) {
+	path := writeClientCertMapFile(t, `[
+		{"subject": "CN=alice,O=Example University", "kind": "user", "username": "alice"},
+		{"san": "node01.cluster-a.example.edu", "kind": "system", "name": "cluster-a-node01", "username": "team-a"},
+		{"san": "spiffe://cluster-b/agent", "kind": "system", "name": "cluster-b-agent", "username": "team-b"},
+		{"san": "10.0.0.7", "kind": "system", "name": "cluster-c-login", "username": "team-c"}
+	]`)
+
+	certMap, err := NewClientCertMap(path)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		cert     *x509.Certificate
+		expected string
+		username string
+	}{
+		{
+			name:     "user by subject",
+			cert:     &x509.Certificate{Subject: pkix.Name{CommonName: "alice", Organization: []string{"Example University"}}},
+			expected: "user:alice",
+			username: "alice",
+		},
+		{
+			name:     "system by DNS SAN",
+			cert:     &x509.Certificate{Subject: pkix.Name{CommonName: "node01"}, DNSNames: []string{"node01.cluster-a.example.edu"}},
+			expected: "system:cluster-a-node01",
+			username: "team-a",
+		},
+		{
+			name:     "system by URI SAN",
+			cert:     &x509.Certificate{URIs: []*url.URL{{Scheme: "spiffe", Host: "cluster-b", Path: "/agent"}}},
+			expected: "system:cluster-b-agent",
+			username: "team-b",
+		},
+		{
+			name:     "system by IP SAN",
+			cert:     &x509.Certificate{IPAddresses: []net.IP{net.ParseIP("10.0.0.7")}},
+			expected: "system:cluster-c-login",
+			username: "team-c",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			identity, err := certMap.ResolveClientCert(tc.cert)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, identity.String())
+			assert.Equal(t, tc.username, identity.Username)
+		})
+	}
+
+	// 只比對 CN 不夠，subject 必須完全相同
+	_, err = certMap.ResolveClientCert(&x509.Certificate{Subject: pkix.Name{CommonName: "alice"}})
+	assert.ErrorIs(t, err, middleware.ErrUnknownClientCert)
+}
+
+func TestClientCertMapInvalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{name: "not JSON", content: `garbage`},
+		{name: "no selector", content: `[{"kind": "user", "username": "alice"}]`},
+		{name: "both selectors", content: `[{"subject": "CN=alice", "san": "alice.example.edu", "kind": "user", "username": "alice"}]`},
+		{name: "missing username", content: `[{"san": "node01", "kind": "system", "name": "node01"}]`},
+		{name: "system without name", content: `[{"san": "node01", "kind": "system", "username": "team-a"}]`},
+		{name: "unknown kind", content: `[{"san": "node01", "kind": "robot", "username": "team-a"}]`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewClientCertMap(writeClientCertMapFile(t, tc.content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestClientCertMapReload(t *testing.T) {
+	path := writeClientCertMapFile(t, `[{"san": "node01", "kind": "system", "name": "node01", "username": "team-a"}]`)
+	certMap, err := NewClientCertMap(path)
+	require.NoError(t, err)
+
+	node01 := &x509.Certificate{DNSNames: []string{"node01"}}
+
+	// 從對應檔移除即可撤銷節點
+	require.NoError(t, os.WriteFile(path, []byte(`[]`), 0o600))
+	require.NoError(t, certMap.Reload())
+	_, err = certMap.ResolveClientCert(node01)
+	assert.ErrorIs(t, err, middleware.ErrUnknownClientCert)
+
+	// 檔案格式錯誤時保留原本的對應
+	require.NoError(t, os.WriteFile(path, []byte(`[{"san": "node01", "kind": "system", "name": "node01", "username": "team-a"}]`), 0o600))
+	require.NoError(t, certMap.Reload())
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0o600))
+	assert.Error(t, certMap.Reload())
+	identity, err := certMap.ResolveClientCert(node01)
+	require.NoError(t, err)
+	assert.Equal(t, "system:node01", identity.String())
+}
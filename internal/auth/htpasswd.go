@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HtpasswdAuthenticator 以 Apache htpasswd 檔驗證，只支援 bcrypt (htpasswd -B)
+type HtpasswdAuthenticator struct {
+	path string
+
+	mu      sync.RWMutex
+	entries map[string]string
+}
+
+// NewHtpasswdAuthenticator loads the htpasswd file at path
+func NewHtpasswdAuthenticator(path string) (*HtpasswdAuthenticator, error) {
+	a := &HtpasswdAuthenticator{path: path}
+	if err := a.Reload(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Reload re-reads the htpasswd file. On error the current entries are kept.
+func (a *HtpasswdAuthenticator) Reload() error {
+	entries, err := parseHtpasswdFile(a.path)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.entries = entries
+	a.mu.Unlock()
+	return nil
+}
+
+func parseHtpasswdFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open htpasswd file: %w", err)
+	}
+	defer file.Close()
+
+	entries := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		username, hash, ok := strings.Cut(line, ":")
+		if !ok || username == "" {
+			return nil, fmt.Errorf("htpasswd line %d: expected username:hash", lineNumber)
+		}
+
+		// MD5 (apr1)、SHA1 與 crypt 都太弱，不予支援
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return nil, fmt.Errorf("htpasswd line %d: only bcrypt hashes are supported (use htpasswd -B)", lineNumber)
+		}
+		entries[username] = hash
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read htpasswd file: %w", err)
+	}
+	return entries, nil
+}
+
+func (a *HtpasswdAuthenticator) Authenticate(ctx context.Context, username string, password string) error {
+	a.mu.RLock()
+	hash, ok := a.entries[username]
+	a.mu.RUnlock()
+
+	if !ok {
+		// 與密碼錯誤花費相同時間，避免洩漏帳號是否存在
+		_ = service.CheckPassword(password, dummyHash())
+		return fmt.Errorf("%w: user %q not in htpasswd file", ErrInvalidCredentials, username)
+	}
+
+	if err := service.CheckPassword(password, hash); err != nil {
+		return fmt.Errorf("%w: incorrect password", ErrInvalidCredentials)
+	}
+	return nil
+}
+
+var (
+	dummyHashValue string
+	dummyHashOnce  sync.Once
+)
+
+func dummyHash() string {
+	dummyHashOnce.Do(func() {
+		dummyHashValue, _ = service.HashPassword("dummy-password")
+	})
+	return dummyHashValue
+}
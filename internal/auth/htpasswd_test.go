@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func writeHtpasswdFile(t *testing.T, lines ...string) string {
+	path := filepath.Join(t.TempDir(), "htpasswd")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+	return path
+}
+
+func htpasswdBcrypt(t *testing.T, password string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	// htpasswd -B 產生的是 $2y$ 前綴
+	return "$2y$" + strings.TrimPrefix(string(hash), "$2a$")
+}
+
+func TestHtpasswdAuthenticator(t *testing.T) {
+	path := writeHtpasswdFile(t,
+		"# competition accounts",
+		"alice:"+htpasswdBcrypt(t, "correct-horse"),
+		"",
+		"bob:"+htpasswdBcrypt(t, "battery-staple"),
+	)
+
+	authenticator, err := NewHtpasswdAuthenticator(path)
+	require.NoError(t, err)
+
+	assert.NoError(t, authenticator.Authenticate(context.Background(), "alice", "correct-horse"))
+	assert.NoError(t, authenticator.Authenticate(context.Background(), "bob", "battery-staple"))
+	assert.ErrorIs(t, authenticator.Authenticate(context.Background(), "alice", "battery-staple"), ErrInvalidCredentials)
+	assert.ErrorIs(t, authenticator.Authenticate(context.Background(), "mallory", "correct-horse"), ErrInvalidCredentials)
+}
+
+func TestHtpasswdAuthenticatorReload(t *testing.T) {
+	path := writeHtpasswdFile(t, "alice:"+htpasswdBcrypt(t, "correct-horse"))
+
+	authenticator, err := NewHtpasswdAuthenticator(path)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("alice:"+htpasswdBcrypt(t, "new-password")+"\n"), 0o600))
+	require.NoError(t, authenticator.Reload())
+
+	assert.ErrorIs(t, authenticator.Authenticate(context.Background(), "alice", "correct-horse"), ErrInvalidCredentials)
+	assert.NoError(t, authenticator.Authenticate(context.Background(), "alice", "new-password"))
+
+	// 檔案格式錯誤時保留原本的帳號
+	require.NoError(t, os.WriteFile(path, []byte("garbage\n"), 0o600))
+	assert.Error(t, authenticator.Reload())
+	assert.NoError(t, authenticator.Authenticate(context.Background(), "alice", "new-password"))
+}
+
+func TestHtpasswdAuthenticatorRejectsWeakHashes(t *testing.T) {
+	testCases := []struct {
+		name string
+		line string
+	}{
+		{name: "apr1 md5", line: "alice:$apr1$r31.....$HqJZimcKQFAMYayBlzkrA/"},
+		{name: "sha1", line: "alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g="},
+		{name: "plaintext", line: "alice:password"},
+		{name: "missing separator", line: "alice"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewHtpasswdAuthenticator(writeHtpasswdFile(t, tc.line))
+			assert.Error(t, err)
+		})
+	}
+}
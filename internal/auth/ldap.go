@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// LDAPConfig 設定 LDAP bind 驗證
+type LDAPConfig struct {
+	// URL 例如 ldaps://ldap.example.edu:636 或 ldap://ldap.example.edu:389
+	URL string
+	// BindDNTemplate 中的 %s 會被替換成跳脫後的使用者名稱，
+	// 例如 uid=%s,ou=people,dc=example,dc=edu
+	BindDNTemplate string
+	// StartTLS 在 ldap:// 連線上升級為 TLS
+	StartTLS  bool
+	TLSConfig *tls.Config
+	Timeout   time.Duration
+}
+
+// LDAPAuthenticator 以使用者的帳號密碼對 LDAP 伺服器做 simple bind
+type LDAPAuthenticator struct {
+	config LDAPConfig
+}
+
+func NewLDAPAuthenticator(config LDAPConfig) (*LDAPAuthenticator, error) {
+	if config.URL == "" {
+		return nil, errors.New("LDAP URL is required")
+	}
+	if strings.Count(config.BindDNTemplate, "%s") != 1 {
+		return nil, errors.New("LDAP bind DN template must contain exactly one %s")
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &LDAPAuthenticator{config: config}, nil
+}
+
+func (a *LDAPAuthenticator) Authenticate(ctx context.Context, username string, password string) error {
+	// 空密碼在 LDAP 是 unauthenticated bind，伺服器會回報成功
+	if username == "" || password == "" {
+		return fmt.Errorf("%w: empty username or password", ErrInvalidCredentials)
+	}
+
+	dialer := ldap.DialWithDialer(&net.Dialer{Timeout: a.config.Timeout})
+	opts := []ldap.DialOpt{dialer}
+	if a.config.TLSConfig != nil {
+		opts = append(opts, ldap.DialWithTLSConfig(a.config.TLSConfig))
+	}
+
+	conn, err := ldap.DialURL(a.config.URL, opts...)
+	if err != nil {
+		return fmt.Errorf("cannot connect to LDAP server: %w", err)
+	}
+	defer conn.Close()
+	conn.SetTimeout(a.config.Timeout)
+
+	if a.config.StartTLS {
+		tlsConfig := a.config.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{ServerName: hostname(a.config.URL)}
+		}
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("LDAP StartTLS failed: %w", err)
+		}
+	}
+
+	bindDN := fmt.Sprintf(a.config.BindDNTemplate, ldap.EscapeDN(username))
+	if err := conn.Bind(bindDN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return fmt.Errorf("%w: LDAP bind rejected for %q", ErrInvalidCredentials, bindDN)
+		}
+		return fmt.Errorf("LDAP bind failed: %w", err)
+	}
+	return nil
+}
+
+func hostname(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLDAPServer 只實作 simple bind 與 unbind，足以測試 LDAPAuthenticator
+type fakeLDAPServer struct {
+	listener net.Listener
+	// passwords 以 bind DN 為 key
+	passwords map[string]string
+	binds     chan string
+}
+
+func newFakeLDAPServer(t *testing.T, passwords map[string]string) *fakeLDAPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeLDAPServer{
+		listener:  listener,
+		passwords: passwords,
+		binds:     make(chan string, 16),
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeLDAPServer) URL() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *fakeLDAPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+
+		messageID := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			bindDN := op.Children[1].Data.String()
+			password := op.Children[2].Data.String()
+			s.binds <- bindDN
+
+			resultCode := uint16(ldap.LDAPResultSuccess)
+			if expected, ok := s.passwords[bindDN]; !ok || expected != password {
+				resultCode = ldap.LDAPResultInvalidCredentials
+			}
+
+			response := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+			response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+			bindResponse := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationBindResponse, nil, "Bind Response")
+			bindResponse.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(resultCode), "Result Code"))
+			bindResponse.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+			bindResponse.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+			response.AppendChild(bindResponse)
+
+			if _, err := conn.Write(response.Bytes()); err != nil {
+				return
+			}
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func TestLDAPAuthenticator(t *testing.T) {
+	server := newFakeLDAPServer(t, map[string]string{
+		"uid=alice,ou=people,dc=example,dc=edu": "correct-horse",
+	})
+
+	authenticator, err := NewLDAPAuthenticator(LDAPConfig{
+		URL:            server.URL(),
+		BindDNTemplate: "uid=%s,ou=people,dc=example,dc=edu",
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		username string
+		password string
+		checkErr func(t *testing.T, err error)
+	}{
+		{
+			name:     "valid credentials",
+			username: "alice",
+			password: "correct-horse",
+			checkErr: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:     "wrong password",
+			username: "alice",
+			password: "wrong",
+			checkErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, ErrInvalidCredentials)
+			},
+		},
+		{
+			name:     "unknown user",
+			username: "mallory",
+			password: "correct-horse",
+			checkErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, ErrInvalidCredentials)
+			},
+		},
+		{
+			// 空密碼會變成 unauthenticated bind，必須在送出前擋下
+			name:     "empty password",
+			username: "alice",
+			password: "",
+			checkErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, ErrInvalidCredentials)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.checkErr(t, authenticator.Authenticate(context.Background(), tc.username, tc.password))
+		})
+	}
+}
+
+func TestLDAPAuthenticatorEscapesUsername(t *testing.T) {
+	server := newFakeLDAPServer(t, map[string]string{})
+
+	authenticator, err := NewLDAPAuthenticator(LDAPConfig{
+		URL:            server.URL(),
+		BindDNTemplate: "uid=%s,ou=people,dc=example,dc=edu",
+	})
+	require.NoError(t, err)
+
+	err = authenticator.Authenticate(context.Background(), "admin,ou=staff", "secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Equal(t, `uid=admin\,ou=staff,ou=people,dc=example,dc=edu`, <-server.binds)
+}
+
+func TestLDAPAuthenticatorServerUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	url := "ldap://" + listener.Addr().String()
+	listener.Close()
+
+	authenticator, err := NewLDAPAuthenticator(LDAPConfig{
+		URL:            url,
+		BindDNTemplate: "uid=%s,dc=example,dc=edu",
+	})
+	require.NoError(t, err)
+
+	err = authenticator.Authenticate(context.Background(), "alice", "correct-horse")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestNewLDAPAuthenticatorValidatesTemplate(t *testing.T) {
+	_, err := NewLDAPAuthenticator(LDAPConfig{URL: "ldap://localhost", BindDNTemplate: "dc=example,dc=edu"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+const defaultOIDCUsernameClaim = "preferred_username"
+
+// OIDCConfig 設定 OpenID Connect 登入
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes 之外一律會要求 openid
+	Scopes []string
+	// UsernameClaim 指定 ID Token 中作為使用者名稱的 claim，預設為 preferred_username
+	UsernameClaim string
+}
+
+// OIDCIdentity 是 ID Token 中識別使用者的資訊。Issuer 與 Subject 在 IdP 內
+// 唯一且不會改變，Username 只作為第一次登入時建立本地帳號的名稱
+type OIDCIdentity struct {
+	Issuer   string
+	Subject  string
+	Username string
+}
+
+// OIDCProvider 實作 authorization code + PKCE 流程
+type OIDCProvider struct {
+	oauth2Config  oauth2.Config
+	verifier      *oidc.IDTokenVerifier
+	usernameClaim string
+}
+
+// NewOIDCProvider fetches the issuer's discovery document and returns a
+// provider for the authorization code flow.
+func NewOIDCProvider(ctx context.Context, config OIDCConfig) (*OIDCProvider, error) {
+	if config.ClientID == "" || config.RedirectURL == "" {
+		return nil, errors.New("OIDC client ID and redirect URL are required")
+	}
+
+	provider, err := oidc.NewProvider(ctx, config.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("cannot discover OIDC issuer: %w", err)
+	}
+
+	usernameClaim := config.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = defaultOIDCUsernameClaim
+	}
+
+	return &OIDCProvider{
+		oauth2Config: oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       append([]string{oidc.ScopeOpenID}, config.Scopes...),
+		},
+		verifier:      provider.Verifier(&oidc.Config{ClientID: config.ClientID}),
+		usernameClaim: usernameClaim,
+	}, nil
+}
+
+// AuthCodeURL returns the identity provider URL to send the browser to.
+// codeVerifier and nonce must be kept until the callback.
+func (p *OIDCProvider) AuthCodeURL(state string, codeVerifier string, nonce string) string {
+	return p.oauth2Config.AuthCodeURL(state, oauth2.S256ChallengeOption(codeVerifier), oidc.Nonce(nonce))
+}
+
+// Exchange redeems the authorization code, verifies the ID token and returns
+// the identity it asserts.
+func (p *OIDCProvider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*OIDCIdentity, error) {
+	oauth2Token, err := p.oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		// IdP 拒絕 (code 無效、PKCE 驗證失敗) 視為驗證失敗，其餘為連線問題
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) {
+			return nil, fmt.Errorf("%w: code exchange rejected: %v", ErrInvalidCredentials, err)
+		}
+		return nil, fmt.Errorf("OIDC code exchange failed: %w", err)
+	}
+
+	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: token response has no id_token", ErrInvalidCredentials)
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, fmt.Errorf("%w: ID token nonce mismatch", ErrInvalidCredentials)
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+	username, _ := claims[p.usernameClaim].(string)
+	if username == "" {
+		return nil, fmt.Errorf("%w: ID token has no %q claim", ErrInvalidCredentials, p.usernameClaim)
+	}
+	if idToken.Subject == "" {
+		return nil, fmt.Errorf("%w: ID token has no sub claim", ErrInvalidCredentials)
+	}
+	return &OIDCIdentity{
+		Issuer:   idToken.Issuer,
+		Subject:  idToken.Subject,
+		Username: username,
+	}, nil
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// fakeIdentityProvider 是最小的 OIDC IdP：discovery、JWKS 與會檢查 PKCE 的 token endpoint
+type fakeIdentityProvider struct {
+	server     *httptest.Server
+	signingKey *rsa.PrivateKey
+	clientID   string
+	// idTokenClaims 會合併進簽發的 ID Token
+	idTokenClaims jwt.MapClaims
+
+	mu    sync.Mutex
+	codes map[string]authorizationRequest
+}
+
+type authorizationRequest struct {
+	codeChallenge string
+	nonce         string
+}
+
+func newFakeIdentityProvider(t *testing.T, clientID string) *fakeIdentityProvider {
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &fakeIdentityProvider{
+		signingKey:    signingKey,
+		clientID:      clientID,
+		idTokenClaims: jwt.MapClaims{"preferred_username": "campus-user"},
+		codes:         make(map[string]authorizationRequest),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                idp.server.URL,
+			"authorization_endpoint":                idp.server.URL + "/authorize",
+			"token_endpoint":                        idp.server.URL + "/token",
+			"jwks_uri":                              idp.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(token.JSONWebKeySet{
+			Keys: []token.JSONWebKey{token.NewJSONWebKey("idp-key", "RS256", &signingKey.PublicKey)},
+		})
+	})
+	mux.HandleFunc("POST /token", idp.handleToken)
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// authorize 模擬使用者在 IdP 登入成功，回傳 authorization code
+func (idp *fakeIdentityProvider) authorize(t *testing.T, authCodeURL string) string {
+	u, err := url.Parse(authCodeURL)
+	require.NoError(t, err)
+	query := u.Query()
+	require.Equal(t, idp.server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+
+	code := rand.Text()
+	idp.mu.Lock()
+	idp.codes[code] = authorizationRequest{
+		codeChallenge: query.Get("code_challenge"),
+		nonce:         query.Get("nonce"),
+	}
+	idp.mu.Unlock()
+	return code
+}
+
+func (idp *fakeIdentityProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	idp.mu.Lock()
+	request, ok := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != request.codeChallenge {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":   idp.server.URL,
+		"aud":   idp.clientID,
+		"sub":   "subject-1",
+		"nonce": request.nonce,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Minute).Unix(),
+	}
+	for k, v := range idp.idTokenClaims {
+		claims[k] = v
+	}
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = "idp-key"
+	signed, err := idToken.SignedString(idp.signingKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "idp-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     signed,
+	})
+}
+
+func newTestOIDCProvider(t *testing.T, idp *fakeIdentityProvider) *OIDCProvider {
+	provider, err := NewOIDCProvider(context.Background(), OIDCConfig{
+		IssuerURL:    idp.server.URL,
+		ClientID:     idp.clientID,
+		ClientSecret: "client-secret",
+		RedirectURL:  "https://scoreboard.example.edu/api/v1/auth/oidc/callback",
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+func TestOIDCProviderCodeFlow(t *testing.T) {
+	idp := newFakeIdentityProvider(t, "scoreboard")
+	provider := newTestOIDCProvider(t, idp)
+
+	verifier := oauth2.GenerateVerifier()
+	code := idp.authorize(t, provider.AuthCodeURL("state-1", verifier, "nonce-1"))
+
+	identity, err := provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, idp.server.URL, identity.Issuer)
+	assert.Equal(t, "subject-1", identity.Subject)
+	assert.Equal(t, "campus-user", identity.Username)
+}
+
+func TestOIDCProviderRejectsWrongVerifier(t *testing.T) {
+	idp := newFakeIdentityProvider(t, "scoreboard")
+	provider := newTestOIDCProvider(t, idp)
+
+	code := idp.authorize(t, provider.AuthCodeURL("state-1", oauth2.GenerateVerifier(), "nonce-1"))
+
+	_, err := provider.Exchange(context.Background(), code, oauth2.GenerateVerifier(), "nonce-1")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestOIDCProviderRejectsNonceMismatch(t *testing.T) {
+	idp := newFakeIdentityProvider(t, "scoreboard")
+	provider := newTestOIDCProvider(t, idp)
+
+	verifier := oauth2.GenerateVerifier()
+	code := idp.authorize(t, provider.AuthCodeURL("state-1", verifier, "nonce-1"))
+
+	_, err := provider.Exchange(context.Background(), code, verifier, "nonce-2")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestOIDCProviderRejectsTokenForOtherClient(t *testing.T) {
+	idp := newFakeIdentityProvider(t, "scoreboard")
+	idp.idTokenClaims["aud"] = "another-app"
+	provider := newTestOIDCProvider(t, idp)
+
+	verifier := oauth2.GenerateVerifier()
+	code := idp.authorize(t, provider.AuthCodeURL("state-1", verifier, "nonce-1"))
+
+	_, err := provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestOIDCProviderRequiresUsernameClaim(t *testing.T) {
+	idp := newFakeIdentityProvider(t, "scoreboard")
+	delete(idp.idTokenClaims, "preferred_username")
+	provider := newTestOIDCProvider(t, idp)
+
+	verifier := oauth2.GenerateVerifier()
+	code := idp.authorize(t, provider.AuthCodeURL("state-1", verifier, "nonce-1"))
+
+	_, err := provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomAPIKey(t *testing.T, username string, expiresAt pgtype.Timestamptz) ApiKey {
+	arg := CreateAPIKeyParams{
+		Username:  username,
+		Name:      "cluster-a",
+		Prefix:    "hpl_test",
+		HashedKey: uuid.NewString(),
+		Scopes:    []string{"scores:submit"},
+		ExpiresAt: expiresAt,
+	}
+
+	apiKey, err := testStore.CreateAPIKey(context.Background(), arg)
+	require.NoError(t, err)
+	assert.True(t, apiKey.ID.Valid)
+	assert.Equal(t, arg.Scopes, apiKey.Scopes)
+	assert.False(t, apiKey.LastUsedAt.Valid)
+	assert.False(t, apiKey.RevokedAt.Valid)
+
+	return apiKey
+}
+
+func TestUseAPIKey(t *testing.T) {
+	user := createRandomUser(t)
+	apiKey := createRandomAPIKey(t, user.Username, pgtype.Timestamptz{})
+
+	used, err := testStore.UseAPIKey(context.Background(), apiKey.HashedKey)
+	require.NoError(t, err)
+	assert.Equal(t, apiKey.ID, used.ID)
+	assert.True(t, used.LastUsedAt.Valid)
+
+	// 過期的 Key 無法使用
+	expired := createRandomAPIKey(t, user.Username, pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true})
+	_, err = testStore.UseAPIKey(context.Background(), expired.HashedKey)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	owner := createRandomUser(t)
+	other := createRandomUser(t)
+	apiKey := createRandomAPIKey(t, owner.Username, pgtype.Timestamptz{})
+
+	// 只能撤銷自己的 Key
+	rows, err := testStore.RevokeAPIKey(context.Background(), RevokeAPIKeyParams{ID: apiKey.ID, Username: other.Username})
+	require.NoError(t, err)
+	assert.Zero(t, rows)
+
+	rows, err = testStore.RevokeAPIKey(context.Background(), RevokeAPIKeyParams{ID: apiKey.ID, Username: owner.Username})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = testStore.UseAPIKey(context.Background(), apiKey.HashedKey)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestRevokeUserAPIKeys(t *testing.T) {
+	user := createRandomUser(t)
+	apiKey1 := createRandomAPIKey(t, user.Username, pgtype.Timestamptz{})
+	apiKey2 := createRandomAPIKey(t, user.Username, pgtype.Timestamptz{})
+
+	require.NoError(t, testStore.RevokeUserAPIKeys(context.Background(), user.Username))
+
+	for _, apiKey := range []ApiKey{apiKey1, apiKey2} {
+		_, err := testStore.UseAPIKey(context.Background(), apiKey.HashedKey)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	}
+
+	apiKeys, err := testStore.ListAPIKeys(context.Background(), user.Username)
+	require.NoError(t, err)
+	assert.Len(t, apiKeys, 2)
+}
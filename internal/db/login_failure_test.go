@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordLoginFailure(t *testing.T) {
+	subject := "user-" + uuid.NewString()[:8]
+	arg := RecordLoginFailureParams{
+		Scope:       "account",
+		Subject:     subject,
+		ResetBefore: time.Now().Add(-time.Hour),
+	}
+
+	for i := int32(1); i <= 3; i++ {
+		failure, err := testStore.RecordLoginFailure(context.Background(), arg)
+		require.NoError(t, err)
+		assert.Equal(t, i, failure.Failures)
+	}
+
+	// 上次失敗早於 reset_before 時重新計算
+	arg.ResetBefore = time.Now().Add(time.Minute)
+	failure, err := testStore.RecordLoginFailure(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), failure.Failures)
+}
+
+func TestLockAndClearLoginFailures(t *testing.T) {
+	username := "user-" + uuid.NewString()[:8]
+	clientIP := "192.0.2." + uuid.NewString()[:3]
+
+	for _, scope := range []struct{ scope, subject string }{{"account", username}, {"ip", clientIP}} {
+		_, err := testStore.RecordLoginFailure(context.Background(), RecordLoginFailureParams{
+			Scope:       scope.scope,
+			Subject:     scope.subject,
+			ResetBefore: time.Now().Add(-time.Hour),
+		})
+		require.NoError(t, err)
+	}
+
+	lockedUntil := time.Now().Add(time.Minute)
+	require.NoError(t, testStore.LockLogin(context.Background(), LockLoginParams{
+		LockedUntil: pgtype.Timestamptz{Time: lockedUntil, Valid: true},
+		Scope:       "account",
+		Subject:     username,
+	}))
+
+	failures, err := testStore.GetLoginFailures(context.Background(), GetLoginFailuresParams{Username: username, ClientIp: clientIP})
+	require.NoError(t, err)
+	require.Len(t, failures, 2)
+	for _, failure := range failures {
+		if failure.Scope == "account" {
+			assert.WithinDuration(t, lockedUntil, failure.LockedUntil.Time, time.Second)
+		} else {
+			assert.False(t, failure.LockedUntil.Valid)
+		}
+	}
+
+	rows, err := testStore.ClearLoginFailures(context.Background(), ClearLoginFailuresParams{Scope: "account", Subject: username})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	failures, err = testStore.GetLoginFailures(context.Background(), GetLoginFailuresParams{Username: username, ClientIp: clientIP})
+	require.NoError(t, err)
+	assert.Len(t, failures, 1)
+}
+
+func TestCreateAuditEvent(t *testing.T) {
+	arg := CreateAuditEventParams{
+		Event:    "login_lockout",
+		Subject:  "user-" + uuid.NewString()[:8],
+		ClientIp: "192.0.2.1",
+		Details:  "account locked for 1m0s after 5 failed logins",
+	}
+
+	event, err := testStore.CreateAuditEvent(context.Background(), arg)
+	require.NoError(t, err)
+	assert.NotZero(t, event.ID)
+	assert.Equal(t, arg.Subject, event.Subject)
+	assert.NotZero(t, event.CreatedAt)
+
+	events, err := testStore.ListAuditEvents(context.Background(), ListAuditEventsParams{Limit: 10})
+	require.NoError(t, err)
+	assert.NotEmpty(t, events)
+}
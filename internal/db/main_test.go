@@ -18,7 +18,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
-var testStore Querier
+var testStore Store
 
 func TestMain(m *testing.M) {
 	ctx := context.Background()
@@ -56,7 +56,7 @@ func TestMain(m *testing.M) {
 	}
 	defer connPool.Close()
 
-	testStore = New(connPool)
+	testStore = NewStore(connPool)
 
 	code := m.Run()
 
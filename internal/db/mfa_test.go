@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnableTOTPTx(t *testing.T) {
+	user := createRandomUser(t)
+
+	// 尚未開始註冊時不能啟用
+	err := testStore.EnableTOTPTx(context.Background(), EnableTOTPTxParams{Username: user.Username})
+	require.ErrorIs(t, err, ErrTOTPNotPending)
+
+	rows, err := testStore.SetTOTPSecret(context.Background(), SetTOTPSecretParams{Username: user.Username, TotpSecret: "SECRET"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	err = testStore.EnableTOTPTx(context.Background(), EnableTOTPTxParams{
+		Username:            user.Username,
+		HashedRecoveryCodes: []string{"code-1", "code-2"},
+	})
+	require.NoError(t, err)
+
+	user, err = testStore.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	assert.True(t, user.TotpEnabledAt.Valid)
+
+	// 已啟用後不能再覆寫 secret
+	rows, err = testStore.SetTOTPSecret(context.Background(), SetTOTPSecretParams{Username: user.Username, TotpSecret: "OTHER"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	// Recovery code 只能使用一次
+	used, err := testStore.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{Username: user.Username, HashedCode: "code-1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), used)
+	used, err = testStore.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{Username: user.Username, HashedCode: "code-1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), used)
+}
+
+func TestUseTOTPStepRejectsReplay(t *testing.T) {
+	user := createRandomUser(t)
+
+	rows, err := testStore.UseTOTPStep(context.Background(), UseTOTPStepParams{Username: user.Username, Step: 100})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	for _, step := range []int64{100, 99} {
+		rows, err = testStore.UseTOTPStep(context.Background(), UseTOTPStepParams{Username: user.Username, Step: step})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), rows)
+	}
+}
+
+func TestDisableTOTPTx(t *testing.T) {
+	user := createRandomUser(t)
+
+	_, err := testStore.SetTOTPSecret(context.Background(), SetTOTPSecretParams{Username: user.Username, TotpSecret: "SECRET"})
+	require.NoError(t, err)
+	require.NoError(t, testStore.EnableTOTPTx(context.Background(), EnableTOTPTxParams{
+		Username:            user.Username,
+		HashedRecoveryCodes: []string{"code-1"},
+	}))
+
+	require.NoError(t, testStore.DisableTOTPTx(context.Background(), user.Username))
+
+	user, err = testStore.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	assert.Empty(t, user.TotpSecret)
+	assert.False(t, user.TotpEnabledAt.Valid)
+
+	used, err := testStore.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{Username: user.Username, HashedCode: "code-1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), used)
+}
@@ -10,17 +10,224 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ApiKey struct {
+	ID         pgtype.UUID        `json:"id"`
+	Username   string             `json:"username"`
+	Name       string             `json:"name"`
+	Prefix     string             `json:"prefix"`
+	HashedKey  string             `json:"hashed_key"`
+	Scopes     []string           `json:"scopes"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+type AuditEvent struct {
+	ID        int64     `json:"id"`
+	Event     string    `json:"event"`
+	Actor     string    `json:"actor"`
+	Subject   string    `json:"subject"`
+	ClientIp  string    `json:"client_ip"`
+	Details   string    `json:"details"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LinuxAccount struct {
+	LinuxUsername string    `json:"linux_username"`
+	Username      string    `json:"username"`
+	VerifiedAt    time.Time `json:"verified_at"`
+}
+
+type LoginFailure struct {
+	Scope         string             `json:"scope"`
+	Subject       string             `json:"subject"`
+	Failures      int32              `json:"failures"`
+	LastFailureAt time.Time          `json:"last_failure_at"`
+	LockedUntil   pgtype.Timestamptz `json:"locked_until"`
+}
+
+type MfaRecoveryCode struct {
+	ID         int64              `json:"id"`
+	Username   string             `json:"username"`
+	HashedCode string             `json:"hashed_code"`
+	UsedAt     pgtype.Timestamptz `json:"used_at"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+type OidcIdentity struct {
+	Issuer    string    `json:"issuer"`
+	Subject   string    `json:"subject"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RequestNonce struct {
+	KeyID     string    `json:"key_id"`
+	Nonce     string    `json:"nonce"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type RevokedToken struct {
+	ID        pgtype.UUID `json:"id"`
+	Username  string      `json:"username"`
+	ExpiresAt time.Time   `json:"expires_at"`
+	RevokedAt time.Time   `json:"revoked_at"`
+}
+
+type RunNonce struct {
+	Nonce     string             `json:"nonce"`
+	Username  string             `json:"username"`
+	IssuedAt  time.Time          `json:"issued_at"`
+	ExpiresAt time.Time          `json:"expires_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+}
+
 type Score struct {
-	ID            pgtype.UUID `json:"id"`
-	UserID        string      `json:"user_id"`
-	Gflops        float64     `json:"gflops"`
-	ProblemSizeN  int32       `json:"problem_size_n"`
-	BlockSizeNb   int32       `json:"block_size_nb"`
-	SubmittedAt   time.Time   `json:"submitted_at"`
-	LinuxUsername string      `json:"linux_username"`
-	N             int32       `json:"n"`
-	Nb            int32       `json:"nb"`
-	P             int32       `json:"p"`
-	Q             int32       `json:"q"`
-	ExecutionTime float64     `json:"execution_time"`
+	ID                     pgtype.UUID        `json:"id"`
+	UserID                 string             `json:"user_id"`
+	Gflops                 float64            `json:"gflops"`
+	ProblemSizeN           int32              `json:"problem_size_n"`
+	BlockSizeNb            int32              `json:"block_size_nb"`
+	SubmittedAt            time.Time          `json:"submitted_at"`
+	LinuxUsername          string             `json:"linux_username"`
+	N                      int32              `json:"n"`
+	Nb                     int32              `json:"nb"`
+	P                      int32              `json:"p"`
+	Q                      int32              `json:"q"`
+	ExecutionTime          float64            `json:"execution_time"`
+	DisqualifiedAt         pgtype.Timestamptz `json:"disqualified_at"`
+	DisqualifiedBy         string             `json:"disqualified_by"`
+	DisqualificationReason string             `json:"disqualification_reason"`
+	LinuxUsernameVerified  bool               `json:"linux_username_verified"`
+	ClientIdentity         string             `json:"client_identity"`
+	TeamID                 pgtype.UUID        `json:"team_id"`
+	RunNonce               string             `json:"run_nonce"`
+	ExpectedGflops         float64            `json:"expected_gflops"`
+	GflopsFlagged          bool               `json:"gflops_flagged"`
+	Variant                string             `json:"variant"`
+	Residual               pgtype.Float8      `json:"residual"`
+	ResidualThreshold      pgtype.Float8      `json:"residual_threshold"`
+	ResidualPassed         pgtype.Bool        `json:"residual_passed"`
+	Ranks                  int32              `json:"ranks"`
+	Nodes                  int32              `json:"nodes"`
+	RanksPerNode           int32              `json:"ranks_per_node"`
+	GflopsPerNode          pgtype.Float8      `json:"gflops_per_node"`
+	GflopsPerRank          pgtype.Float8      `json:"gflops_per_rank"`
+	SystemID               pgtype.UUID        `json:"system_id"`
+	RpeakGflops            pgtype.Float8      `json:"rpeak_gflops"`
+	Efficiency             pgtype.Float8      `json:"efficiency"`
+	ReviewStatus           string             `json:"review_status"`
+	ReviewReason           string             `json:"review_reason"`
+	ReviewedBy             string             `json:"reviewed_by"`
+	ReviewedAt             pgtype.Timestamptz `json:"reviewed_at"`
+	AvgPowerWatts          pgtype.Float8      `json:"avg_power_watts"`
+	EnergyJoules           pgtype.Float8      `json:"energy_joules"`
+	GflopsPerWatt          pgtype.Float8      `json:"gflops_per_watt"`
+	Benchmark              string             `json:"benchmark"`
+	HpcgNx                 int32              `json:"hpcg_nx"`
+	HpcgNy                 int32              `json:"hpcg_ny"`
+	HpcgNz                 int32              `json:"hpcg_nz"`
+	StreamCopyMbs          pgtype.Float8      `json:"stream_copy_mbs"`
+	StreamScaleMbs         pgtype.Float8      `json:"stream_scale_mbs"`
+	StreamAddMbs           pgtype.Float8      `json:"stream_add_mbs"`
+	StreamTriadMbs         pgtype.Float8      `json:"stream_triad_mbs"`
+	MxpPrecision           string             `json:"mxp_precision"`
+	Metric                 pgtype.Float8      `json:"metric"`
+}
+
+type Session struct {
+	ID         pgtype.UUID        `json:"id"`
+	FamilyID   pgtype.UUID        `json:"family_id"`
+	Username   string             `json:"username"`
+	UserAgent  string             `json:"user_agent"`
+	ClientIp   string             `json:"client_ip"`
+	IsRevoked  bool               `json:"is_revoked"`
+	RotatedAt  pgtype.Timestamptz `json:"rotated_at"`
+	ReplacedBy pgtype.UUID        `json:"replaced_by"`
+	ExpiresAt  time.Time          `json:"expires_at"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+type SigningKey struct {
+	ID        pgtype.UUID        `json:"id"`
+	Username  string             `json:"username"`
+	Name      string             `json:"name"`
+	Secret    string             `json:"secret"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type SshKey struct {
+	ID                 pgtype.UUID        `json:"id"`
+	Username           string             `json:"username"`
+	LinuxUsername      string             `json:"linux_username"`
+	PublicKey          string             `json:"public_key"`
+	Fingerprint        string             `json:"fingerprint"`
+	Challenge          string             `json:"challenge"`
+	ChallengeExpiresAt pgtype.Timestamptz `json:"challenge_expires_at"`
+	VerifiedAt         pgtype.Timestamptz `json:"verified_at"`
+	CreatedAt          time.Time          `json:"created_at"`
+}
+
+type System struct {
+	ID                       pgtype.UUID `json:"id"`
+	Name                     string      `json:"name"`
+	Nodes                    int32       `json:"nodes"`
+	CpuModel                 string      `json:"cpu_model"`
+	CoresPerNode             int32       `json:"cores_per_node"`
+	ClockGhz                 float64     `json:"clock_ghz"`
+	FlopsPerCycle            int32       `json:"flops_per_cycle"`
+	Accelerators             string      `json:"accelerators"`
+	AcceleratorGflopsPerNode float64     `json:"accelerator_gflops_per_node"`
+	Interconnect             string      `json:"interconnect"`
+	Mpi                      string      `json:"mpi"`
+	Blas                     string      `json:"blas"`
+	NodeRpeakGflops          float64     `json:"node_rpeak_gflops"`
+	RpeakGflops              float64     `json:"rpeak_gflops"`
+	CreatedBy                string      `json:"created_by"`
+	CreatedAt                time.Time   `json:"created_at"`
+	UpdatedAt                time.Time   `json:"updated_at"`
+}
+
+type Team struct {
+	ID        pgtype.UUID `json:"id"`
+	Name      string      `json:"name"`
+	CreatedBy string      `json:"created_by"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type TeamInvitation struct {
+	ID         pgtype.UUID        `json:"id"`
+	TeamID     pgtype.UUID        `json:"team_id"`
+	HashedCode string             `json:"hashed_code"`
+	CreatedBy  string             `json:"created_by"`
+	MaxUses    int32              `json:"max_uses"`
+	UseCount   int32              `json:"use_count"`
+	ExpiresAt  time.Time          `json:"expires_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+type TeamMember struct {
+	TeamID   pgtype.UUID `json:"team_id"`
+	Username string      `json:"username"`
+	Role     string      `json:"role"`
+	JoinedAt time.Time   `json:"joined_at"`
+}
+
+type User struct {
+	Username       string             `json:"username"`
+	HashedPassword string             `json:"hashed_password"`
+	CreatedAt      time.Time          `json:"created_at"`
+	Roles          []string           `json:"roles"`
+	TotpSecret     string             `json:"totp_secret"`
+	TotpEnabledAt  pgtype.Timestamptz `json:"totp_enabled_at"`
+	TotpLastStep   int64              `json:"totp_last_step"`
+}
+
+type UserTokenRevocation struct {
+	Username      string    `json:"username"`
+	RevokedBefore time.Time `json:"revoked_before"`
+	ExpiresAt     time.Time `json:"expires_at"`
 }
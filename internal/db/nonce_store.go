@@ -0,0 +1,32 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// PostgresNonceStore is a middleware.NonceStore shared by every API instance
+type PostgresNonceStore struct {
+	store Querier
+}
+
+// NewPostgresNonceStore creates a NonceStore backed by Postgres
+func NewPostgresNonceStore(store Querier) *PostgresNonceStore {
+	return &PostgresNonceStore{store: store}
+}
+
+func (store *PostgresNonceStore) UseNonce(ctx context.Context, keyID string, nonce string, expiresAt time.Time) (bool, error) {
+	rows, err := store.store.UseRequestNonce(ctx, UseRequestNonceParams{
+		KeyID:     keyID,
+		Nonce:     nonce,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+func (store *PostgresNonceStore) DeleteExpired(ctx context.Context) error {
+	return store.store.DeleteExpiredRequestNonces(ctx)
+}
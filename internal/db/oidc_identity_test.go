@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOIDCUserTx(t *testing.T) {
+	arg := CreateOIDCUserTxParams{
+		Issuer:   "https://idp.example.edu",
+		Subject:  uuid.NewString(),
+		Username: "oidc-" + uuid.NewString(),
+	}
+
+	user, err := testStore.CreateOIDCUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, arg.Username, user.Username)
+	assert.Empty(t, user.HashedPassword)
+
+	identity, err := testStore.GetOIDCIdentity(context.Background(), GetOIDCIdentityParams{
+		Issuer:  arg.Issuer,
+		Subject: arg.Subject,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, arg.Username, identity.Username)
+}
+
+func TestCreateOIDCUserTxUsernameTaken(t *testing.T) {
+	existing := createRandomUser(t)
+	subject := uuid.NewString()
+
+	// 使用者名稱已被本地帳號使用時，整個交易回滾，不會留下身分連結
+	_, err := testStore.CreateOIDCUserTx(context.Background(), CreateOIDCUserTxParams{
+		Issuer:   "https://idp.example.edu",
+		Subject:  subject,
+		Username: existing.Username,
+	})
+	var pgErr *pgconn.PgError
+	require.True(t, errors.As(err, &pgErr))
+	assert.Equal(t, "23505", pgErr.Code)
+
+	_, err = testStore.GetOIDCIdentity(context.Background(), GetOIDCIdentityParams{
+		Issuer:  "https://idp.example.edu",
+		Subject: subject,
+	})
+	assert.Error(t, err)
+}
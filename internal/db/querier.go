@@ -6,13 +6,104 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
-	CountTotalScores(ctx context.Context) (int64, error)
+	AddTeamMember(ctx context.Context, arg AddTeamMemberParams) (TeamMember, error)
+	AddUserRole(ctx context.Context, arg AddUserRoleParams) error
+	ClaimLinuxAccount(ctx context.Context, arg ClaimLinuxAccountParams) (int64, error)
+	ClearLoginFailures(ctx context.Context, arg ClearLoginFailuresParams) (int64, error)
+	CountGreenScores(ctx context.Context, arg CountGreenScoresParams) (int64, error)
+	CountLeaderboardTeams(ctx context.Context, residualFilter string) (int64, error)
+	CountPendingScores(ctx context.Context) (int64, error)
+	CountSystems(ctx context.Context) (int64, error)
+	CountTotalScores(ctx context.Context, arg CountTotalScoresParams) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error)
+	CreateOIDCIdentity(ctx context.Context, arg CreateOIDCIdentityParams) (OidcIdentity, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
+	CreateRunNonce(ctx context.Context, arg CreateRunNonceParams) (RunNonce, error)
+	CreateSSHKey(ctx context.Context, arg CreateSSHKeyParams) (SshKey, error)
 	CreateScore(ctx context.Context, arg CreateScoreParams) (Score, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateSigningKey(ctx context.Context, arg CreateSigningKeyParams) (SigningKey, error)
+	CreateSystem(ctx context.Context, arg CreateSystemParams) (System, error)
+	CreateTeam(ctx context.Context, arg CreateTeamParams) (Team, error)
+	CreateTeamInvitation(ctx context.Context, arg CreateTeamInvitationParams) (TeamInvitation, error)
+	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeleteExpiredRequestNonces(ctx context.Context) error
+	DeleteExpiredRevokedTokens(ctx context.Context) error
+	DeleteExpiredUserTokenRevocations(ctx context.Context) error
+	DeleteLinuxAccount(ctx context.Context, linuxUsername string) (int64, error)
+	DeleteRecoveryCodes(ctx context.Context, username string) error
+	DeleteSSHKey(ctx context.Context, arg DeleteSSHKeyParams) (int64, error)
+	DeleteScore(ctx context.Context, id pgtype.UUID) (int64, error)
+	DeleteSystem(ctx context.Context, id pgtype.UUID) (int64, error)
+	DeleteTeam(ctx context.Context, id pgtype.UUID) (int64, error)
+	DisableTOTP(ctx context.Context, username string) error
+	DisqualifyScore(ctx context.Context, arg DisqualifyScoreParams) (Score, error)
+	EnableTOTP(ctx context.Context, username string) (int64, error)
+	GetActiveSigningKey(ctx context.Context, id pgtype.UUID) (SigningKey, error)
+	GetLoginFailures(ctx context.Context, arg GetLoginFailuresParams) ([]LoginFailure, error)
+	GetOIDCIdentity(ctx context.Context, arg GetOIDCIdentityParams) (OidcIdentity, error)
+	GetRunNonce(ctx context.Context, nonce string) (RunNonce, error)
+	GetSSHKey(ctx context.Context, arg GetSSHKeyParams) (SshKey, error)
+	GetScore(ctx context.Context, id pgtype.UUID) (Score, error)
+	GetSession(ctx context.Context, id pgtype.UUID) (Session, error)
+	GetSystem(ctx context.Context, id pgtype.UUID) (System, error)
+	GetSystemMaxScoreNodes(ctx context.Context, systemID pgtype.UUID) (int32, error)
+	GetTeam(ctx context.Context, id pgtype.UUID) (Team, error)
+	GetTeamMember(ctx context.Context, arg GetTeamMemberParams) (TeamMember, error)
+	GetUser(ctx context.Context, username string) (User, error)
+	IsLinuxAccountVerified(ctx context.Context, arg IsLinuxAccountVerifiedParams) (bool, error)
+	IsTokenRevoked(ctx context.Context, arg IsTokenRevokedParams) (bool, error)
+	ListAPIKeys(ctx context.Context, username string) ([]ApiKey, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListGreenScores(ctx context.Context, arg ListGreenScoresParams) ([]Score, error)
+	ListPendingScores(ctx context.Context, arg ListPendingScoresParams) ([]Score, error)
+	ListSSHKeys(ctx context.Context, username string) ([]SshKey, error)
+	ListScoresAfter(ctx context.Context, arg ListScoresAfterParams) ([]Score, error)
 	ListScoresWithPagination(ctx context.Context, arg ListScoresWithPaginationParams) ([]Score, error)
+	ListSigningKeys(ctx context.Context, username string) ([]SigningKey, error)
+	ListSystemScoresForReview(ctx context.Context, systemID pgtype.UUID) ([]Score, error)
+	ListSystems(ctx context.Context, arg ListSystemsParams) ([]System, error)
+	ListTeamInvitations(ctx context.Context, teamID pgtype.UUID) ([]TeamInvitation, error)
+	ListTeamLeaderboard(ctx context.Context, arg ListTeamLeaderboardParams) ([]ListTeamLeaderboardRow, error)
+	ListTeamMembers(ctx context.Context, teamID pgtype.UUID) ([]TeamMember, error)
 	ListTopScores(ctx context.Context, arg ListTopScoresParams) ([]Score, error)
+	ListUserTeams(ctx context.Context, username string) ([]ListUserTeamsRow, error)
+	LockLogin(ctx context.Context, arg LockLoginParams) error
+	LockTeamOwners(ctx context.Context, teamID pgtype.UUID) ([]string, error)
+	MarkSSHKeyVerified(ctx context.Context, arg MarkSSHKeyVerifiedParams) (SshKey, error)
+	MarkSessionRotated(ctx context.Context, arg MarkSessionRotatedParams) (int64, error)
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginFailure, error)
+	RemoveTeamMember(ctx context.Context, arg RemoveTeamMemberParams) (int64, error)
+	ReviewScore(ctx context.Context, arg ReviewScoreParams) (Score, error)
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
+	RevokeSessionFamily(ctx context.Context, familyID pgtype.UUID) error
+	RevokeSigningKey(ctx context.Context, arg RevokeSigningKeyParams) (int64, error)
+	RevokeTeamInvitation(ctx context.Context, arg RevokeTeamInvitationParams) (int64, error)
+	RevokeToken(ctx context.Context, arg RevokeTokenParams) error
+	RevokeUserAPIKeys(ctx context.Context, username string) error
+	RevokeUserSessions(ctx context.Context, username string) error
+	RevokeUserSigningKeys(ctx context.Context, username string) error
+	RevokeUserTokens(ctx context.Context, arg RevokeUserTokensParams) error
+	SetSSHKeyChallenge(ctx context.Context, arg SetSSHKeyChallengeParams) (int64, error)
+	SetScoreGflopsCheck(ctx context.Context, arg SetScoreGflopsCheckParams) error
+	SetScoreReviewStatus(ctx context.Context, arg SetScoreReviewStatusParams) error
+	SetTOTPSecret(ctx context.Context, arg SetTOTPSecretParams) (int64, error)
+	UpdateSystem(ctx context.Context, arg UpdateSystemParams) (System, error)
+	UpdateSystemScoresRpeak(ctx context.Context, arg UpdateSystemScoresRpeakParams) error
+	UpdateTeamMemberRole(ctx context.Context, arg UpdateTeamMemberRoleParams) (TeamMember, error)
+	UpdateUserRoles(ctx context.Context, arg UpdateUserRolesParams) (User, error)
+	UseAPIKey(ctx context.Context, hashedKey string) (ApiKey, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
+	UseRequestNonce(ctx context.Context, arg UseRequestNonceParams) (int64, error)
+	UseRunNonce(ctx context.Context, arg UseRunNonceParams) (int64, error)
+	UseTOTPStep(ctx context.Context, arg UseTOTPStepParams) (int64, error)
+	UseTeamInvitation(ctx context.Context, hashedCode string) (TeamInvitation, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,34 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (
+  username,
+  name,
+  prefix,
+  hashed_key,
+  scopes,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListAPIKeys :many
+SELECT * FROM api_keys
+WHERE username = $1
+ORDER BY created_at DESC;
+
+-- name: UseAPIKey :one
+UPDATE api_keys
+SET last_used_at = now()
+WHERE hashed_key = $1
+  AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > now())
+RETURNING *;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = now()
+WHERE id = $1 AND username = $2 AND revoked_at IS NULL;
+
+-- name: RevokeUserAPIKeys :exec
+UPDATE api_keys
+SET revoked_at = now()
+WHERE username = $1 AND revoked_at IS NULL;
@@ -0,0 +1,16 @@
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (
+  event,
+  actor,
+  subject,
+  client_ip,
+  details
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListAuditEvents :many
+SELECT * FROM audit_events
+ORDER BY created_at DESC, id DESC
+LIMIT $1
+OFFSET $2;
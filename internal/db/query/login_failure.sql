@@ -0,0 +1,30 @@
+-- name: GetLoginFailures :many
+SELECT * FROM login_failures
+WHERE (scope = 'account' AND subject = sqlc.arg(username))
+   OR (scope = 'ip' AND subject = sqlc.arg(client_ip));
+
+-- name: RecordLoginFailure :one
+INSERT INTO login_failures (
+  scope,
+  subject,
+  failures,
+  last_failure_at
+) VALUES (
+  sqlc.arg(scope), sqlc.arg(subject), 1, now()
+)
+ON CONFLICT (scope, subject) DO UPDATE
+SET failures = CASE
+      WHEN login_failures.last_failure_at < sqlc.arg(reset_before) THEN 1
+      ELSE login_failures.failures + 1
+    END,
+    last_failure_at = now()
+RETURNING *;
+
+-- name: LockLogin :exec
+UPDATE login_failures
+SET locked_until = sqlc.arg(locked_until)
+WHERE scope = sqlc.arg(scope) AND subject = sqlc.arg(subject);
+
+-- name: ClearLoginFailures :execrows
+DELETE FROM login_failures
+WHERE scope = $1 AND subject = $2;
@@ -0,0 +1,36 @@
+-- name: SetTOTPSecret :execrows
+UPDATE users
+SET totp_secret = $2, totp_last_step = 0
+WHERE username = $1 AND totp_enabled_at IS NULL;
+
+-- name: EnableTOTP :execrows
+UPDATE users
+SET totp_enabled_at = now()
+WHERE username = $1 AND totp_secret <> '' AND totp_enabled_at IS NULL;
+
+-- name: DisableTOTP :exec
+UPDATE users
+SET totp_secret = '', totp_enabled_at = NULL, totp_last_step = 0
+WHERE username = $1;
+
+-- name: UseTOTPStep :execrows
+UPDATE users
+SET totp_last_step = sqlc.arg(step)
+WHERE username = sqlc.arg(username) AND totp_last_step < sqlc.arg(step);
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO mfa_recovery_codes (
+  username,
+  hashed_code
+) VALUES (
+  $1, $2
+);
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE username = $1;
+
+-- name: UseRecoveryCode :execrows
+UPDATE mfa_recovery_codes
+SET used_at = now()
+WHERE username = $1 AND hashed_code = $2 AND used_at IS NULL;
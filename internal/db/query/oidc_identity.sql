@@ -0,0 +1,12 @@
+-- name: GetOIDCIdentity :one
+SELECT * FROM oidc_identities
+WHERE issuer = $1 AND subject = $2 LIMIT 1;
+
+-- name: CreateOIDCIdentity :one
+INSERT INTO oidc_identities (
+  issuer,
+  subject,
+  username
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
@@ -0,0 +1,17 @@
+-- name: CreateRunNonce :one
+INSERT INTO run_nonces (
+  nonce,
+  username,
+  expires_at
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetRunNonce :one
+SELECT * FROM run_nonces
+WHERE nonce = $1 LIMIT 1;
+
+-- name: UseRunNonce :execrows
+UPDATE run_nonces
+SET used_at = now()
+WHERE nonce = $1 AND username = $2 AND used_at IS NULL AND expires_at > now();
@@ -10,21 +10,151 @@ INSERT INTO scores (
   p,
   q,
   execution_time,
-  submitted_at
+  submitted_at,
+  linux_username_verified,
+  client_identity,
+  team_id,
+  run_nonce,
+  expected_gflops,
+  variant,
+  residual,
+  residual_threshold,
+  residual_passed,
+  ranks,
+  nodes,
+  ranks_per_node,
+  system_id,
+  rpeak_gflops,
+  review_status,
+  review_reason,
+  avg_power_watts,
+  energy_joules,
+  benchmark,
+  hpcg_nx,
+  hpcg_ny,
+  hpcg_nz,
+  stream_copy_mbs,
+  stream_scale_mbs,
+  stream_add_mbs,
+  stream_triad_mbs,
+  mxp_precision
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+  $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38
 ) RETURNING *;
 
 -- name: ListTopScores :many
 SELECT * FROM scores
-ORDER BY gflops DESC
-LIMIT $1 OFFSET $2;
+WHERE disqualified_at IS NULL AND review_status = 'accepted' AND benchmark = sqlc.arg(benchmark)::text
+  AND CASE sqlc.arg(residual_filter)::text
+    WHEN 'all' THEN true
+    WHEN 'passed' THEN residual_passed IS TRUE
+    WHEN 'failed' THEN residual_passed IS FALSE
+    ELSE residual_passed IS DISTINCT FROM false
+  END
+ORDER BY CASE sqlc.arg(sort_by)::text
+    WHEN 'gflops_per_node' THEN gflops_per_node
+    WHEN 'gflops_per_rank' THEN gflops_per_rank
+    ELSE metric
+  END DESC NULLS LAST, metric DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: ListScoresWithPagination :many
 SELECT * FROM scores
-WHERE ($1::uuid IS NULL OR id < $1)
+WHERE ($1::uuid IS NULL OR id < $1) AND disqualified_at IS NULL AND benchmark = 'hpl'
 ORDER BY gflops DESC, id DESC
 LIMIT $2;
 
 -- name: CountTotalScores :one
-SELECT COUNT(*) FROM scores;
\ No newline at end of file
+SELECT COUNT(*) FROM scores
+WHERE disqualified_at IS NULL AND review_status = 'accepted' AND benchmark = sqlc.arg(benchmark)::text
+  AND CASE sqlc.arg(residual_filter)::text
+    WHEN 'all' THEN true
+    WHEN 'passed' THEN residual_passed IS TRUE
+    WHEN 'failed' THEN residual_passed IS FALSE
+    ELSE residual_passed IS DISTINCT FROM false
+  END;
+
+-- name: GetScore :one
+SELECT * FROM scores
+WHERE id = $1 LIMIT 1;
+
+-- name: DeleteScore :execrows
+DELETE FROM scores
+WHERE id = $1;
+
+-- name: DisqualifyScore :one
+UPDATE scores
+SET disqualified_at = now(),
+    disqualified_by = sqlc.arg(disqualified_by),
+    disqualification_reason = sqlc.arg(disqualification_reason)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListScoresAfter :many
+SELECT * FROM scores
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
+
+-- name: SetScoreGflopsCheck :exec
+UPDATE scores
+SET expected_gflops = $2,
+    gflops_flagged = $3
+WHERE id = $1;
+
+-- name: ListPendingScores :many
+SELECT * FROM scores
+WHERE review_status = 'pending'
+ORDER BY submitted_at
+LIMIT $1 OFFSET $2;
+
+-- name: CountPendingScores :one
+SELECT COUNT(*) FROM scores
+WHERE review_status = 'pending';
+
+-- name: ReviewScore :one
+UPDATE scores
+SET review_status = sqlc.arg(review_status),
+    review_reason = sqlc.arg(review_reason),
+    reviewed_by = sqlc.arg(reviewed_by),
+    reviewed_at = now()
+WHERE id = sqlc.arg(id) AND review_status = 'pending'
+RETURNING *;
+
+-- name: ListSystemScoresForReview :many
+SELECT * FROM scores
+WHERE system_id = $1 AND review_status <> 'rejected'
+ORDER BY submitted_at;
+
+-- name: SetScoreReviewStatus :exec
+UPDATE scores
+SET review_status = $2,
+    review_reason = $3,
+    reviewed_by = '',
+    reviewed_at = NULL
+WHERE id = $1;
+
+-- name: ListGreenScores :many
+SELECT * FROM scores
+WHERE disqualified_at IS NULL AND review_status = 'accepted' AND benchmark = 'hpl'
+  AND gflops_per_watt IS NOT NULL AND gflops >= sqlc.arg(min_gflops)::float8
+  AND CASE sqlc.arg(residual_filter)::text
+    WHEN 'all' THEN true
+    WHEN 'passed' THEN residual_passed IS TRUE
+    WHEN 'failed' THEN residual_passed IS FALSE
+    ELSE residual_passed IS DISTINCT FROM false
+  END
+ORDER BY gflops_per_watt DESC, gflops DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountGreenScores :one
+SELECT COUNT(*) FROM scores
+WHERE disqualified_at IS NULL AND review_status = 'accepted' AND benchmark = 'hpl'
+  AND gflops_per_watt IS NOT NULL AND gflops >= sqlc.arg(min_gflops)::float8
+  AND CASE sqlc.arg(residual_filter)::text
+    WHEN 'all' THEN true
+    WHEN 'passed' THEN residual_passed IS TRUE
+    WHEN 'failed' THEN residual_passed IS FALSE
+    ELSE residual_passed IS DISTINCT FROM false
+  END;
@@ -0,0 +1,30 @@
+-- name: CreateSession :one
+INSERT INTO sessions (
+  id,
+  family_id,
+  username,
+  user_agent,
+  client_ip,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetSession :one
+SELECT * FROM sessions
+WHERE id = $1 LIMIT 1;
+
+-- name: MarkSessionRotated :execrows
+UPDATE sessions
+SET rotated_at = now(), replaced_by = sqlc.arg(replaced_by)
+WHERE id = sqlc.arg(id) AND rotated_at IS NULL AND NOT is_revoked;
+
+-- name: RevokeSessionFamily :exec
+UPDATE sessions
+SET is_revoked = true
+WHERE family_id = $1;
+
+-- name: RevokeUserSessions :exec
+UPDATE sessions
+SET is_revoked = true
+WHERE username = $1;
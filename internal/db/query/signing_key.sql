@@ -0,0 +1,40 @@
+-- name: CreateSigningKey :one
+INSERT INTO signing_keys (
+  username,
+  name,
+  secret
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: ListSigningKeys :many
+SELECT * FROM signing_keys
+WHERE username = $1
+ORDER BY created_at DESC;
+
+-- name: GetActiveSigningKey :one
+SELECT * FROM signing_keys
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeSigningKey :execrows
+UPDATE signing_keys
+SET revoked_at = now()
+WHERE id = $1 AND username = $2 AND revoked_at IS NULL;
+
+-- name: RevokeUserSigningKeys :exec
+UPDATE signing_keys
+SET revoked_at = now()
+WHERE username = $1 AND revoked_at IS NULL;
+
+-- name: UseRequestNonce :execrows
+INSERT INTO request_nonces (
+  key_id,
+  nonce,
+  expires_at
+) VALUES (
+  $1, $2, $3
+) ON CONFLICT (key_id, nonce) DO NOTHING;
+
+-- name: DeleteExpiredRequestNonces :exec
+DELETE FROM request_nonces
+WHERE expires_at < now();
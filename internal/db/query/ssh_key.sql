@@ -0,0 +1,54 @@
+-- name: CreateSSHKey :one
+INSERT INTO ssh_keys (
+  username,
+  linux_username,
+  public_key,
+  fingerprint
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListSSHKeys :many
+SELECT * FROM ssh_keys
+WHERE username = $1
+ORDER BY created_at DESC;
+
+-- name: GetSSHKey :one
+SELECT * FROM ssh_keys
+WHERE id = $1 AND username = $2 LIMIT 1;
+
+-- name: DeleteSSHKey :execrows
+DELETE FROM ssh_keys
+WHERE id = $1 AND username = $2;
+
+-- name: SetSSHKeyChallenge :execrows
+UPDATE ssh_keys
+SET challenge = $3, challenge_expires_at = $4
+WHERE id = $1 AND username = $2;
+
+-- name: MarkSSHKeyVerified :one
+UPDATE ssh_keys
+SET verified_at = now(), challenge = '', challenge_expires_at = NULL
+WHERE id = $1 AND username = $2 AND challenge = $3 AND challenge_expires_at > now()
+RETURNING *;
+
+-- name: ClaimLinuxAccount :execrows
+INSERT INTO linux_accounts (
+  linux_username,
+  username
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (linux_username) DO UPDATE
+SET verified_at = now()
+WHERE linux_accounts.username = EXCLUDED.username;
+
+-- name: IsLinuxAccountVerified :one
+SELECT EXISTS (
+  SELECT 1 FROM linux_accounts
+  WHERE linux_username = $1 AND username = $2
+);
+
+-- name: DeleteLinuxAccount :execrows
+DELETE FROM linux_accounts
+WHERE linux_username = $1;
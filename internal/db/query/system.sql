@@ -0,0 +1,59 @@
+-- name: CreateSystem :one
+INSERT INTO systems (
+  name,
+  nodes,
+  cpu_model,
+  cores_per_node,
+  clock_ghz,
+  flops_per_cycle,
+  accelerators,
+  accelerator_gflops_per_node,
+  interconnect,
+  mpi,
+  blas,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING *;
+
+-- name: GetSystem :one
+SELECT * FROM systems
+WHERE id = $1 LIMIT 1;
+
+-- name: ListSystems :many
+SELECT * FROM systems
+ORDER BY name
+LIMIT $1 OFFSET $2;
+
+-- name: CountSystems :one
+SELECT COUNT(*) FROM systems;
+
+-- name: UpdateSystem :one
+UPDATE systems
+SET name = $2,
+    nodes = $3,
+    cpu_model = $4,
+    cores_per_node = $5,
+    clock_ghz = $6,
+    flops_per_cycle = $7,
+    accelerators = $8,
+    accelerator_gflops_per_node = $9,
+    interconnect = $10,
+    mpi = $11,
+    blas = $12,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteSystem :execrows
+DELETE FROM systems
+WHERE id = $1;
+
+-- name: UpdateSystemScoresRpeak :exec
+UPDATE scores
+SET rpeak_gflops = nodes * sqlc.arg(node_rpeak_gflops)::float8
+WHERE system_id = sqlc.arg(system_id) AND benchmark <> 'stream';
+
+-- name: GetSystemMaxScoreNodes :one
+SELECT COALESCE(MAX(nodes), 0)::int AS max_nodes FROM scores
+WHERE system_id = $1;
@@ -0,0 +1,115 @@
+-- name: CreateTeam :one
+INSERT INTO teams (
+  name,
+  created_by
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: GetTeam :one
+SELECT * FROM teams
+WHERE id = $1 LIMIT 1;
+
+-- name: DeleteTeam :execrows
+DELETE FROM teams
+WHERE id = $1;
+
+-- name: ListUserTeams :many
+SELECT teams.id, teams.name, teams.created_by, teams.created_at, team_members.role
+FROM teams
+JOIN team_members ON team_members.team_id = teams.id
+WHERE team_members.username = $1
+ORDER BY teams.name;
+
+-- name: AddTeamMember :one
+INSERT INTO team_members (
+  team_id,
+  username,
+  role
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetTeamMember :one
+SELECT * FROM team_members
+WHERE team_id = $1 AND username = $2 LIMIT 1;
+
+-- name: ListTeamMembers :many
+SELECT * FROM team_members
+WHERE team_id = $1
+ORDER BY joined_at;
+
+-- name: UpdateTeamMemberRole :one
+UPDATE team_members
+SET role = $3
+WHERE team_id = $1 AND username = $2
+RETURNING *;
+
+-- name: RemoveTeamMember :execrows
+DELETE FROM team_members
+WHERE team_id = $1 AND username = $2;
+
+-- name: LockTeamOwners :many
+SELECT username FROM team_members
+WHERE team_id = $1 AND role = 'owner'
+FOR UPDATE;
+
+-- name: CreateTeamInvitation :one
+INSERT INTO team_invitations (
+  team_id,
+  hashed_code,
+  created_by,
+  max_uses,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListTeamInvitations :many
+SELECT * FROM team_invitations
+WHERE team_id = $1
+ORDER BY created_at DESC;
+
+-- name: UseTeamInvitation :one
+UPDATE team_invitations
+SET use_count = use_count + 1
+WHERE hashed_code = $1
+  AND revoked_at IS NULL
+  AND expires_at > now()
+  AND use_count < max_uses
+RETURNING *;
+
+-- name: RevokeTeamInvitation :execrows
+UPDATE team_invitations
+SET revoked_at = now()
+WHERE id = $1 AND team_id = $2 AND revoked_at IS NULL;
+
+-- name: ListTeamLeaderboard :many
+SELECT
+  teams.id,
+  teams.name,
+  MAX(scores.gflops)::float8 AS best_gflops,
+  (ARRAY_AGG(scores.id ORDER BY scores.gflops DESC))[1]::uuid AS best_score_id,
+  COUNT(scores.id)::bigint AS submissions
+FROM teams
+JOIN scores ON scores.team_id = teams.id
+WHERE scores.disqualified_at IS NULL AND scores.review_status = 'accepted' AND scores.benchmark = 'hpl'
+  AND CASE sqlc.arg(residual_filter)::text
+    WHEN 'all' THEN true
+    WHEN 'passed' THEN scores.residual_passed IS TRUE
+    WHEN 'failed' THEN scores.residual_passed IS FALSE
+    ELSE scores.residual_passed IS DISTINCT FROM false
+  END
+GROUP BY teams.id, teams.name
+ORDER BY best_gflops DESC, teams.name
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountLeaderboardTeams :one
+SELECT COUNT(DISTINCT team_id)::bigint AS count FROM scores
+WHERE team_id IS NOT NULL AND disqualified_at IS NULL AND review_status = 'accepted' AND benchmark = 'hpl'
+  AND CASE sqlc.arg(residual_filter)::text
+    WHEN 'all' THEN true
+    WHEN 'passed' THEN residual_passed IS TRUE
+    WHEN 'failed' THEN residual_passed IS FALSE
+    ELSE residual_passed IS DISTINCT FROM false
+  END;
@@ -0,0 +1,22 @@
+-- name: CreateUser :one
+INSERT INTO users (
+  username,
+  hashed_password
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: GetUser :one
+SELECT * FROM users
+WHERE username = $1 LIMIT 1;
+
+-- name: UpdateUserRoles :one
+UPDATE users
+SET roles = sqlc.arg(roles)
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: AddUserRole :exec
+UPDATE users
+SET roles = array_append(roles, sqlc.arg(role)::varchar)
+WHERE username = sqlc.arg(username) AND NOT (sqlc.arg(role)::varchar = ANY(roles));
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+)
+
+// PostgresRevocationStore is a token.RevocationStore shared by every API instance
+type PostgresRevocationStore struct {
+	store Querier
+}
+
+// NewPostgresRevocationStore creates a RevocationStore backed by Postgres
+func NewPostgresRevocationStore(store Querier) *PostgresRevocationStore {
+	return &PostgresRevocationStore{store: store}
+}
+
+func (store *PostgresRevocationStore) RevokeToken(ctx context.Context, id uuid.UUID, username string, expiresAt time.Time) error {
+	return store.store.RevokeToken(ctx, RevokeTokenParams{
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+		Username:  username,
+		ExpiresAt: expiresAt,
+	})
+}
+
+func (store *PostgresRevocationStore) RevokeUserTokens(ctx context.Context, username string, revokedBefore time.Time, expiresAt time.Time) error {
+	return store.store.RevokeUserTokens(ctx, RevokeUserTokensParams{
+		Username:      username,
+		RevokedBefore: revokedBefore,
+		ExpiresAt:     expiresAt,
+	})
+}
+
+func (store *PostgresRevocationStore) IsRevoked(ctx context.Context, payload *token.Payload) (bool, error) {
+	return store.store.IsTokenRevoked(ctx, IsTokenRevokedParams{
+		ID:       pgtype.UUID{Bytes: payload.ID, Valid: true},
+		Username: payload.Username,
+		IssuedAt: payload.IssuedAt,
+	})
+}
+
+func (store *PostgresRevocationStore) DeleteExpired(ctx context.Context) error {
+	if err := store.store.DeleteExpiredRevokedTokens(ctx); err != nil {
+		return err
+	}
+	return store.store.DeleteExpiredUserTokenRevocations(ctx)
+}
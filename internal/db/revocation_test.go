@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeToken(t *testing.T) {
+	id := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	username := "user-" + uuid.NewString()[:8]
+
+	arg := IsTokenRevokedParams{ID: id, Username: username, IssuedAt: time.Now()}
+	revoked, err := testStore.IsTokenRevoked(context.Background(), arg)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	err = testStore.RevokeToken(context.Background(), RevokeTokenParams{
+		ID:        id,
+		Username:  username,
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	revoked, err = testStore.IsTokenRevoked(context.Background(), arg)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestRevokeUserTokens(t *testing.T) {
+	username := "user-" + uuid.NewString()[:8]
+	revokedBefore := time.Now()
+
+	err := testStore.RevokeUserTokens(context.Background(), RevokeUserTokensParams{
+		Username:      username,
+		RevokedBefore: revokedBefore,
+		ExpiresAt:     time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	revoked, err := testStore.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		ID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Username: username,
+		IssuedAt: revokedBefore.Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = testStore.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		ID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Username: username,
+		IssuedAt: revokedBefore.Add(time.Minute),
+	})
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestDeleteExpiredRevokedTokens(t *testing.T) {
+	id := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	err := testStore.RevokeToken(context.Background(), RevokeTokenParams{
+		ID:        id,
+		Username:  "expired-user",
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, testStore.DeleteExpiredRevokedTokens(context.Background()))
+
+	revoked, err := testStore.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		ID:       id,
+		Username: "expired-user",
+		IssuedAt: time.Now(),
+	})
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomRunNonce(t *testing.T, username string, expiresAt time.Time) RunNonce {
+	runNonce, err := testStore.CreateRunNonce(context.Background(), CreateRunNonceParams{
+		Nonce:     "hplr_" + uuid.NewString(),
+		Username:  username,
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+	assert.False(t, runNonce.UsedAt.Valid)
+
+	return runNonce
+}
+
+func runScoresTxParams(username string, nonce string, gflops ...float64) CreateScoresTxParams {
+	arg := CreateScoresTxParams{RunNonce: nonce, Username: username}
+	for _, g := range gflops {
+		arg.Scores = append(arg.Scores, CreateScoreParams{
+			UserID:      username,
+			Gflops:      g,
+			SubmittedAt: time.Now(),
+			RunNonce:    nonce,
+		})
+	}
+	return arg
+}
+
+func TestCreateScoresTx(t *testing.T) {
+	user := createRandomUser(t)
+	runNonce := createRandomRunNonce(t, user.Username, time.Now().Add(time.Hour))
+
+	arg := runScoresTxParams(user.Username, runNonce.Nonce, 123.45, 234.56)
+	scores, err := testStore.CreateScoresTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, scores, 2)
+	assert.Equal(t, runNonce.Nonce, scores[0].RunNonce)
+	assert.Equal(t, 234.56, scores[1].Gflops)
+
+	used, err := testStore.GetRunNonce(context.Background(), runNonce.Nonce)
+	require.NoError(t, err)
+	assert.True(t, used.UsedAt.Valid)
+
+	// 同一個 nonce 只能用在一次送出
+	_, err = testStore.CreateScoresTx(context.Background(), arg)
+	assert.ErrorIs(t, err, ErrRunNonceInvalid)
+}
+
+func TestCreateScoresTxRejectsOtherUsersNonce(t *testing.T) {
+	runNonce := createRandomRunNonce(t, createRandomUser(t).Username, time.Now().Add(time.Hour))
+
+	_, err := testStore.CreateScoresTx(context.Background(), runScoresTxParams(createRandomUser(t).Username, runNonce.Nonce, 123.45))
+	assert.ErrorIs(t, err, ErrRunNonceInvalid)
+}
+
+func TestCreateScoresTxExpiredNonce(t *testing.T) {
+	user := createRandomUser(t)
+	runNonce := createRandomRunNonce(t, user.Username, time.Now().Add(-time.Second))
+
+	_, err := testStore.CreateScoresTx(context.Background(), runScoresTxParams(user.Username, runNonce.Nonce, 123.45))
+	assert.ErrorIs(t, err, ErrRunNonceInvalid)
+}
@@ -12,12 +12,60 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countGreenScores = `-- name: CountGreenScores :one
+SELECT COUNT(*) FROM scores
+WHERE disqualified_at IS NULL AND review_status = 'accepted' AND benchmark = 'hpl'
+  AND gflops_per_watt IS NOT NULL AND gflops >= $1::float8
+  AND CASE $2::text
+    WHEN 'all' THEN true
+    WHEN 'passed' THEN residual_passed IS TRUE
+    WHEN 'failed' THEN residual_passed IS FALSE
+    ELSE residual_passed IS DISTINCT FROM false
+  END
+`
+
+type CountGreenScoresParams struct {
+	MinGflops      float64 `json:"min_gflops"`
+	ResidualFilter string  `json:"residual_filter"`
+}
+
+func (q *Queries) CountGreenScores(ctx context.Context, arg CountGreenScoresParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countGreenScores, arg.MinGflops, arg.ResidualFilter)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPendingScores = `-- name: CountPendingScores :one
+SELECT COUNT(*) FROM scores
+WHERE review_status = 'pending'
+`
+
+func (q *Queries) CountPendingScores(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countPendingScores)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countTotalScores = `-- name: CountTotalScores :one
 SELECT COUNT(*) FROM scores
+WHERE disqualified_at IS NULL AND review_status = 'accepted' AND benchmark = $1::text
+  AND CASE $2::text
+    WHEN 'all' THEN true
+    WHEN 'passed' THEN residual_passed IS TRUE
+    WHEN 'failed' THEN residual_passed IS FALSE
+    ELSE residual_passed IS DISTINCT FROM false
+  END
 `
 
-func (q *Queries) CountTotalScores(ctx context.Context) (int64, error) {
-	row := q.db.QueryRow(ctx, countTotalScores)
+type CountTotalScoresParams struct {
+	Benchmark      string `json:"benchmark"`
+	ResidualFilter string `json:"residual_filter"`
+}
+
+func (q *Queries) CountTotalScores(ctx context.Context, arg CountTotalScoresParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTotalScores, arg.Benchmark, arg.ResidualFilter)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -35,24 +83,79 @@ INSERT INTO scores (
   p,
   q,
   execution_time,
-  submitted_at
+  submitted_at,
+  linux_username_verified,
+  client_identity,
+  team_id,
+  run_nonce,
+  expected_gflops,
+  variant,
+  residual,
+  residual_threshold,
+  residual_passed,
+  ranks,
+  nodes,
+  ranks_per_node,
+  system_id,
+  rpeak_gflops,
+  review_status,
+  review_reason,
+  avg_power_watts,
+  energy_joules,
+  benchmark,
+  hpcg_nx,
+  hpcg_ny,
+  hpcg_nz,
+  stream_copy_mbs,
+  stream_scale_mbs,
+  stream_add_mbs,
+  stream_triad_mbs,
+  mxp_precision
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
-) RETURNING id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+  $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38
+) RETURNING id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time, disqualified_at, disqualified_by, disqualification_reason, linux_username_verified, client_identity, team_id, run_nonce, expected_gflops, gflops_flagged, variant, residual, residual_threshold, residual_passed, ranks, nodes, ranks_per_node, gflops_per_node, gflops_per_rank, system_id, rpeak_gflops, efficiency, review_status, review_reason, reviewed_by, reviewed_at, avg_power_watts, energy_joules, gflops_per_watt, benchmark, hpcg_nx, hpcg_ny, hpcg_nz, stream_copy_mbs, stream_scale_mbs, stream_add_mbs, stream_triad_mbs, mxp_precision, metric
 `
 
 type CreateScoreParams struct {
-	UserID        string    `json:"user_id"`
-	Gflops        float64   `json:"gflops"`
-	ProblemSizeN  int32     `json:"problem_size_n"`
-	BlockSizeNb   int32     `json:"block_size_nb"`
-	LinuxUsername string    `json:"linux_username"`
-	N             int32     `json:"n"`
-	Nb            int32     `json:"nb"`
-	P             int32     `json:"p"`
-	Q             int32     `json:"q"`
-	ExecutionTime float64   `json:"execution_time"`
-	SubmittedAt   time.Time `json:"submitted_at"`
+	UserID                string        `json:"user_id"`
+	Gflops                float64       `json:"gflops"`
+	ProblemSizeN          int32         `json:"problem_size_n"`
+	BlockSizeNb           int32         `json:"block_size_nb"`
+	LinuxUsername         string        `json:"linux_username"`
+	N                     int32         `json:"n"`
+	Nb                    int32         `json:"nb"`
+	P                     int32         `json:"p"`
+	Q                     int32         `json:"q"`
+	ExecutionTime         float64       `json:"execution_time"`
+	SubmittedAt           time.Time     `json:"submitted_at"`
+	LinuxUsernameVerified bool          `json:"linux_username_verified"`
+	ClientIdentity        string        `json:"client_identity"`
+	TeamID                pgtype.UUID   `json:"team_id"`
+	RunNonce              string        `json:"run_nonce"`
+	ExpectedGflops        float64       `json:"expected_gflops"`
+	Variant               string        `json:"variant"`
+	Residual              pgtype.Float8 `json:"residual"`
+	ResidualThreshold     pgtype.Float8 `json:"residual_threshold"`
+	ResidualPassed        pgtype.Bool   `json:"residual_passed"`
+	Ranks                 int32         `json:"ranks"`
+	Nodes                 int32         `json:"nodes"`
+	RanksPerNode          int32         `json:"ranks_per_node"`
+	SystemID              pgtype.UUID   `json:"system_id"`
+	RpeakGflops           pgtype.Float8 `json:"rpeak_gflops"`
+	ReviewStatus          string        `json:"review_status"`
+	ReviewReason          string        `json:"review_reason"`
+	AvgPowerWatts         pgtype.Float8 `json:"avg_power_watts"`
+	EnergyJoules          pgtype.Float8 `json:"energy_joules"`
+	Benchmark             string        `json:"benchmark"`
+	HpcgNx                int32         `json:"hpcg_nx"`
+	HpcgNy                int32         `json:"hpcg_ny"`
+	HpcgNz                int32         `json:"hpcg_nz"`
+	StreamCopyMbs         pgtype.Float8 `json:"stream_copy_mbs"`
+	StreamScaleMbs        pgtype.Float8 `json:"stream_scale_mbs"`
+	StreamAddMbs          pgtype.Float8 `json:"stream_add_mbs"`
+	StreamTriadMbs        pgtype.Float8 `json:"stream_triad_mbs"`
+	MxpPrecision          string        `json:"mxp_precision"`
 }
 
 func (q *Queries) CreateScore(ctx context.Context, arg CreateScoreParams) (Score, error) {
@@ -68,6 +171,33 @@ func (q *Queries) CreateScore(ctx context.Context, arg CreateScoreParams) (Score
 		arg.Q,
 		arg.ExecutionTime,
 		arg.SubmittedAt,
+		arg.LinuxUsernameVerified,
+		arg.ClientIdentity,
+		arg.TeamID,
+		arg.RunNonce,
+		arg.ExpectedGflops,
+		arg.Variant,
+		arg.Residual,
+		arg.ResidualThreshold,
+		arg.ResidualPassed,
+		arg.Ranks,
+		arg.Nodes,
+		arg.RanksPerNode,
+		arg.SystemID,
+		arg.RpeakGflops,
+		arg.ReviewStatus,
+		arg.ReviewReason,
+		arg.AvgPowerWatts,
+		arg.EnergyJoules,
+		arg.Benchmark,
+		arg.HpcgNx,
+		arg.HpcgNy,
+		arg.HpcgNz,
+		arg.StreamCopyMbs,
+		arg.StreamScaleMbs,
+		arg.StreamAddMbs,
+		arg.StreamTriadMbs,
+		arg.MxpPrecision,
 	)
 	var i Score
 	err := row.Scan(
@@ -83,13 +213,463 @@ func (q *Queries) CreateScore(ctx context.Context, arg CreateScoreParams) (Score
 		&i.P,
 		&i.Q,
 		&i.ExecutionTime,
+		&i.DisqualifiedAt,
+		&i.DisqualifiedBy,
+		&i.DisqualificationReason,
+		&i.LinuxUsernameVerified,
+		&i.ClientIdentity,
+		&i.TeamID,
+		&i.RunNonce,
+		&i.ExpectedGflops,
+		&i.GflopsFlagged,
+		&i.Variant,
+		&i.Residual,
+		&i.ResidualThreshold,
+		&i.ResidualPassed,
+		&i.Ranks,
+		&i.Nodes,
+		&i.RanksPerNode,
+		&i.GflopsPerNode,
+		&i.GflopsPerRank,
+		&i.SystemID,
+		&i.RpeakGflops,
+		&i.Efficiency,
+		&i.ReviewStatus,
+		&i.ReviewReason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.AvgPowerWatts,
+		&i.EnergyJoules,
+		&i.GflopsPerWatt,
+		&i.Benchmark,
+		&i.HpcgNx,
+		&i.HpcgNy,
+		&i.HpcgNz,
+		&i.StreamCopyMbs,
+		&i.StreamScaleMbs,
+		&i.StreamAddMbs,
+		&i.StreamTriadMbs,
+		&i.MxpPrecision,
+		&i.Metric,
 	)
 	return i, err
 }
 
+const deleteScore = `-- name: DeleteScore :execrows
+DELETE FROM scores
+WHERE id = $1
+`
+
+func (q *Queries) DeleteScore(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteScore, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const disqualifyScore = `-- name: DisqualifyScore :one
+UPDATE scores
+SET disqualified_at = now(),
+    disqualified_by = $1,
+    disqualification_reason = $2
+WHERE id = $3
+RETURNING id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time, disqualified_at, disqualified_by, disqualification_reason, linux_username_verified, client_identity, team_id, run_nonce, expected_gflops, gflops_flagged, variant, residual, residual_threshold, residual_passed, ranks, nodes, ranks_per_node, gflops_per_node, gflops_per_rank, system_id, rpeak_gflops, efficiency, review_status, review_reason, reviewed_by, reviewed_at, avg_power_watts, energy_joules, gflops_per_watt, benchmark, hpcg_nx, hpcg_ny, hpcg_nz, stream_copy_mbs, stream_scale_mbs, stream_add_mbs, stream_triad_mbs, mxp_precision, metric
+`
+
+type DisqualifyScoreParams struct {
+	DisqualifiedBy         string      `json:"disqualified_by"`
+	DisqualificationReason string      `json:"disqualification_reason"`
+	ID                     pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) DisqualifyScore(ctx context.Context, arg DisqualifyScoreParams) (Score, error) {
+	row := q.db.QueryRow(ctx, disqualifyScore, arg.DisqualifiedBy, arg.DisqualificationReason, arg.ID)
+	var i Score
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Gflops,
+		&i.ProblemSizeN,
+		&i.BlockSizeNb,
+		&i.SubmittedAt,
+		&i.LinuxUsername,
+		&i.N,
+		&i.Nb,
+		&i.P,
+		&i.Q,
+		&i.ExecutionTime,
+		&i.DisqualifiedAt,
+		&i.DisqualifiedBy,
+		&i.DisqualificationReason,
+		&i.LinuxUsernameVerified,
+		&i.ClientIdentity,
+		&i.TeamID,
+		&i.RunNonce,
+		&i.ExpectedGflops,
+		&i.GflopsFlagged,
+		&i.Variant,
+		&i.Residual,
+		&i.ResidualThreshold,
+		&i.ResidualPassed,
+		&i.Ranks,
+		&i.Nodes,
+		&i.RanksPerNode,
+		&i.GflopsPerNode,
+		&i.GflopsPerRank,
+		&i.SystemID,
+		&i.RpeakGflops,
+		&i.Efficiency,
+		&i.ReviewStatus,
+		&i.ReviewReason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.AvgPowerWatts,
+		&i.EnergyJoules,
+		&i.GflopsPerWatt,
+		&i.Benchmark,
+		&i.HpcgNx,
+		&i.HpcgNy,
+		&i.HpcgNz,
+		&i.StreamCopyMbs,
+		&i.StreamScaleMbs,
+		&i.StreamAddMbs,
+		&i.StreamTriadMbs,
+		&i.MxpPrecision,
+		&i.Metric,
+	)
+	return i, err
+}
+
+const getScore = `-- name: GetScore :one
+SELECT id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time, disqualified_at, disqualified_by, disqualification_reason, linux_username_verified, client_identity, team_id, run_nonce, expected_gflops, gflops_flagged, variant, residual, residual_threshold, residual_passed, ranks, nodes, ranks_per_node, gflops_per_node, gflops_per_rank, system_id, rpeak_gflops, efficiency, review_status, review_reason, reviewed_by, reviewed_at, avg_power_watts, energy_joules, gflops_per_watt, benchmark, hpcg_nx, hpcg_ny, hpcg_nz, stream_copy_mbs, stream_scale_mbs, stream_add_mbs, stream_triad_mbs, mxp_precision, metric FROM scores
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetScore(ctx context.Context, id pgtype.UUID) (Score, error) {
+	row := q.db.QueryRow(ctx, getScore, id)
+	var i Score
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Gflops,
+		&i.ProblemSizeN,
+		&i.BlockSizeNb,
+		&i.SubmittedAt,
+		&i.LinuxUsername,
+		&i.N,
+		&i.Nb,
+		&i.P,
+		&i.Q,
+		&i.ExecutionTime,
+		&i.DisqualifiedAt,
+		&i.DisqualifiedBy,
+		&i.DisqualificationReason,
+		&i.LinuxUsernameVerified,
+		&i.ClientIdentity,
+		&i.TeamID,
+		&i.RunNonce,
+		&i.ExpectedGflops,
+		&i.GflopsFlagged,
+		&i.Variant,
+		&i.Residual,
+		&i.ResidualThreshold,
+		&i.ResidualPassed,
+		&i.Ranks,
+		&i.Nodes,
+		&i.RanksPerNode,
+		&i.GflopsPerNode,
+		&i.GflopsPerRank,
+		&i.SystemID,
+		&i.RpeakGflops,
+		&i.Efficiency,
+		&i.ReviewStatus,
+		&i.ReviewReason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.AvgPowerWatts,
+		&i.EnergyJoules,
+		&i.GflopsPerWatt,
+		&i.Benchmark,
+		&i.HpcgNx,
+		&i.HpcgNy,
+		&i.HpcgNz,
+		&i.StreamCopyMbs,
+		&i.StreamScaleMbs,
+		&i.StreamAddMbs,
+		&i.StreamTriadMbs,
+		&i.MxpPrecision,
+		&i.Metric,
+	)
+	return i, err
+}
+
+const listGreenScores = `-- name: ListGreenScores :many
+SELECT id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time, disqualified_at, disqualified_by, disqualification_reason, linux_username_verified, client_identity, team_id, run_nonce, expected_gflops, gflops_flagged, variant, residual, residual_threshold, residual_passed, ranks, nodes, ranks_per_node, gflops_per_node, gflops_per_rank, system_id, rpeak_gflops, efficiency, review_status, review_reason, reviewed_by, reviewed_at, avg_power_watts, energy_joules, gflops_per_watt, benchmark, hpcg_nx, hpcg_ny, hpcg_nz, stream_copy_mbs, stream_scale_mbs, stream_add_mbs, stream_triad_mbs, mxp_precision, metric FROM scores
+WHERE disqualified_at IS NULL AND review_status = 'accepted' AND benchmark = 'hpl'
+  AND gflops_per_watt IS NOT NULL AND gflops >= $1::float8
+  AND CASE $2::text
+    WHEN 'all' THEN true
+    WHEN 'passed' THEN residual_passed IS TRUE
+    WHEN 'failed' THEN residual_passed IS FALSE
+    ELSE residual_passed IS DISTINCT FROM false
+  END
+ORDER BY gflops_per_watt DESC, gflops DESC
+LIMIT $3 OFFSET $4
+`
+
+type ListGreenScoresParams struct {
+	MinGflops      float64 `json:"min_gflops"`
+	ResidualFilter string  `json:"residual_filter"`
+	Limit          int32   `json:"limit"`
+	Offset         int32   `json:"offset"`
+}
+
+func (q *Queries) ListGreenScores(ctx context.Context, arg ListGreenScoresParams) ([]Score, error) {
+	rows, err := q.db.Query(ctx, listGreenScores,
+		arg.MinGflops,
+		arg.ResidualFilter,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Score
+	for rows.Next() {
+		var i Score
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Gflops,
+			&i.ProblemSizeN,
+			&i.BlockSizeNb,
+			&i.SubmittedAt,
+			&i.LinuxUsername,
+			&i.N,
+			&i.Nb,
+			&i.P,
+			&i.Q,
+			&i.ExecutionTime,
+			&i.DisqualifiedAt,
+			&i.DisqualifiedBy,
+			&i.DisqualificationReason,
+			&i.LinuxUsernameVerified,
+			&i.ClientIdentity,
+			&i.TeamID,
+			&i.RunNonce,
+			&i.ExpectedGflops,
+			&i.GflopsFlagged,
+			&i.Variant,
+			&i.Residual,
+			&i.ResidualThreshold,
+			&i.ResidualPassed,
+			&i.Ranks,
+			&i.Nodes,
+			&i.RanksPerNode,
+			&i.GflopsPerNode,
+			&i.GflopsPerRank,
+			&i.SystemID,
+			&i.RpeakGflops,
+			&i.Efficiency,
+			&i.ReviewStatus,
+			&i.ReviewReason,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.AvgPowerWatts,
+			&i.EnergyJoules,
+			&i.GflopsPerWatt,
+			&i.Benchmark,
+			&i.HpcgNx,
+			&i.HpcgNy,
+			&i.HpcgNz,
+			&i.StreamCopyMbs,
+			&i.StreamScaleMbs,
+			&i.StreamAddMbs,
+			&i.StreamTriadMbs,
+			&i.MxpPrecision,
+			&i.Metric,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingScores = `-- name: ListPendingScores :many
+SELECT id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time, disqualified_at, disqualified_by, disqualification_reason, linux_username_verified, client_identity, team_id, run_nonce, expected_gflops, gflops_flagged, variant, residual, residual_threshold, residual_passed, ranks, nodes, ranks_per_node, gflops_per_node, gflops_per_rank, system_id, rpeak_gflops, efficiency, review_status, review_reason, reviewed_by, reviewed_at, avg_power_watts, energy_joules, gflops_per_watt, benchmark, hpcg_nx, hpcg_ny, hpcg_nz, stream_copy_mbs, stream_scale_mbs, stream_add_mbs, stream_triad_mbs, mxp_precision, metric FROM scores
+WHERE review_status = 'pending'
+ORDER BY submitted_at
+LIMIT $1 OFFSET $2
+`
+
+type ListPendingScoresParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListPendingScores(ctx context.Context, arg ListPendingScoresParams) ([]Score, error) {
+	rows, err := q.db.Query(ctx, listPendingScores, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Score
+	for rows.Next() {
+		var i Score
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Gflops,
+			&i.ProblemSizeN,
+			&i.BlockSizeNb,
+			&i.SubmittedAt,
+			&i.LinuxUsername,
+			&i.N,
+			&i.Nb,
+			&i.P,
+			&i.Q,
+			&i.ExecutionTime,
+			&i.DisqualifiedAt,
+			&i.DisqualifiedBy,
+			&i.DisqualificationReason,
+			&i.LinuxUsernameVerified,
+			&i.ClientIdentity,
+			&i.TeamID,
+			&i.RunNonce,
+			&i.ExpectedGflops,
+			&i.GflopsFlagged,
+			&i.Variant,
+			&i.Residual,
+			&i.ResidualThreshold,
+			&i.ResidualPassed,
+			&i.Ranks,
+			&i.Nodes,
+			&i.RanksPerNode,
+			&i.GflopsPerNode,
+			&i.GflopsPerRank,
+			&i.SystemID,
+			&i.RpeakGflops,
+			&i.Efficiency,
+			&i.ReviewStatus,
+			&i.ReviewReason,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.AvgPowerWatts,
+			&i.EnergyJoules,
+			&i.GflopsPerWatt,
+			&i.Benchmark,
+			&i.HpcgNx,
+			&i.HpcgNy,
+			&i.HpcgNz,
+			&i.StreamCopyMbs,
+			&i.StreamScaleMbs,
+			&i.StreamAddMbs,
+			&i.StreamTriadMbs,
+			&i.MxpPrecision,
+			&i.Metric,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScoresAfter = `-- name: ListScoresAfter :many
+SELECT id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time, disqualified_at, disqualified_by, disqualification_reason, linux_username_verified, client_identity, team_id, run_nonce, expected_gflops, gflops_flagged, variant, residual, residual_threshold, residual_passed, ranks, nodes, ranks_per_node, gflops_per_node, gflops_per_rank, system_id, rpeak_gflops, efficiency, review_status, review_reason, reviewed_by, reviewed_at, avg_power_watts, energy_joules, gflops_per_watt, benchmark, hpcg_nx, hpcg_ny, hpcg_nz, stream_copy_mbs, stream_scale_mbs, stream_add_mbs, stream_triad_mbs, mxp_precision, metric FROM scores
+WHERE id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListScoresAfterParams struct {
+	ID    pgtype.UUID `json:"id"`
+	Limit int32       `json:"limit"`
+}
+
+func (q *Queries) ListScoresAfter(ctx context.Context, arg ListScoresAfterParams) ([]Score, error) {
+	rows, err := q.db.Query(ctx, listScoresAfter, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Score
+	for rows.Next() {
+		var i Score
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Gflops,
+			&i.ProblemSizeN,
+			&i.BlockSizeNb,
+			&i.SubmittedAt,
+			&i.LinuxUsername,
+			&i.N,
+			&i.Nb,
+			&i.P,
+			&i.Q,
+			&i.ExecutionTime,
+			&i.DisqualifiedAt,
+			&i.DisqualifiedBy,
+			&i.DisqualificationReason,
+			&i.LinuxUsernameVerified,
+			&i.ClientIdentity,
+			&i.TeamID,
+			&i.RunNonce,
+			&i.ExpectedGflops,
+			&i.GflopsFlagged,
+			&i.Variant,
+			&i.Residual,
+			&i.ResidualThreshold,
+			&i.ResidualPassed,
+			&i.Ranks,
+			&i.Nodes,
+			&i.RanksPerNode,
+			&i.GflopsPerNode,
+			&i.GflopsPerRank,
+			&i.SystemID,
+			&i.RpeakGflops,
+			&i.Efficiency,
+			&i.ReviewStatus,
+			&i.ReviewReason,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.AvgPowerWatts,
+			&i.EnergyJoules,
+			&i.GflopsPerWatt,
+			&i.Benchmark,
+			&i.HpcgNx,
+			&i.HpcgNy,
+			&i.HpcgNz,
+			&i.StreamCopyMbs,
+			&i.StreamScaleMbs,
+			&i.StreamAddMbs,
+			&i.StreamTriadMbs,
+			&i.MxpPrecision,
+			&i.Metric,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listScoresWithPagination = `-- name: ListScoresWithPagination :many
-SELECT id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time FROM scores
-WHERE ($1::uuid IS NULL OR id < $1)
+SELECT id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time, disqualified_at, disqualified_by, disqualification_reason, linux_username_verified, client_identity, team_id, run_nonce, expected_gflops, gflops_flagged, variant, residual, residual_threshold, residual_passed, ranks, nodes, ranks_per_node, gflops_per_node, gflops_per_rank, system_id, rpeak_gflops, efficiency, review_status, review_reason, reviewed_by, reviewed_at, avg_power_watts, energy_joules, gflops_per_watt, benchmark, hpcg_nx, hpcg_ny, hpcg_nz, stream_copy_mbs, stream_scale_mbs, stream_add_mbs, stream_triad_mbs, mxp_precision, metric FROM scores
+WHERE ($1::uuid IS NULL OR id < $1) AND disqualified_at IS NULL AND benchmark = 'hpl'
 ORDER BY gflops DESC, id DESC
 LIMIT $2
 `
@@ -121,6 +701,121 @@ func (q *Queries) ListScoresWithPagination(ctx context.Context, arg ListScoresWi
 			&i.P,
 			&i.Q,
 			&i.ExecutionTime,
+			&i.DisqualifiedAt,
+			&i.DisqualifiedBy,
+			&i.DisqualificationReason,
+			&i.LinuxUsernameVerified,
+			&i.ClientIdentity,
+			&i.TeamID,
+			&i.RunNonce,
+			&i.ExpectedGflops,
+			&i.GflopsFlagged,
+			&i.Variant,
+			&i.Residual,
+			&i.ResidualThreshold,
+			&i.ResidualPassed,
+			&i.Ranks,
+			&i.Nodes,
+			&i.RanksPerNode,
+			&i.GflopsPerNode,
+			&i.GflopsPerRank,
+			&i.SystemID,
+			&i.RpeakGflops,
+			&i.Efficiency,
+			&i.ReviewStatus,
+			&i.ReviewReason,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.AvgPowerWatts,
+			&i.EnergyJoules,
+			&i.GflopsPerWatt,
+			&i.Benchmark,
+			&i.HpcgNx,
+			&i.HpcgNy,
+			&i.HpcgNz,
+			&i.StreamCopyMbs,
+			&i.StreamScaleMbs,
+			&i.StreamAddMbs,
+			&i.StreamTriadMbs,
+			&i.MxpPrecision,
+			&i.Metric,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSystemScoresForReview = `-- name: ListSystemScoresForReview :many
+SELECT id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time, disqualified_at, disqualified_by, disqualification_reason, linux_username_verified, client_identity, team_id, run_nonce, expected_gflops, gflops_flagged, variant, residual, residual_threshold, residual_passed, ranks, nodes, ranks_per_node, gflops_per_node, gflops_per_rank, system_id, rpeak_gflops, efficiency, review_status, review_reason, reviewed_by, reviewed_at, avg_power_watts, energy_joules, gflops_per_watt, benchmark, hpcg_nx, hpcg_ny, hpcg_nz, stream_copy_mbs, stream_scale_mbs, stream_add_mbs, stream_triad_mbs, mxp_precision, metric FROM scores
+WHERE system_id = $1 AND review_status <> 'rejected'
+ORDER BY submitted_at
+`
+
+func (q *Queries) ListSystemScoresForReview(ctx context.Context, systemID pgtype.UUID) ([]Score, error) {
+	rows, err := q.db.Query(ctx, listSystemScoresForReview, systemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Score
+	for rows.Next() {
+		var i Score
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Gflops,
+			&i.ProblemSizeN,
+			&i.BlockSizeNb,
+			&i.SubmittedAt,
+			&i.LinuxUsername,
+			&i.N,
+			&i.Nb,
+			&i.P,
+			&i.Q,
+			&i.ExecutionTime,
+			&i.DisqualifiedAt,
+			&i.DisqualifiedBy,
+			&i.DisqualificationReason,
+			&i.LinuxUsernameVerified,
+			&i.ClientIdentity,
+			&i.TeamID,
+			&i.RunNonce,
+			&i.ExpectedGflops,
+			&i.GflopsFlagged,
+			&i.Variant,
+			&i.Residual,
+			&i.ResidualThreshold,
+			&i.ResidualPassed,
+			&i.Ranks,
+			&i.Nodes,
+			&i.RanksPerNode,
+			&i.GflopsPerNode,
+			&i.GflopsPerRank,
+			&i.SystemID,
+			&i.RpeakGflops,
+			&i.Efficiency,
+			&i.ReviewStatus,
+			&i.ReviewReason,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.AvgPowerWatts,
+			&i.EnergyJoules,
+			&i.GflopsPerWatt,
+			&i.Benchmark,
+			&i.HpcgNx,
+			&i.HpcgNy,
+			&i.HpcgNz,
+			&i.StreamCopyMbs,
+			&i.StreamScaleMbs,
+			&i.StreamAddMbs,
+			&i.StreamTriadMbs,
+			&i.MxpPrecision,
+			&i.Metric,
 		); err != nil {
 			return nil, err
 		}
@@ -133,18 +828,38 @@ func (q *Queries) ListScoresWithPagination(ctx context.Context, arg ListScoresWi
 }
 
 const listTopScores = `-- name: ListTopScores :many
-SELECT id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time FROM scores
-ORDER BY gflops DESC
-LIMIT $1 OFFSET $2
+SELECT id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time, disqualified_at, disqualified_by, disqualification_reason, linux_username_verified, client_identity, team_id, run_nonce, expected_gflops, gflops_flagged, variant, residual, residual_threshold, residual_passed, ranks, nodes, ranks_per_node, gflops_per_node, gflops_per_rank, system_id, rpeak_gflops, efficiency, review_status, review_reason, reviewed_by, reviewed_at, avg_power_watts, energy_joules, gflops_per_watt, benchmark, hpcg_nx, hpcg_ny, hpcg_nz, stream_copy_mbs, stream_scale_mbs, stream_add_mbs, stream_triad_mbs, mxp_precision, metric FROM scores
+WHERE disqualified_at IS NULL AND review_status = 'accepted' AND benchmark = $1::text
+  AND CASE $2::text
+    WHEN 'all' THEN true
+    WHEN 'passed' THEN residual_passed IS TRUE
+    WHEN 'failed' THEN residual_passed IS FALSE
+    ELSE residual_passed IS DISTINCT FROM false
+  END
+ORDER BY CASE $3::text
+    WHEN 'gflops_per_node' THEN gflops_per_node
+    WHEN 'gflops_per_rank' THEN gflops_per_rank
+    ELSE metric
+  END DESC NULLS LAST, metric DESC
+LIMIT $4 OFFSET $5
 `
 
 type ListTopScoresParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Benchmark      string `json:"benchmark"`
+	ResidualFilter string `json:"residual_filter"`
+	SortBy         string `json:"sort_by"`
+	Limit          int32  `json:"limit"`
+	Offset         int32  `json:"offset"`
 }
 
 func (q *Queries) ListTopScores(ctx context.Context, arg ListTopScoresParams) ([]Score, error) {
-	rows, err := q.db.Query(ctx, listTopScores, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listTopScores,
+		arg.Benchmark,
+		arg.ResidualFilter,
+		arg.SortBy,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +880,44 @@ func (q *Queries) ListTopScores(ctx context.Context, arg ListTopScoresParams) ([
 			&i.P,
 			&i.Q,
 			&i.ExecutionTime,
+			&i.DisqualifiedAt,
+			&i.DisqualifiedBy,
+			&i.DisqualificationReason,
+			&i.LinuxUsernameVerified,
+			&i.ClientIdentity,
+			&i.TeamID,
+			&i.RunNonce,
+			&i.ExpectedGflops,
+			&i.GflopsFlagged,
+			&i.Variant,
+			&i.Residual,
+			&i.ResidualThreshold,
+			&i.ResidualPassed,
+			&i.Ranks,
+			&i.Nodes,
+			&i.RanksPerNode,
+			&i.GflopsPerNode,
+			&i.GflopsPerRank,
+			&i.SystemID,
+			&i.RpeakGflops,
+			&i.Efficiency,
+			&i.ReviewStatus,
+			&i.ReviewReason,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.AvgPowerWatts,
+			&i.EnergyJoules,
+			&i.GflopsPerWatt,
+			&i.Benchmark,
+			&i.HpcgNx,
+			&i.HpcgNy,
+			&i.HpcgNz,
+			&i.StreamCopyMbs,
+			&i.StreamScaleMbs,
+			&i.StreamAddMbs,
+			&i.StreamTriadMbs,
+			&i.MxpPrecision,
+			&i.Metric,
 		); err != nil {
 			return nil, err
 		}
@@ -175,3 +928,121 @@ func (q *Queries) ListTopScores(ctx context.Context, arg ListTopScoresParams) ([
 	}
 	return items, nil
 }
+
+const reviewScore = `-- name: ReviewScore :one
+UPDATE scores
+SET review_status = $1,
+    review_reason = $2,
+    reviewed_by = $3,
+    reviewed_at = now()
+WHERE id = $4 AND review_status = 'pending'
+RETURNING id, user_id, gflops, problem_size_n, block_size_nb, submitted_at, linux_username, n, nb, p, q, execution_time, disqualified_at, disqualified_by, disqualification_reason, linux_username_verified, client_identity, team_id, run_nonce, expected_gflops, gflops_flagged, variant, residual, residual_threshold, residual_passed, ranks, nodes, ranks_per_node, gflops_per_node, gflops_per_rank, system_id, rpeak_gflops, efficiency, review_status, review_reason, reviewed_by, reviewed_at, avg_power_watts, energy_joules, gflops_per_watt, benchmark, hpcg_nx, hpcg_ny, hpcg_nz, stream_copy_mbs, stream_scale_mbs, stream_add_mbs, stream_triad_mbs, mxp_precision, metric
+`
+
+type ReviewScoreParams struct {
+	ReviewStatus string      `json:"review_status"`
+	ReviewReason string      `json:"review_reason"`
+	ReviewedBy   string      `json:"reviewed_by"`
+	ID           pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) ReviewScore(ctx context.Context, arg ReviewScoreParams) (Score, error) {
+	row := q.db.QueryRow(ctx, reviewScore,
+		arg.ReviewStatus,
+		arg.ReviewReason,
+		arg.ReviewedBy,
+		arg.ID,
+	)
+	var i Score
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Gflops,
+		&i.ProblemSizeN,
+		&i.BlockSizeNb,
+		&i.SubmittedAt,
+		&i.LinuxUsername,
+		&i.N,
+		&i.Nb,
+		&i.P,
+		&i.Q,
+		&i.ExecutionTime,
+		&i.DisqualifiedAt,
+		&i.DisqualifiedBy,
+		&i.DisqualificationReason,
+		&i.LinuxUsernameVerified,
+		&i.ClientIdentity,
+		&i.TeamID,
+		&i.RunNonce,
+		&i.ExpectedGflops,
+		&i.GflopsFlagged,
+		&i.Variant,
+		&i.Residual,
+		&i.ResidualThreshold,
+		&i.ResidualPassed,
+		&i.Ranks,
+		&i.Nodes,
+		&i.RanksPerNode,
+		&i.GflopsPerNode,
+		&i.GflopsPerRank,
+		&i.SystemID,
+		&i.RpeakGflops,
+		&i.Efficiency,
+		&i.ReviewStatus,
+		&i.ReviewReason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.AvgPowerWatts,
+		&i.EnergyJoules,
+		&i.GflopsPerWatt,
+		&i.Benchmark,
+		&i.HpcgNx,
+		&i.HpcgNy,
+		&i.HpcgNz,
+		&i.StreamCopyMbs,
+		&i.StreamScaleMbs,
+		&i.StreamAddMbs,
+		&i.StreamTriadMbs,
+		&i.MxpPrecision,
+		&i.Metric,
+	)
+	return i, err
+}
+
+const setScoreGflopsCheck = `-- name: SetScoreGflopsCheck :exec
+UPDATE scores
+SET expected_gflops = $2,
+    gflops_flagged = $3
+WHERE id = $1
+`
+
+type SetScoreGflopsCheckParams struct {
+	ID             pgtype.UUID `json:"id"`
+	ExpectedGflops float64     `json:"expected_gflops"`
+	GflopsFlagged  bool        `json:"gflops_flagged"`
+}
+
+func (q *Queries) SetScoreGflopsCheck(ctx context.Context, arg SetScoreGflopsCheckParams) error {
+	_, err := q.db.Exec(ctx, setScoreGflopsCheck, arg.ID, arg.ExpectedGflops, arg.GflopsFlagged)
+	return err
+}
+
+const setScoreReviewStatus = `-- name: SetScoreReviewStatus :exec
+UPDATE scores
+SET review_status = $2,
+    review_reason = $3,
+    reviewed_by = '',
+    reviewed_at = NULL
+WHERE id = $1
+`
+
+type SetScoreReviewStatusParams struct {
+	ID           pgtype.UUID `json:"id"`
+	ReviewStatus string      `json:"review_status"`
+	ReviewReason string      `json:"review_reason"`
+}
+
+func (q *Queries) SetScoreReviewStatus(ctx context.Context, arg SetScoreReviewStatusParams) error {
+	_, err := q.db.Exec(ctx, setScoreReviewStatus, arg.ID, arg.ReviewStatus, arg.ReviewReason)
+	return err
+}
@@ -5,7 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateScore(t *testing.T) {
@@ -26,3 +29,207 @@ func TestCreateScore(t *testing.T) {
 	assert.NotZero(t, score.ID)
 	assert.WithinDuration(t, arg.SubmittedAt, score.SubmittedAt, time.Second)
 }
+
+func TestDisqualifyScore(t *testing.T) {
+	score, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:      "user-uuid-mock",
+		Gflops:      9999.99,
+		SubmittedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	disqualified, err := testStore.DisqualifyScore(context.Background(), DisqualifyScoreParams{
+		DisqualifiedBy:         "judge",
+		DisqualificationReason: "duplicate submission",
+		ID:                     score.ID,
+	})
+	require.NoError(t, err)
+	assert.True(t, disqualified.DisqualifiedAt.Valid)
+	assert.Equal(t, "judge", disqualified.DisqualifiedBy)
+	assert.Equal(t, "duplicate submission", disqualified.DisqualificationReason)
+
+	// 失格成績不會出現在排行榜上
+	scores, err := testStore.ListTopScores(context.Background(), ListTopScoresParams{Limit: 1000})
+	require.NoError(t, err)
+	for _, s := range scores {
+		assert.NotEqual(t, score.ID, s.ID)
+	}
+}
+
+func TestDeleteScore(t *testing.T) {
+	score, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:      "user-uuid-mock",
+		Gflops:      1.0,
+		SubmittedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	rows, err := testStore.DeleteScore(context.Background(), score.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	rows, err = testStore.DeleteScore(context.Background(), score.ID)
+	require.NoError(t, err)
+	assert.Zero(t, rows)
+}
+
+func TestListTopScoresResidualFilter(t *testing.T) {
+	failed, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:            "user-uuid-mock",
+		Gflops:            99999.0,
+		SubmittedAt:       time.Now(),
+		Variant:           "WR11C2R4",
+		Residual:          pgtype.Float8{Float64: 21.15, Valid: true},
+		ResidualThreshold: pgtype.Float8{Float64: 16, Valid: true},
+		ResidualPassed:    pgtype.Bool{Bool: false, Valid: true},
+		ReviewStatus:      "accepted",
+		Benchmark:         "hpl",
+	})
+	require.NoError(t, err)
+
+	contains := func(filter string) bool {
+		scores, err := testStore.ListTopScores(context.Background(), ListTopScoresParams{Benchmark: "hpl", ResidualFilter: filter, Limit: 1000})
+		require.NoError(t, err)
+		for _, s := range scores {
+			if s.ID == failed.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	// 預設與 passed 不列出失敗的成績
+	assert.False(t, contains(""))
+	assert.False(t, contains("passed"))
+	assert.True(t, contains("failed"))
+	assert.True(t, contains("all"))
+}
+
+func TestListTopScoresSortByGflopsPerNode(t *testing.T) {
+	score, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:       "user-uuid-mock",
+		Gflops:       8e15,
+		SubmittedAt:  time.Now(),
+		P:            2,
+		Q:            4,
+		Ranks:        8,
+		Nodes:        1,
+		RanksPerNode: 8,
+		ReviewStatus: "accepted",
+		Benchmark:    "hpl",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pgtype.Float8{Float64: 8e15, Valid: true}, score.GflopsPerNode)
+	assert.Equal(t, pgtype.Float8{Float64: 1e15, Valid: true}, score.GflopsPerRank)
+
+	scores, err := testStore.ListTopScores(context.Background(), ListTopScoresParams{Benchmark: "hpl", SortBy: "gflops_per_node", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, scores, 1)
+	assert.Equal(t, score.ID, scores[0].ID)
+}
+
+func TestReviewScore(t *testing.T) {
+	score, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:       "user-uuid-mock",
+		Gflops:       9e15,
+		SubmittedAt:  time.Now(),
+		ReviewStatus: "pending",
+		ReviewReason: "efficiency 97.0% is above the review threshold of 90%",
+		Benchmark:    "hpl",
+	})
+	require.NoError(t, err)
+
+	listed := func() bool {
+		scores, err := testStore.ListTopScores(context.Background(), ListTopScoresParams{Benchmark: "hpl", Limit: 1})
+		require.NoError(t, err)
+		return len(scores) == 1 && scores[0].ID == score.ID
+	}
+
+	// 等待審核的成績只出現在審核佇列
+	assert.False(t, listed())
+	pending, err := testStore.ListPendingScores(context.Background(), ListPendingScoresParams{Limit: 1000})
+	require.NoError(t, err)
+	assert.Contains(t, pending, score)
+
+	reviewed, err := testStore.ReviewScore(context.Background(), ReviewScoreParams{
+		ReviewStatus: "accepted",
+		ReviewReason: "checked the HPL.out",
+		ReviewedBy:   "judge",
+		ID:           score.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "accepted", reviewed.ReviewStatus)
+	assert.Equal(t, "judge", reviewed.ReviewedBy)
+	assert.True(t, reviewed.ReviewedAt.Valid)
+	assert.True(t, listed())
+
+	// 已審核的成績不能再審核一次
+	_, err = testStore.ReviewScore(context.Background(), ReviewScoreParams{
+		ReviewStatus: "rejected",
+		ReviewedBy:   "judge",
+		ID:           score.ID,
+	})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestListGreenScores(t *testing.T) {
+	efficient, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:        "user-uuid-mock",
+		Gflops:        4e6,
+		SubmittedAt:   time.Now(),
+		ReviewStatus:  "accepted",
+		Benchmark:     "hpl",
+		AvgPowerWatts: pgtype.Float8{Float64: 1, Valid: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pgtype.Float8{Float64: 4e6, Valid: true}, efficient.GflopsPerWatt)
+
+	// 效能低於門檻的成績不列入，即使 GFLOPS/W 更高
+	small, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:        "user-uuid-mock",
+		Gflops:        1e6,
+		SubmittedAt:   time.Now(),
+		ReviewStatus:  "accepted",
+		Benchmark:     "hpl",
+		AvgPowerWatts: pgtype.Float8{Float64: 0.1, Valid: true},
+	})
+	require.NoError(t, err)
+
+	scores, err := testStore.ListGreenScores(context.Background(), ListGreenScoresParams{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, scores, 1)
+	assert.Equal(t, small.ID, scores[0].ID)
+
+	scores, err = testStore.ListGreenScores(context.Background(), ListGreenScoresParams{MinGflops: 2e6, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, scores, 1)
+	assert.Equal(t, efficient.ID, scores[0].ID)
+}
+
+func TestListTopScoresByBenchmark(t *testing.T) {
+	stream, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:         "user-uuid-mock",
+		SubmittedAt:    time.Now(),
+		ReviewStatus:   "accepted",
+		Benchmark:      "stream",
+		StreamCopyMbs:  pgtype.Float8{Float64: 9e15, Valid: true},
+		StreamScaleMbs: pgtype.Float8{Float64: 9e15, Valid: true},
+		StreamAddMbs:   pgtype.Float8{Float64: 9.5e15, Valid: true},
+		StreamTriadMbs: pgtype.Float8{Float64: 9.5e15, Valid: true},
+	})
+	require.NoError(t, err)
+	// STREAM 依 triad 頻寬排名
+	assert.Equal(t, pgtype.Float8{Float64: 9.5e15, Valid: true}, stream.Metric)
+
+	scores, err := testStore.ListTopScores(context.Background(), ListTopScoresParams{Benchmark: "stream", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, scores, 1)
+	assert.Equal(t, stream.ID, scores[0].ID)
+
+	// 其他 benchmark 的排行榜不會出現 STREAM 成績
+	scores, err = testStore.ListTopScores(context.Background(), ListTopScoresParams{Benchmark: "hpl", Limit: 1000})
+	require.NoError(t, err)
+	for _, s := range scores {
+		assert.Equal(t, "hpl", s.Benchmark)
+	}
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSessionParams(username string, familyID pgtype.UUID) CreateSessionParams {
+	return CreateSessionParams{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		FamilyID:  familyID,
+		Username:  username,
+		UserAgent: "curl/8.0",
+		ClientIp:  "127.0.0.1",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func TestCreateAndGetSession(t *testing.T) {
+	user := createRandomUser(t)
+	familyID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	session1, err := testStore.CreateSession(context.Background(), newSessionParams(user.Username, familyID))
+	require.NoError(t, err)
+	assert.False(t, session1.IsRevoked)
+	assert.False(t, session1.RotatedAt.Valid)
+
+	session2, err := testStore.GetSession(context.Background(), session1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, session1.ID, session2.ID)
+	assert.Equal(t, familyID, session2.FamilyID)
+	assert.Equal(t, user.Username, session2.Username)
+}
+
+func TestMarkSessionRotatedOnlyOnce(t *testing.T) {
+	user := createRandomUser(t)
+	familyID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	session, err := testStore.CreateSession(context.Background(), newSessionParams(user.Username, familyID))
+	require.NoError(t, err)
+
+	arg := MarkSessionRotatedParams{
+		ReplacedBy: pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		ID:         session.ID,
+	}
+
+	rows, err := testStore.MarkSessionRotated(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	// 第二次使用同一個 Session 不應更新任何資料
+	rows, err = testStore.MarkSessionRotated(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func TestRevokeSessionFamily(t *testing.T) {
+	user := createRandomUser(t)
+	familyID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	session1, err := testStore.CreateSession(context.Background(), newSessionParams(user.Username, familyID))
+	require.NoError(t, err)
+	session2, err := testStore.CreateSession(context.Background(), newSessionParams(user.Username, familyID))
+	require.NoError(t, err)
+
+	require.NoError(t, testStore.RevokeSessionFamily(context.Background(), familyID))
+
+	for _, id := range []pgtype.UUID{session1.ID, session2.ID} {
+		session, err := testStore.GetSession(context.Background(), id)
+		require.NoError(t, err)
+		assert.True(t, session.IsRevoked)
+	}
+}
+
+func TestRotateSessionTx(t *testing.T) {
+	user := createRandomUser(t)
+	familyID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	oldSession, err := testStore.CreateSession(context.Background(), newSessionParams(user.Username, familyID))
+	require.NoError(t, err)
+
+	arg := RotateSessionTxParams{
+		OldSessionID: oldSession.ID,
+		NewSession:   newSessionParams(user.Username, familyID),
+	}
+
+	result, err := testStore.RotateSessionTx(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, arg.NewSession.ID, result.Session.ID)
+	assert.Equal(t, familyID, result.Session.FamilyID)
+
+	rotated, err := testStore.GetSession(context.Background(), oldSession.ID)
+	require.NoError(t, err)
+	assert.True(t, rotated.RotatedAt.Valid)
+	assert.Equal(t, result.Session.ID, rotated.ReplacedBy)
+
+	// 重複輪替同一個 Session 必須失敗，且不能建立新 Session
+	arg.NewSession = newSessionParams(user.Username, familyID)
+	_, err = testStore.RotateSessionTx(context.Background(), arg)
+	assert.ErrorIs(t, err, ErrSessionAlreadyRotated)
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSigningKey(t *testing.T, username string) SigningKey {
+	arg := CreateSigningKeyParams{
+		Username: username,
+		Name:     "cluster-a",
+		Secret:   "hpls_" + uuid.NewString(),
+	}
+
+	signingKey, err := testStore.CreateSigningKey(context.Background(), arg)
+	require.NoError(t, err)
+	assert.True(t, signingKey.ID.Valid)
+	assert.Equal(t, arg.Secret, signingKey.Secret)
+	assert.False(t, signingKey.RevokedAt.Valid)
+
+	return signingKey
+}
+
+func TestRevokeSigningKey(t *testing.T) {
+	owner := createRandomUser(t)
+	other := createRandomUser(t)
+	signingKey := createRandomSigningKey(t, owner.Username)
+
+	active, err := testStore.GetActiveSigningKey(context.Background(), signingKey.ID)
+	require.NoError(t, err)
+	assert.Equal(t, signingKey.Secret, active.Secret)
+
+	// 只能撤銷自己的 Key
+	rows, err := testStore.RevokeSigningKey(context.Background(), RevokeSigningKeyParams{ID: signingKey.ID, Username: other.Username})
+	require.NoError(t, err)
+	assert.Zero(t, rows)
+
+	rows, err = testStore.RevokeSigningKey(context.Background(), RevokeSigningKeyParams{ID: signingKey.ID, Username: owner.Username})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = testStore.GetActiveSigningKey(context.Background(), signingKey.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestUseRequestNonce(t *testing.T) {
+	keyID := uuid.NewString()
+	arg := UseRequestNonceParams{
+		KeyID:     keyID,
+		Nonce:     uuid.NewString(),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+
+	rows, err := testStore.UseRequestNonce(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	// 同一個 nonce 不能再用
+	rows, err = testStore.UseRequestNonce(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Zero(t, rows)
+
+	// 過期後清除，可以再次寫入
+	require.NoError(t, testStore.DeleteExpiredRequestNonces(context.Background()))
+	rows, err = testStore.UseRequestNonce(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSSHKey(t *testing.T, username string, linuxUsername string) SshKey {
+	arg := CreateSSHKeyParams{
+		Username:      username,
+		LinuxUsername: linuxUsername,
+		PublicKey:     "ssh-ed25519 AAAA" + uuid.NewString(),
+		Fingerprint:   "SHA256:" + uuid.NewString(),
+	}
+
+	sshKey, err := testStore.CreateSSHKey(context.Background(), arg)
+	require.NoError(t, err)
+	assert.True(t, sshKey.ID.Valid)
+	assert.Equal(t, arg.LinuxUsername, sshKey.LinuxUsername)
+	assert.False(t, sshKey.VerifiedAt.Valid)
+
+	return sshKey
+}
+
+func startSSHKeyChallenge(t *testing.T, sshKey SshKey, challenge string, expiresAt time.Time) {
+	rows, err := testStore.SetSSHKeyChallenge(context.Background(), SetSSHKeyChallengeParams{
+		ID:                 sshKey.ID,
+		Username:           sshKey.Username,
+		Challenge:          challenge,
+		ChallengeExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
+
+func TestVerifySSHKeyTx(t *testing.T) {
+	user := createRandomUser(t)
+	linuxUsername := "u" + uuid.NewString()[:8]
+	sshKey := createRandomSSHKey(t, user.Username, linuxUsername)
+	startSSHKeyChallenge(t, sshKey, "challenge-1", time.Now().Add(time.Minute))
+
+	arg := VerifySSHKeyTxParams{ID: sshKey.ID, Username: user.Username, Challenge: "challenge-1"}
+	result, err := testStore.VerifySSHKeyTx(context.Background(), arg)
+	require.NoError(t, err)
+	assert.True(t, result.SSHKey.VerifiedAt.Valid)
+	assert.Empty(t, result.SSHKey.Challenge)
+
+	verified, err := testStore.IsLinuxAccountVerified(context.Background(), IsLinuxAccountVerifiedParams{LinuxUsername: linuxUsername, Username: user.Username})
+	require.NoError(t, err)
+	assert.True(t, verified)
+
+	// 同一個 challenge 只能使用一次
+	_, err = testStore.VerifySSHKeyTx(context.Background(), arg)
+	assert.ErrorIs(t, err, ErrSSHKeyChallengeInvalid)
+}
+
+func TestVerifySSHKeyTxExpiredChallenge(t *testing.T) {
+	user := createRandomUser(t)
+	sshKey := createRandomSSHKey(t, user.Username, "u"+uuid.NewString()[:8])
+	startSSHKeyChallenge(t, sshKey, "challenge-1", time.Now().Add(-time.Second))
+
+	_, err := testStore.VerifySSHKeyTx(context.Background(), VerifySSHKeyTxParams{ID: sshKey.ID, Username: user.Username, Challenge: "challenge-1"})
+	assert.ErrorIs(t, err, ErrSSHKeyChallengeInvalid)
+}
+
+func TestVerifySSHKeyTxLinuxAccountTaken(t *testing.T) {
+	linuxUsername := "u" + uuid.NewString()[:8]
+
+	owner := createRandomUser(t)
+	ownerKey := createRandomSSHKey(t, owner.Username, linuxUsername)
+	startSSHKeyChallenge(t, ownerKey, "owner-challenge", time.Now().Add(time.Minute))
+	_, err := testStore.VerifySSHKeyTx(context.Background(), VerifySSHKeyTxParams{ID: ownerKey.ID, Username: owner.Username, Challenge: "owner-challenge"})
+	require.NoError(t, err)
+
+	other := createRandomUser(t)
+	otherKey := createRandomSSHKey(t, other.Username, linuxUsername)
+	startSSHKeyChallenge(t, otherKey, "other-challenge", time.Now().Add(time.Minute))
+	_, err = testStore.VerifySSHKeyTx(context.Background(), VerifySSHKeyTxParams{ID: otherKey.ID, Username: other.Username, Challenge: "other-challenge"})
+	require.ErrorIs(t, err, ErrLinuxAccountTaken)
+
+	// 交易回滾，另一位使用者的金鑰仍未驗證
+	otherKey, err = testStore.GetSSHKey(context.Background(), GetSSHKeyParams{ID: otherKey.ID, Username: other.Username})
+	require.NoError(t, err)
+	assert.False(t, otherKey.VerifiedAt.Valid)
+
+	verified, err := testStore.IsLinuxAccountVerified(context.Background(), IsLinuxAccountVerifiedParams{LinuxUsername: linuxUsername, Username: other.Username})
+	require.NoError(t, err)
+	assert.False(t, verified)
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Store provides all functions to execute db queries and transactions
+type Store interface {
+	Querier
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error)
+	EnableTOTPTx(ctx context.Context, arg EnableTOTPTxParams) error
+	DisableTOTPTx(ctx context.Context, username string) error
+	VerifySSHKeyTx(ctx context.Context, arg VerifySSHKeyTxParams) (VerifySSHKeyTxResult, error)
+	CreateTeamTx(ctx context.Context, arg CreateTeamTxParams) (CreateTeamTxResult, error)
+	JoinTeamTx(ctx context.Context, arg JoinTeamTxParams) (TeamMember, error)
+	UpdateTeamMemberRoleTx(ctx context.Context, arg UpdateTeamMemberRoleParams) (TeamMember, error)
+	RemoveTeamMemberTx(ctx context.Context, arg RemoveTeamMemberParams) (int64, error)
+	CreateScoresTx(ctx context.Context, arg CreateScoresTxParams) ([]Score, error)
+	UpdateSystemTx(ctx context.Context, arg UpdateSystemTxParams) (System, error)
+	CreateOIDCUserTx(ctx context.Context, arg CreateOIDCUserTxParams) (User, error)
+}
+
+// SQLStore provides all functions to execute SQL queries and transactions
+type SQLStore struct {
+	connPool *pgxpool.Pool
+	*Queries
+}
+
+// NewStore creates a new store
+func NewStore(connPool *pgxpool.Pool) Store {
+	return &SQLStore{
+		connPool: connPool,
+		Queries:  New(connPool),
+	}
+}
+
+// execTx executes a function within a database transaction
+func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
+	tx, err := store.connPool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	q := New(tx)
+	err = fn(q)
+	if err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
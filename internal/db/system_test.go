@@ -0,0 +1,201 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomSystem 建立 4 個節點、每節點峰值 40 × 2.5 × 32 = 3200 GFLOPS 的系統
+func createRandomSystem(t *testing.T, createdBy string) System {
+	system, err := testStore.CreateSystem(context.Background(), CreateSystemParams{
+		Name:          "system-" + uuid.NewString(),
+		Nodes:         4,
+		CpuModel:      "Xeon Gold 6248",
+		CoresPerNode:  40,
+		ClockGhz:      2.5,
+		FlopsPerCycle: 32,
+		Interconnect:  "EDR InfiniBand",
+		Mpi:           "Open MPI 4.1",
+		Blas:          "OpenBLAS 0.3",
+		CreatedBy:     createdBy,
+	})
+	require.NoError(t, err)
+	return system
+}
+
+func TestCreateSystem(t *testing.T) {
+	system := createRandomSystem(t, createRandomUser(t).Username)
+
+	assert.True(t, system.ID.Valid)
+	assert.Equal(t, 3200.0, system.NodeRpeakGflops)
+	assert.Equal(t, 12800.0, system.RpeakGflops)
+}
+
+func TestUpdateSystemTx(t *testing.T) {
+	user := createRandomUser(t)
+	system := createRandomSystem(t, user.Username)
+
+	score, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:      user.Username,
+		Gflops:      3200,
+		SubmittedAt: time.Now(),
+		Nodes:       2,
+		SystemID:    system.ID,
+		RpeakGflops: pgtype.Float8{Float64: 2 * system.NodeRpeakGflops, Valid: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pgtype.Float8{Float64: 0.5, Valid: true}, score.Efficiency)
+
+	// STREAM 沒有浮點運算，不記錄峰值
+	stream, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:         user.Username,
+		SubmittedAt:    time.Now(),
+		Nodes:          1,
+		SystemID:       system.ID,
+		ReviewStatus:   "accepted",
+		Benchmark:      "stream",
+		StreamTriadMbs: pgtype.Float8{Float64: 13000, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// 加上每個節點 3200 GFLOPS 的加速器後，峰值加倍
+	updated, err := testStore.UpdateSystemTx(context.Background(), UpdateSystemTxParams{
+		UpdateSystemParams: UpdateSystemParams{
+			ID:                       system.ID,
+			Name:                     system.Name,
+			Nodes:                    system.Nodes,
+			CpuModel:                 system.CpuModel,
+			CoresPerNode:             system.CoresPerNode,
+			ClockGhz:                 system.ClockGhz,
+			FlopsPerCycle:            system.FlopsPerCycle,
+			Accelerators:             "1x accelerator",
+			AcceleratorGflopsPerNode: 3200,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 6400.0, updated.NodeRpeakGflops)
+
+	score, err = testStore.GetScore(context.Background(), score.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pgtype.Float8{Float64: 12800, Valid: true}, score.RpeakGflops)
+	assert.Equal(t, pgtype.Float8{Float64: 0.25, Valid: true}, score.Efficiency)
+
+	stream, err = testStore.GetScore(context.Background(), stream.ID)
+	require.NoError(t, err)
+	assert.False(t, stream.RpeakGflops.Valid)
+}
+
+func TestUpdateSystemTxReviewsScores(t *testing.T) {
+	user := createRandomUser(t)
+	system := createRandomSystem(t, user.Username)
+
+	score, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:       user.Username,
+		Gflops:       4800,
+		SubmittedAt:  time.Now(),
+		Nodes:        2,
+		SystemID:     system.ID,
+		RpeakGflops:  pgtype.Float8{Float64: 2 * system.NodeRpeakGflops, Valid: true},
+		ReviewStatus: "accepted",
+		Benchmark:    "hpl",
+	})
+	require.NoError(t, err)
+
+	// 每節點峰值減半後 efficiency 變成 150%
+	arg := UpdateSystemTxParams{
+		UpdateSystemParams: UpdateSystemParams{
+			ID:            system.ID,
+			Name:          system.Name,
+			Nodes:         system.Nodes,
+			CpuModel:      system.CpuModel,
+			CoresPerNode:  system.CoresPerNode,
+			ClockGhz:      system.ClockGhz,
+			FlopsPerCycle: system.FlopsPerCycle / 2,
+		},
+		ReviewScore: func(s Score) (string, string) {
+			assert.Equal(t, score.ID, s.ID)
+			assert.Equal(t, pgtype.Float8{Float64: 3200, Valid: true}, s.RpeakGflops)
+			return "rejected", "exceeds rpeak"
+		},
+	}
+	_, err = testStore.UpdateSystemTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	score, err = testStore.GetScore(context.Background(), score.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "rejected", score.ReviewStatus)
+	assert.Equal(t, "exceeds rpeak", score.ReviewReason)
+
+	// 只改名稱不會重新評估
+	arg.Name = system.Name + "-renamed"
+	arg.ReviewScore = func(Score) (string, string) {
+		t.Error("scores re-reviewed although the per-node peak did not change")
+		return "", ""
+	}
+	_, err = testStore.UpdateSystemTx(context.Background(), arg)
+	require.NoError(t, err)
+}
+
+func TestUpdateSystemTxNodesInUse(t *testing.T) {
+	user := createRandomUser(t)
+	system := createRandomSystem(t, user.Username)
+
+	_, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:       user.Username,
+		Gflops:       3200,
+		SubmittedAt:  time.Now(),
+		Nodes:        3,
+		SystemID:     system.ID,
+		RpeakGflops:  pgtype.Float8{Float64: 3 * system.NodeRpeakGflops, Valid: true},
+		ReviewStatus: "accepted",
+		Benchmark:    "hpl",
+	})
+	require.NoError(t, err)
+
+	arg := UpdateSystemParams{
+		ID:            system.ID,
+		Name:          system.Name,
+		Nodes:         2,
+		CpuModel:      system.CpuModel,
+		CoresPerNode:  system.CoresPerNode,
+		ClockGhz:      system.ClockGhz,
+		FlopsPerCycle: system.FlopsPerCycle,
+	}
+	_, err = testStore.UpdateSystemTx(context.Background(), UpdateSystemTxParams{UpdateSystemParams: arg})
+	assert.ErrorIs(t, err, ErrSystemNodesInUse)
+
+	arg.Nodes = 3
+	updated, err := testStore.UpdateSystemTx(context.Background(), UpdateSystemTxParams{UpdateSystemParams: arg})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), updated.Nodes)
+}
+
+func TestDeleteSystemWithScores(t *testing.T) {
+	user := createRandomUser(t)
+	system := createRandomSystem(t, user.Username)
+
+	_, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+		UserID:      user.Username,
+		Gflops:      1000,
+		SubmittedAt: time.Now(),
+		SystemID:    system.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.DeleteSystem(context.Background(), system.ID)
+	var pgErr *pgconn.PgError
+	require.True(t, errors.As(err, &pgErr))
+	assert.Equal(t, "23503", pgErr.Code)
+
+	rows, err := testStore.DeleteSystem(context.Background(), createRandomSystem(t, user.Username).ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+}
@@ -0,0 +1,175 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTeam(t *testing.T, owner string) Team {
+	result, err := testStore.CreateTeamTx(context.Background(), CreateTeamTxParams{
+		Name:  "team-" + uuid.NewString(),
+		Owner: owner,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Team.ID.Valid)
+	assert.Equal(t, owner, result.Member.Username)
+	assert.Equal(t, TeamRoleOwner, result.Member.Role)
+
+	return result.Team
+}
+
+func createRandomTeamInvitation(t *testing.T, team Team, maxUses int32, expiresAt time.Time) string {
+	hashedCode := uuid.NewString()
+	_, err := testStore.CreateTeamInvitation(context.Background(), CreateTeamInvitationParams{
+		TeamID:     team.ID,
+		HashedCode: hashedCode,
+		CreatedBy:  team.CreatedBy,
+		MaxUses:    maxUses,
+		ExpiresAt:  expiresAt,
+	})
+	require.NoError(t, err)
+	return hashedCode
+}
+
+func TestCreateTeamTx(t *testing.T) {
+	owner := createRandomUser(t)
+	team := createRandomTeam(t, owner.Username)
+
+	teams, err := testStore.ListUserTeams(context.Background(), owner.Username)
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, team.ID, teams[0].ID)
+	assert.Equal(t, TeamRoleOwner, teams[0].Role)
+
+	// 隊名不可重複
+	_, err = testStore.CreateTeamTx(context.Background(), CreateTeamTxParams{Name: team.Name, Owner: owner.Username})
+	assert.Error(t, err)
+}
+
+func TestJoinTeamTx(t *testing.T) {
+	owner := createRandomUser(t)
+	team := createRandomTeam(t, owner.Username)
+	hashedCode := createRandomTeamInvitation(t, team, 1, time.Now().Add(time.Hour))
+
+	// 已在隊伍中的人不會消耗邀請碼
+	_, err := testStore.JoinTeamTx(context.Background(), JoinTeamTxParams{HashedCode: hashedCode, Username: owner.Username})
+	assert.ErrorIs(t, err, ErrAlreadyTeamMember)
+
+	member := createRandomUser(t)
+	joined, err := testStore.JoinTeamTx(context.Background(), JoinTeamTxParams{HashedCode: hashedCode, Username: member.Username})
+	require.NoError(t, err)
+	assert.Equal(t, team.ID, joined.TeamID)
+	assert.Equal(t, TeamRoleMember, joined.Role)
+
+	// max_uses 用完後失效
+	_, err = testStore.JoinTeamTx(context.Background(), JoinTeamTxParams{HashedCode: hashedCode, Username: createRandomUser(t).Username})
+	assert.ErrorIs(t, err, ErrTeamInvitationInvalid)
+}
+
+func TestJoinTeamTxExpiredInvitation(t *testing.T) {
+	team := createRandomTeam(t, createRandomUser(t).Username)
+	hashedCode := createRandomTeamInvitation(t, team, 10, time.Now().Add(-time.Second))
+
+	_, err := testStore.JoinTeamTx(context.Background(), JoinTeamTxParams{HashedCode: hashedCode, Username: createRandomUser(t).Username})
+	assert.ErrorIs(t, err, ErrTeamInvitationInvalid)
+}
+
+func TestUpdateTeamMemberRoleTxLastOwner(t *testing.T) {
+	owner := createRandomUser(t)
+	team := createRandomTeam(t, owner.Username)
+
+	_, err := testStore.UpdateTeamMemberRoleTx(context.Background(), UpdateTeamMemberRoleParams{
+		TeamID:   team.ID,
+		Username: owner.Username,
+		Role:     TeamRoleMember,
+	})
+	assert.ErrorIs(t, err, ErrLastTeamOwner)
+
+	_, err = testStore.RemoveTeamMemberTx(context.Background(), RemoveTeamMemberParams{
+		TeamID:   team.ID,
+		Username: owner.Username,
+	})
+	assert.ErrorIs(t, err, ErrLastTeamOwner)
+
+	// 有第二個 owner 後，原本的 owner 可以離開
+	second := createRandomUser(t)
+	_, err = testStore.AddTeamMember(context.Background(), AddTeamMemberParams{
+		TeamID:   team.ID,
+		Username: second.Username,
+		Role:     TeamRoleOwner,
+	})
+	require.NoError(t, err)
+
+	rows, err := testStore.RemoveTeamMemberTx(context.Background(), RemoveTeamMemberParams{
+		TeamID:   team.ID,
+		Username: owner.Username,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+}
+
+func TestRemoveTeamMemberTxConcurrentOwners(t *testing.T) {
+	owners := []User{createRandomUser(t), createRandomUser(t)}
+	team := createRandomTeam(t, owners[0].Username)
+	_, err := testStore.AddTeamMember(context.Background(), AddTeamMemberParams{
+		TeamID:   team.ID,
+		Username: owners[1].Username,
+		Role:     TeamRoleOwner,
+	})
+	require.NoError(t, err)
+
+	// 兩個 owner 同時離開，只能有一個成功
+	errs := make(chan error, len(owners))
+	for _, owner := range owners {
+		go func() {
+			_, err := testStore.RemoveTeamMemberTx(context.Background(), RemoveTeamMemberParams{
+				TeamID:   team.ID,
+				Username: owner.Username,
+			})
+			errs <- err
+		}()
+	}
+
+	var failed int
+	for range owners {
+		if err := <-errs; err != nil {
+			assert.ErrorIs(t, err, ErrLastTeamOwner)
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed)
+
+	members, err := testStore.ListTeamMembers(context.Background(), team.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, TeamRoleOwner, members[0].Role)
+}
+
+func TestListTeamLeaderboard(t *testing.T) {
+	owner := createRandomUser(t)
+	team := createRandomTeam(t, owner.Username)
+
+	for _, gflops := range []float64{1e9, 3e9, 2e9} {
+		_, err := testStore.CreateScore(context.Background(), CreateScoreParams{
+			UserID:       owner.Username,
+			Gflops:       gflops,
+			SubmittedAt:  time.Now(),
+			TeamID:       team.ID,
+			ReviewStatus: "accepted",
+			Benchmark:    "hpl",
+		})
+		require.NoError(t, err)
+	}
+
+	rows, err := testStore.ListTeamLeaderboard(context.Background(), ListTeamLeaderboardParams{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, team.ID, rows[0].ID)
+	assert.Equal(t, 3e9, rows[0].BestGflops)
+	assert.Equal(t, int64(3), rows[0].Submissions)
+}
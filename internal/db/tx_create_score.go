@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrRunNonceInvalid is returned when the run nonce is unknown, belongs to
+// another user, has expired or was already used by another submission.
+var ErrRunNonceInvalid = errors.New("run nonce is invalid, expired or already used")
+
+// CreateScoresTxParams contains the input parameters of the create scores transaction
+type CreateScoresTxParams struct {
+	RunNonce string
+	Username string
+	Scores   []CreateScoreParams
+}
+
+// CreateScoresTx consumes the run nonce and records every score of the run
+// within a single transaction, so a failed insert leaves the nonce usable.
+func (store *SQLStore) CreateScoresTx(ctx context.Context, arg CreateScoresTxParams) ([]Score, error) {
+	var scores []Score
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		rows, err := q.UseRunNonce(ctx, UseRunNonceParams{
+			Nonce:    arg.RunNonce,
+			Username: arg.Username,
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return ErrRunNonceInvalid
+		}
+
+		for _, scoreArg := range arg.Scores {
+			score, err := q.CreateScore(ctx, scoreArg)
+			if err != nil {
+				return err
+			}
+			scores = append(scores, score)
+		}
+		return nil
+	})
+
+	return scores, err
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+)
+
+// CreateOIDCUserTxParams contains the input parameters of the create OIDC user transaction
+type CreateOIDCUserTxParams struct {
+	Issuer   string
+	Subject  string
+	Username string
+}
+
+// CreateOIDCUserTx creates a local account without a password and links it to
+// the OIDC identity (issuer, subject) within a single transaction.
+func (store *SQLStore) CreateOIDCUserTx(ctx context.Context, arg CreateOIDCUserTxParams) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		// 空的 hashed_password 不是合法的 bcrypt 雜湊，此帳號無法以本地密碼登入
+		user, err = q.CreateUser(ctx, CreateUserParams{
+			Username:       arg.Username,
+			HashedPassword: "",
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateOIDCIdentity(ctx, CreateOIDCIdentityParams{
+			Issuer:   arg.Issuer,
+			Subject:  arg.Subject,
+			Username: arg.Username,
+		})
+		return err
+	})
+
+	return user, err
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrSessionAlreadyRotated is returned when the session being rotated has
+// already been used, revoked, or is being rotated by a concurrent request.
+var ErrSessionAlreadyRotated = errors.New("session already rotated")
+
+// RotateSessionTxParams contains the input parameters of the rotate session transaction
+type RotateSessionTxParams struct {
+	OldSessionID pgtype.UUID
+	NewSession   CreateSessionParams
+}
+
+// RotateSessionTxResult is the result of the rotate session transaction
+type RotateSessionTxResult struct {
+	Session Session
+}
+
+// RotateSessionTx marks the old session as rotated and creates its replacement
+// in the same family within a single transaction.
+func (store *SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error) {
+	var result RotateSessionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		rows, err := q.MarkSessionRotated(ctx, MarkSessionRotatedParams{
+			ReplacedBy: arg.NewSession.ID,
+			ID:         arg.OldSessionID,
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return ErrSessionAlreadyRotated
+		}
+
+		result.Session, err = q.CreateSession(ctx, arg.NewSession)
+		return err
+	})
+
+	return result, err
+}
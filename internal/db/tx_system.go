@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrSystemNodesInUse is returned when a system would shrink below the number
+// of nodes one of its scores was run on.
+var ErrSystemNodesInUse = errors.New("system has scores on more nodes than requested")
+
+// UpdateSystemTxParams contains the input parameters of the update system transaction
+type UpdateSystemTxParams struct {
+	UpdateSystemParams
+	// ReviewScore 依新的 Rpeak 重新評估一筆未被拒絕的成績，回傳審核狀態與原因；
+	// 只在每節點峰值改變時呼叫，結果與原本相同時不會更新
+	ReviewScore func(score Score) (status string, reason string)
+}
+
+// UpdateSystemTx updates a system, recomputes rpeak_gflops of its scores from
+// the new per-node peak and re-evaluates their review status within a single
+// transaction.
+func (store *SQLStore) UpdateSystemTx(ctx context.Context, arg UpdateSystemTxParams) (System, error) {
+	var system System
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		old, err := q.GetSystem(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		maxNodes, err := q.GetSystemMaxScoreNodes(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if arg.Nodes < maxNodes {
+			return ErrSystemNodesInUse
+		}
+
+		system, err = q.UpdateSystem(ctx, arg.UpdateSystemParams)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateSystemScoresRpeak(ctx, UpdateSystemScoresRpeakParams{
+			NodeRpeakGflops: system.NodeRpeakGflops,
+			SystemID:        system.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.ReviewScore == nil || system.NodeRpeakGflops == old.NodeRpeakGflops {
+			return nil
+		}
+		scores, err := q.ListSystemScoresForReview(ctx, system.ID)
+		if err != nil {
+			return err
+		}
+		for _, score := range scores {
+			status, reason := arg.ReviewScore(score)
+			if status == score.ReviewStatus && reason == score.ReviewReason {
+				continue
+			}
+			err = q.SetScoreReviewStatus(ctx, SetScoreReviewStatusParams{
+				ID:           score.ID,
+				ReviewStatus: status,
+				ReviewReason: reason,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return system, err
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Team member roles
+const (
+	TeamRoleOwner  = "owner"
+	TeamRoleMember = "member"
+)
+
+var (
+	// ErrTeamInvitationInvalid is returned when an invitation code is unknown,
+	// expired, revoked or has no uses left.
+	ErrTeamInvitationInvalid = errors.New("team invitation is invalid or expired")
+	// ErrAlreadyTeamMember is returned when joining a team the user already belongs to.
+	ErrAlreadyTeamMember = errors.New("user is already a member of the team")
+	// ErrLastTeamOwner is returned when demoting or removing the only owner of a team.
+	ErrLastTeamOwner = errors.New("a team must keep at least one owner")
+)
+
+// CreateTeamTxParams contains the input parameters of the create team transaction
+type CreateTeamTxParams struct {
+	Name  string
+	Owner string
+}
+
+// CreateTeamTxResult is the result of the create team transaction
+type CreateTeamTxResult struct {
+	Team   Team
+	Member TeamMember
+}
+
+// CreateTeamTx creates a team and makes its creator the first owner within a
+// single transaction.
+func (store *SQLStore) CreateTeamTx(ctx context.Context, arg CreateTeamTxParams) (CreateTeamTxResult, error) {
+	var result CreateTeamTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Team, err = q.CreateTeam(ctx, CreateTeamParams{
+			Name:      arg.Name,
+			CreatedBy: arg.Owner,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Member, err = q.AddTeamMember(ctx, AddTeamMemberParams{
+			TeamID:   result.Team.ID,
+			Username: arg.Owner,
+			Role:     TeamRoleOwner,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// JoinTeamTxParams contains the input parameters of the join team transaction
+type JoinTeamTxParams struct {
+	HashedCode string
+	Username   string
+}
+
+// JoinTeamTx consumes one use of an invitation and adds the user to its team
+// as a member within a single transaction. The invitation is left untouched
+// when the user already belongs to the team.
+func (store *SQLStore) JoinTeamTx(ctx context.Context, arg JoinTeamTxParams) (TeamMember, error) {
+	var member TeamMember
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		invitation, err := q.UseTeamInvitation(ctx, arg.HashedCode)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrTeamInvitationInvalid
+			}
+			return err
+		}
+
+		_, err = q.GetTeamMember(ctx, GetTeamMemberParams{
+			TeamID:   invitation.TeamID,
+			Username: arg.Username,
+		})
+		if err == nil {
+			return ErrAlreadyTeamMember
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		member, err = q.AddTeamMember(ctx, AddTeamMemberParams{
+			TeamID:   invitation.TeamID,
+			Username: arg.Username,
+			Role:     TeamRoleMember,
+		})
+		return err
+	})
+
+	return member, err
+}
+
+// ensureAnotherTeamOwner 鎖住隊伍所有 owner 的資料列，並確認 username 不是唯一的 owner。
+// 同時降級或移除兩個 owner 的交易會在此排隊，後到者看到的是已提交的結果。
+func ensureAnotherTeamOwner(ctx context.Context, q *Queries, teamID pgtype.UUID, username string) error {
+	owners, err := q.LockTeamOwners(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(owners, username) && len(owners) <= 1 {
+		return ErrLastTeamOwner
+	}
+	return nil
+}
+
+// UpdateTeamMemberRoleTx changes a member's role within a single transaction
+// that refuses to demote the last owner (ErrLastTeamOwner).
+func (store *SQLStore) UpdateTeamMemberRoleTx(ctx context.Context, arg UpdateTeamMemberRoleParams) (TeamMember, error) {
+	var member TeamMember
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if arg.Role != TeamRoleOwner {
+			if err := ensureAnotherTeamOwner(ctx, q, arg.TeamID, arg.Username); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		member, err = q.UpdateTeamMemberRole(ctx, arg)
+		return err
+	})
+
+	return member, err
+}
+
+// RemoveTeamMemberTx removes a member within a single transaction that
+// refuses to remove the last owner (ErrLastTeamOwner). It returns the number
+// of rows deleted.
+func (store *SQLStore) RemoveTeamMemberTx(ctx context.Context, arg RemoveTeamMemberParams) (int64, error) {
+	var rows int64
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := ensureAnotherTeamOwner(ctx, q, arg.TeamID, arg.Username); err != nil {
+			return err
+		}
+
+		var err error
+		rows, err = q.RemoveTeamMember(ctx, arg)
+		return err
+	})
+
+	return rows, err
+}
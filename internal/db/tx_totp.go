@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrTOTPNotPending is returned when TOTP is enabled for a user that has no
+// pending enrollment, either because none was started or because it has
+// already been confirmed.
+var ErrTOTPNotPending = errors.New("no pending TOTP enrollment")
+
+// EnableTOTPTxParams contains the input parameters of the enable TOTP transaction
+type EnableTOTPTxParams struct {
+	Username            string
+	HashedRecoveryCodes []string
+}
+
+// EnableTOTPTx confirms a pending TOTP enrollment and replaces the user's
+// recovery codes within a single transaction.
+func (store *SQLStore) EnableTOTPTx(ctx context.Context, arg EnableTOTPTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		rows, err := q.EnableTOTP(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return ErrTOTPNotPending
+		}
+
+		if err := q.DeleteRecoveryCodes(ctx, arg.Username); err != nil {
+			return err
+		}
+		for _, hashedCode := range arg.HashedRecoveryCodes {
+			err := q.CreateRecoveryCode(ctx, CreateRecoveryCodeParams{
+				Username:   arg.Username,
+				HashedCode: hashedCode,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DisableTOTPTx removes the user's TOTP secret and recovery codes within a
+// single transaction.
+func (store *SQLStore) DisableTOTPTx(ctx context.Context, username string) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		if err := q.DisableTOTP(ctx, username); err != nil {
+			return err
+		}
+		return q.DeleteRecoveryCodes(ctx, username)
+	})
+}
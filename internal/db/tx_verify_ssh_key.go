@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// ErrSSHKeyChallengeInvalid is returned when the challenge being answered
+	// is missing, expired or has already been used.
+	ErrSSHKeyChallengeInvalid = errors.New("ssh key challenge is missing or expired")
+	// ErrLinuxAccountTaken is returned when the linux username is already
+	// bound to another user.
+	ErrLinuxAccountTaken = errors.New("linux account already bound to another user")
+)
+
+// VerifySSHKeyTxParams contains the input parameters of the verify SSH key transaction
+type VerifySSHKeyTxParams struct {
+	ID        pgtype.UUID
+	Username  string
+	Challenge string
+}
+
+// VerifySSHKeyTxResult is the result of the verify SSH key transaction
+type VerifySSHKeyTxResult struct {
+	SSHKey SshKey
+}
+
+// VerifySSHKeyTx consumes the answered challenge, marks the key verified and
+// binds its linux username to the key's owner within a single transaction.
+func (store *SQLStore) VerifySSHKeyTx(ctx context.Context, arg VerifySSHKeyTxParams) (VerifySSHKeyTxResult, error) {
+	var result VerifySSHKeyTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.SSHKey, err = q.MarkSSHKeyVerified(ctx, MarkSSHKeyVerifiedParams{
+			ID:        arg.ID,
+			Username:  arg.Username,
+			Challenge: arg.Challenge,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrSSHKeyChallengeInvalid
+			}
+			return err
+		}
+
+		rows, err := q.ClaimLinuxAccount(ctx, ClaimLinuxAccountParams{
+			LinuxUsername: result.SSHKey.LinuxUsername,
+			Username:      result.SSHKey.Username,
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return ErrLinuxAccountTaken
+		}
+		return nil
+	})
+
+	return result, err
+}
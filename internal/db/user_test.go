@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomUser(t *testing.T) User {
+	arg := CreateUserParams{
+		Username:       "user-" + uuid.NewString()[:8],
+		HashedPassword: "$2a$10$mockhashedpassword",
+	}
+
+	user, err := testStore.CreateUser(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, arg.Username, user.Username)
+	assert.Equal(t, arg.HashedPassword, user.HashedPassword)
+	assert.Equal(t, []string{"participant"}, user.Roles)
+	assert.NotZero(t, user.CreatedAt)
+
+	return user
+}
+
+func TestCreateUser(t *testing.T) {
+	createRandomUser(t)
+}
+
+func TestGetUser(t *testing.T) {
+	user1 := createRandomUser(t)
+
+	user2, err := testStore.GetUser(context.Background(), user1.Username)
+	require.NoError(t, err)
+	assert.Equal(t, user1.Username, user2.Username)
+	assert.Equal(t, user1.HashedPassword, user2.HashedPassword)
+
+	_, err = testStore.GetUser(context.Background(), "missing-"+uuid.NewString())
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestUpdateUserRoles(t *testing.T) {
+	user := createRandomUser(t)
+
+	updated, err := testStore.UpdateUserRoles(context.Background(), UpdateUserRolesParams{
+		Roles:    []string{"participant", "judge"},
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"participant", "judge"}, updated.Roles)
+
+	// 不在允許清單內的角色會被 CHECK 限制擋下
+	_, err = testStore.UpdateUserRoles(context.Background(), UpdateUserRolesParams{
+		Roles:    []string{"root"},
+		Username: user.Username,
+	})
+	assert.Error(t, err)
+}
+
+func TestAddUserRoleIsIdempotent(t *testing.T) {
+	user := createRandomUser(t)
+
+	for i := 0; i < 2; i++ {
+		err := testStore.AddUserRole(context.Background(), AddUserRoleParams{
+			Role:     "admin",
+			Username: user.Username,
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := testStore.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"participant", "admin"}, got.Roles)
+}
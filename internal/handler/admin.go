@@ -0,0 +1,324 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+)
+
+type UpdateUserRolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+type DisqualifyScoreRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ReviewScoreRequest 是審核佇列中成績的決定，decision 為 approve 或 reject
+type ReviewScoreRequest struct {
+	Decision string `json:"decision"`
+	Reason   string `json:"reason"`
+}
+
+// RevokeUserTokens 撤銷指定使用者目前所有的 Access Token 與 Refresh Token
+func (h *Handler) RevokeUserTokens(w http.ResponseWriter, r *http.Request) {
+	username := r.PathValue("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	// 撤銷紀錄需保留到最長效期的 Token 過期為止
+	if err := h.service.RevokeUserTokens(r.Context(), username, time.Now().Add(refreshTokenDuration)); err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnlockUser 解除帳號因登入失敗過多而被暫時鎖定的狀態
+func (h *Handler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	username := r.PathValue("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.UnlockUser(r.Context(), username, payload.Username); err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResetUserMFA 在使用者同時遺失 Authenticator 與救援碼時停用其 MFA
+func (h *Handler) ResetUserMFA(w http.ResponseWriter, r *http.Request) {
+	username := r.PathValue("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.ResetMFA(r.Context(), username, payload.Username); err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveLinuxAccount 解除叢集帳號的綁定，讓其他使用者可以重新驗證
+func (h *Handler) RemoveLinuxAccount(w http.ResponseWriter, r *http.Request) {
+	linuxUsername := r.PathValue("linux_username")
+	if linuxUsername == "" {
+		http.Error(w, "Linux username is required", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RemoveLinuxAccount(r.Context(), linuxUsername, payload.Username); err != nil {
+		if errors.Is(err, service.ErrLinuxAccountNotFound) {
+			http.Error(w, "Linux account not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateUserRoles 設定使用者的角色，新角色會在下次換發 Access Token 時生效
+func (h *Handler) UpdateUserRoles(w http.ResponseWriter, r *http.Request) {
+	username := r.PathValue("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateUserRolesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Roles) == 0 {
+		http.Error(w, "At least one role is required", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.service.UpdateUserRoles(r.Context(), username, req.Roles)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidRole):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrUserNotFound):
+			http.Error(w, "User not found", http.StatusNotFound)
+		default:
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(UserResponse{
+		Username: user.Username,
+		Roles:    user.Roles,
+	}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// DeleteScore 永久刪除一筆成績
+func (h *Handler) DeleteScore(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid score id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteScore(r.Context(), id); err != nil {
+		if errors.Is(err, service.ErrScoreNotFound) {
+			http.Error(w, "Score not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DisqualifyScore 將成績標記為失格，該成績不再出現在排行榜上但仍保留紀錄
+func (h *Handler) DisqualifyScore(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid score id", http.StatusBadRequest)
+		return
+	}
+
+	var req DisqualifyScoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Reason == "" {
+		http.Error(w, "Reason is required", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	score, err := h.service.DisqualifyScore(r.Context(), service.DisqualifyScoreParams{
+		ID:             id,
+		Reason:         req.Reason,
+		DisqualifiedBy: payload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrScoreNotFound) {
+			http.Error(w, "Score not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(score); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListReviewQueue 列出 efficiency 超過審核門檻、等待審核的成績，最早送出的在前
+func (h *Handler) ListReviewQueue(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePagination(r, 50, 500)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.ListReviewQueue(r.Context(), limit, offset)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ReviewScore 核准或拒絕審核佇列中的成績，核准後成績才會出現在排行榜上
+func (h *Handler) ReviewScore(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid score id", http.StatusBadRequest)
+		return
+	}
+
+	var req ReviewScoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Decision != "approve" && req.Decision != "reject" {
+		http.Error(w, "Decision must be approve or reject", http.StatusBadRequest)
+		return
+	}
+	if req.Reason == "" {
+		http.Error(w, "Reason is required", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	score, err := h.service.ReviewScore(r.Context(), service.ReviewScoreParams{
+		ID:         id,
+		Approve:    req.Decision == "approve",
+		Reason:     req.Reason,
+		ReviewedBy: payload.Username,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrScoreNotFound):
+			http.Error(w, "Score not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrScoreNotPending):
+			http.Error(w, "Score is not waiting for review", http.StatusConflict)
+		default:
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(score); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListAuditEvents 列出稽核紀錄 (例如登入鎖定與解鎖)，最新的在前
+func (h *Handler) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePagination(r, 50, 500)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	events, err := h.service.ListAuditEvents(r.Context(), limit, offset)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if events == nil {
+		events = []db.AuditEvent{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(events); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
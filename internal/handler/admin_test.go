@@ -0,0 +1,553 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/middleware"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRevokeUserTokens(t *testing.T) {
+	testCases := []struct {
+		name           string
+		username       string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful revocation",
+			username:       "leaked-user",
+			expectedStatus: http.StatusNoContent,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("RevokeUserTokens", mock.Anything, "leaked-user", mock.MatchedBy(func(retainUntil time.Time) bool {
+					// 撤銷紀錄至少要保留到 Refresh Token 過期
+					return !retainUntil.Before(time.Now().Add(refreshTokenDuration - time.Minute))
+				})).Return(nil)
+			},
+		},
+		{
+			name:           "service layer error",
+			username:       "leaked-user",
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("RevokeUserTokens", mock.Anything, "leaked-user", mock.Anything).Return(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			mockTokenMaker := new(token_mocks.Maker)
+			h := NewHandler(mockService, mockTokenMaker)
+
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/"+tc.username+"/revoke-tokens", nil)
+			req.SetPathValue("username", tc.username)
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.RevokeUserTokens).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateUserRoles(t *testing.T) {
+	testCases := []struct {
+		name           string
+		username       string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "promote to judge",
+			username:       "agent-lead",
+			requestBody:    `{"roles": ["participant", "judge"]}`,
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UpdateUserRoles", mock.Anything, "agent-lead", []string{token.RoleParticipant, token.RoleJudge}).
+					Return(&db.User{Username: "agent-lead", Roles: []string{token.RoleParticipant, token.RoleJudge}}, nil)
+			},
+		},
+		{
+			name:           "empty roles",
+			username:       "agent-lead",
+			requestBody:    `{"roles": []}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "unknown role",
+			username:       "agent-lead",
+			requestBody:    `{"roles": ["root"]}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UpdateUserRoles", mock.Anything, "agent-lead", []string{"root"}).Return(nil, service.ErrInvalidRole)
+			},
+		},
+		{
+			name:           "user not found",
+			username:       "ghost",
+			requestBody:    `{"roles": ["admin"]}`,
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UpdateUserRoles", mock.Anything, "ghost", []string{token.RoleAdmin}).Return(nil, service.ErrUserNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			mockTokenMaker := new(token_mocks.Maker)
+			h := NewHandler(mockService, mockTokenMaker)
+
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/users/"+tc.username+"/roles", bytes.NewBufferString(tc.requestBody))
+			req.SetPathValue("username", tc.username)
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.UpdateUserRoles).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var resp UserResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, []string{token.RoleParticipant, token.RoleJudge}, resp.Roles)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteScore(t *testing.T) {
+	scoreID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		id             string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful deletion",
+			id:             scoreID.String(),
+			expectedStatus: http.StatusNoContent,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("DeleteScore", mock.Anything, scoreID).Return(nil)
+			},
+		},
+		{
+			name:           "invalid id",
+			id:             "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "score not found",
+			id:             scoreID.String(),
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("DeleteScore", mock.Anything, scoreID).Return(service.ErrScoreNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			mockTokenMaker := new(token_mocks.Maker)
+			h := NewHandler(mockService, mockTokenMaker)
+
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/scores/"+tc.id, nil)
+			req.SetPathValue("id", tc.id)
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.DeleteScore).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDisqualifyScore(t *testing.T) {
+	scoreID := uuid.New()
+	judge := &token.Payload{Username: "judge", Roles: []string{token.RoleJudge}, ExpiredAt: time.Now().Add(time.Hour)}
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		payload        *token.Payload
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful disqualification",
+			requestBody:    `{"reason": "ran on unapproved hardware"}`,
+			payload:        judge,
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("DisqualifyScore", mock.Anything, service.DisqualifyScoreParams{
+					ID:             scoreID,
+					Reason:         "ran on unapproved hardware",
+					DisqualifiedBy: "judge",
+				}).Return(&db.Score{DisqualifiedBy: "judge", DisqualificationReason: "ran on unapproved hardware"}, nil)
+			},
+		},
+		{
+			name:           "missing reason",
+			requestBody:    `{}`,
+			payload:        judge,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "score not found",
+			requestBody:    `{"reason": "duplicate"}`,
+			payload:        judge,
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("DisqualifyScore", mock.Anything, mock.Anything).Return(nil, service.ErrScoreNotFound)
+			},
+		},
+		{
+			name:           "missing payload",
+			requestBody:    `{"reason": "duplicate"}`,
+			payload:        nil,
+			expectedStatus: http.StatusUnauthorized,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			mockTokenMaker := new(token_mocks.Maker)
+			h := NewHandler(mockService, mockTokenMaker)
+
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/scores/"+scoreID.String()+"/disqualify", bytes.NewBufferString(tc.requestBody))
+			req.SetPathValue("id", scoreID.String())
+			if tc.payload != nil {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorizationPayloadKey, tc.payload))
+			}
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.DisqualifyScore).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReviewScore(t *testing.T) {
+	scoreID := uuid.New()
+	judge := &token.Payload{Username: "judge", Roles: []string{token.RoleJudge}, ExpiredAt: time.Now().Add(time.Hour)}
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "approve",
+			requestBody:    `{"decision": "approve", "reason": "checked the HPL.out"}`,
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("ReviewScore", mock.Anything, service.ReviewScoreParams{
+					ID:         scoreID,
+					Approve:    true,
+					Reason:     "checked the HPL.out",
+					ReviewedBy: "judge",
+				}).Return(&db.Score{ReviewStatus: service.ReviewStatusAccepted, ReviewReason: "checked the HPL.out", ReviewedBy: "judge"}, nil)
+			},
+		},
+		{
+			name:           "reject",
+			requestBody:    `{"decision": "reject", "reason": "node count does not match the allocation"}`,
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("ReviewScore", mock.Anything, mock.MatchedBy(func(arg service.ReviewScoreParams) bool {
+					return !arg.Approve
+				})).Return(&db.Score{ReviewStatus: service.ReviewStatusRejected}, nil)
+			},
+		},
+		{
+			name:           "unknown decision",
+			requestBody:    `{"decision": "maybe", "reason": "unsure"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "missing reason",
+			requestBody:    `{"decision": "approve"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "already reviewed",
+			requestBody:    `{"decision": "approve", "reason": "checked the HPL.out"}`,
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("ReviewScore", mock.Anything, mock.Anything).Return(nil, service.ErrScoreNotPending)
+			},
+		},
+		{
+			name:           "score not found",
+			requestBody:    `{"decision": "approve", "reason": "checked the HPL.out"}`,
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("ReviewScore", mock.Anything, mock.Anything).Return(nil, service.ErrScoreNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/scores/"+scoreID.String()+"/review", bytes.NewBufferString(tc.requestBody))
+			req.SetPathValue("id", scoreID.String())
+			req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorizationPayloadKey, judge))
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.ReviewScore).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListReviewQueue(t *testing.T) {
+	mockService := new(mocks.Service)
+	h := NewHandler(mockService, new(token_mocks.Maker))
+
+	mockService.On("ListReviewQueue", mock.Anything, int32(50), int32(0)).Return(&service.PaginatedScoresResponse{
+		Scores:       []db.Score{{ReviewStatus: service.ReviewStatusPending, ReviewReason: "efficiency 93.4% is above the review threshold of 90%"}},
+		TotalRecords: 1,
+		Limit:        50,
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(h.ListReviewQueue).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/admin/reviews", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp service.PaginatedScoresResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Len(t, resp.Scores, 1)
+	mockService.AssertExpectations(t)
+}
+
+func TestUnlockUser(t *testing.T) {
+	admin := &token.Payload{Username: "judge-lead", Roles: []string{token.RoleAdmin}, ExpiredAt: time.Now().Add(time.Hour)}
+
+	testCases := []struct {
+		name           string
+		payload        *token.Payload
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful unlock",
+			payload:        admin,
+			expectedStatus: http.StatusNoContent,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UnlockUser", mock.Anything, "locked-user", "judge-lead").Return(nil)
+			},
+		},
+		{
+			name:           "service layer error",
+			payload:        admin,
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UnlockUser", mock.Anything, "locked-user", "judge-lead").Return(assert.AnError)
+			},
+		},
+		{
+			name:           "missing payload",
+			payload:        nil,
+			expectedStatus: http.StatusUnauthorized,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/locked-user/unlock", nil)
+			req.SetPathValue("username", "locked-user")
+			if tc.payload != nil {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorizationPayloadKey, tc.payload))
+			}
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.UnlockUser).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResetUserMFA(t *testing.T) {
+	admin := &token.Payload{Username: "judge-lead", Roles: []string{token.RoleAdmin}, MFA: true, ExpiredAt: time.Now().Add(time.Hour)}
+
+	testCases := []struct {
+		name           string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful reset",
+			expectedStatus: http.StatusNoContent,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("ResetMFA", mock.Anything, "lost-phone", "judge-lead").Return(nil)
+			},
+		},
+		{
+			name:           "user not found",
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("ResetMFA", mock.Anything, "lost-phone", "judge-lead").Return(service.ErrUserNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/users/lost-phone/mfa", nil)
+			req.SetPathValue("username", "lost-phone")
+			req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorizationPayloadKey, admin))
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.ResetUserMFA).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRemoveLinuxAccount(t *testing.T) {
+	admin := &token.Payload{Username: "judge-lead", Roles: []string{token.RoleAdmin}, MFA: true, ExpiredAt: time.Now().Add(time.Hour)}
+
+	testCases := []struct {
+		name           string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful removal",
+			expectedStatus: http.StatusNoContent,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("RemoveLinuxAccount", mock.Anything, "hpl_user1", "judge-lead").Return(nil)
+			},
+		},
+		{
+			name:           "linux account not found",
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("RemoveLinuxAccount", mock.Anything, "hpl_user1", "judge-lead").Return(service.ErrLinuxAccountNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/linux-accounts/hpl_user1", nil)
+			req.SetPathValue("linux_username", "hpl_user1")
+			req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorizationPayloadKey, admin))
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.RemoveLinuxAccount).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListAuditEvents(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "default pagination",
+			query:          "",
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("ListAuditEvents", mock.Anything, int32(50), int32(0)).Return([]db.AuditEvent{
+					{ID: 1, Event: service.AuditEventLoginLockout, Subject: "agent-lead"},
+				}, nil)
+			},
+		},
+		{
+			name:           "limit too large",
+			query:          "?limit=1000",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/audit-events"+tc.query, nil)
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.ListAuditEvents).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+)
+
+// CreateAPIKeyRequest 定義建立 API Key 的請求格式
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeyResponse 描述一把 API Key，不含 Key 本身
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse 額外帶回明文 Key，之後無法再取得
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func optionalTime(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func newAPIKeyResponse(apiKey db.ApiKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         apiKey.ID.Bytes,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.Scopes,
+		ExpiresAt:  optionalTime(apiKey.ExpiresAt),
+		LastUsedAt: optionalTime(apiKey.LastUsedAt),
+		RevokedAt:  optionalTime(apiKey.RevokedAt),
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
+
+func (h *Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == "" {
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+
+	var expiresAt time.Time
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(time.Now()) {
+			http.Error(w, "expires_at must be in the future", http.StatusBadRequest)
+			return
+		}
+		expiresAt = *req.ExpiresAt
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	key, apiKey, err := h.service.CreateAPIKey(r.Context(), service.CreateAPIKeyParams{
+		Username:  payload.Username,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidScope) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(CreateAPIKeyResponse{
+		APIKeyResponse: newAPIKeyResponse(*apiKey),
+		Key:            key,
+	}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	apiKeys, err := h.service.ListAPIKeys(r.Context(), payload.Username)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]APIKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		resp = append(resp, newAPIKeyResponse(apiKey))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RevokeAPIKey 撤銷自己的一把 API Key
+func (h *Handler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid API key id", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(r.Context(), id, payload.Username); err != nil {
+		if errors.Is(err, service.ErrAPIKeyNotFound) {
+			http.Error(w, "API key not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/middleware"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func withAuthPayload(req *http.Request, username string) *http.Request {
+	payload := &token.Payload{Username: username, TokenType: token.TokenTypeAccess, ExpiredAt: time.Now().Add(time.Hour)}
+	return req.WithContext(context.WithValue(req.Context(), middleware.AuthorizationPayloadKey, payload))
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	apiKey := &db.ApiKey{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Username:  "agent-lead",
+		Name:      "cluster-a",
+		Prefix:    "hpl_abcdefgh",
+		HashedKey: "secret-hash",
+		Scopes:    []string{token.ScopeScoresSubmit},
+		CreatedAt: time.Now(),
+	}
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful creation",
+			requestBody:    `{"name": "cluster-a", "scopes": ["scores:submit"]}`,
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateAPIKey", mock.Anything, service.CreateAPIKeyParams{
+					Username: "agent-lead",
+					Name:     "cluster-a",
+					Scopes:   []string{token.ScopeScoresSubmit},
+				}).Return("hpl_abcdefghplaintext", apiKey, nil)
+			},
+		},
+		{
+			name:           "missing name",
+			requestBody:    `{"scopes": ["scores:submit"]}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "expiry in the past",
+			requestBody:    `{"name": "cluster-a", "scopes": ["scores:submit"], "expires_at": "2001-01-01T00:00:00Z"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "invalid scope",
+			requestBody:    `{"name": "cluster-a", "scopes": ["admin"]}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateAPIKey", mock.Anything, mock.Anything).Return("", nil, service.ErrInvalidScope)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/api-keys", bytes.NewBufferString(tc.requestBody))
+			req = withAuthPayload(req, "agent-lead")
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.CreateAPIKey).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				var resp CreateAPIKeyResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, "hpl_abcdefghplaintext", resp.Key)
+				assert.Equal(t, "hpl_abcdefgh", resp.Prefix)
+				// 雜湊值不可外流
+				assert.False(t, strings.Contains(rr.Body.String(), "secret-hash"))
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListAPIKeys(t *testing.T) {
+	mockService := new(mocks.Service)
+	h := NewHandler(mockService, new(token_mocks.Maker))
+
+	mockService.On("ListAPIKeys", mock.Anything, "agent-lead").Return([]db.ApiKey{
+		{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Name: "cluster-a", HashedKey: "secret-hash", Scopes: []string{token.ScopeScoresSubmit}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/api-keys", nil)
+	req = withAuthPayload(req, "agent-lead")
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(h.ListAPIKeys).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp []APIKeyResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, "cluster-a", resp[0].Name)
+	assert.False(t, strings.Contains(rr.Body.String(), "secret-hash"))
+	mockService.AssertExpectations(t)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	keyID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		id             string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful revocation",
+			id:             keyID.String(),
+			expectedStatus: http.StatusNoContent,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("RevokeAPIKey", mock.Anything, keyID, "agent-lead").Return(nil)
+			},
+		},
+		{
+			name:           "invalid id",
+			id:             "nope",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "key of another user",
+			id:             keyID.String(),
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("RevokeAPIKey", mock.Anything, keyID, "agent-lead").Return(service.ErrAPIKeyNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/api-keys/"+tc.id, nil)
+			req.SetPathValue("id", tc.id)
+			req = withAuthPayload(req, "agent-lead")
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.RevokeAPIKey).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
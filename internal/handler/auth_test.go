@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/kdotwei/hpl-scoreboard/internal/middleware"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
 	"github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -22,7 +23,7 @@ func TestAuthMiddleware_Unauthorized(t *testing.T) {
 	})
 
 	// Wrap the mockHandler with AuthMiddleware
-	handlerToTest := middleware.AuthMiddleware(mockTokenMaker)(mockHandler)
+	handlerToTest := middleware.AuthMiddleware(mockTokenMaker, token.NewMemoryRevocationStore())(mockHandler)
 
 	// 2. Execution: simulate sending a request without an Authorization header
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/upload", nil)
@@ -1,19 +1,96 @@
 package handler
 
 import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/auth"
+	"github.com/kdotwei/hpl-scoreboard/internal/middleware"
 	"github.com/kdotwei/hpl-scoreboard/internal/service"
 	"github.com/kdotwei/hpl-scoreboard/internal/token"
 )
 
 type Handler struct {
-	service    service.Service
-	tokenMaker token.Maker // 新增依賴
+	service       service.Service
+	tokenMaker    token.Maker // 新增依賴
+	authenticator auth.Authenticator
+	oidc          oidcProvider
+}
+
+// Option 調整 Handler 的選用依賴
+type Option func(*Handler)
+
+// WithAuthenticator 指定 Login 使用的帳密驗證後端，預設為本地資料庫
+func WithAuthenticator(authenticator auth.Authenticator) Option {
+	return func(h *Handler) {
+		h.authenticator = authenticator
+	}
 }
 
 // NewHandler 更新建構子，注入 TokenMaker
-func NewHandler(s service.Service, tm token.Maker) *Handler {
-	return &Handler{
-		service:    s,
-		tokenMaker: tm,
+func NewHandler(s service.Service, tm token.Maker, opts ...Option) *Handler {
+	h := &Handler{
+		service:       s,
+		tokenMaker:    tm,
+		authenticator: auth.NewLocalAuthenticator(s),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// authPayload 取得 AuthMiddleware 放入 Context 的 Payload
+func authPayload(r *http.Request) (*token.Payload, bool) {
+	payload, ok := r.Context().Value(middleware.AuthorizationPayloadKey).(*token.Payload)
+	return payload, ok && payload != nil
+}
+
+// clientIdentity 回傳 RequireClientCert 放入的憑證身分，未使用 mTLS 時 ok 為 false
+func clientIdentity(r *http.Request) (*middleware.ClientIdentity, bool) {
+	identity, ok := r.Context().Value(middleware.ClientIdentityKey).(*middleware.ClientIdentity)
+	return identity, ok && identity != nil
+}
+
+// clientIP 回傳不含 port 的來源 IP
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// parsePagination 解析 limit (1~maxLimit，預設 defaultLimit) 與 offset (預設 0)
+func parsePagination(r *http.Request, defaultLimit int32, maxLimit int32) (int32, int32, error) {
+	limit, offset := defaultLimit, int32(0)
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || parsedLimit <= 0 || parsedLimit > int64(maxLimit) {
+			return 0, 0, errors.New("invalid limit parameter (must be 1-" + strconv.Itoa(int(maxLimit)) + ")")
+		}
+		limit = int32(parsedLimit)
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		parsedOffset, err := strconv.ParseInt(offsetStr, 10, 32)
+		if err != nil || parsedOffset < 0 {
+			return 0, 0, errors.New("invalid offset parameter (must be >= 0)")
+		}
+		offset = int32(parsedOffset)
+	}
+
+	return limit, offset, nil
+}
+
+// parseResidualFilter 讀取排行榜的 residual 參數，未指定時排除 residual check 失敗的成績
+func parseResidualFilter(r *http.Request) (string, error) {
+	filter := r.URL.Query().Get("residual")
+	if !service.ValidResidualFilter(filter) {
+		return "", errors.New("invalid residual parameter (must be passed, failed or all)")
 	}
+	return filter, nil
 }
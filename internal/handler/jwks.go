@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+)
+
+// JWKS 公開驗證 Token 用的公鑰，只有非對稱簽章的 Maker 才支援
+func (h *Handler) JWKS(w http.ResponseWriter, r *http.Request) {
+	provider, ok := h.tokenMaker.(token.JWKSProvider)
+	if !ok {
+		http.Error(w, "JWKS is not available for the configured token maker", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(provider.JWKS()); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
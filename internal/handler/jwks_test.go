@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKS(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	tokenMaker, err := token.NewAsymmetricJWTMaker("key-1", privateKey)
+	require.NoError(t, err)
+
+	h := NewHandler(new(mocks.Service), tokenMaker)
+
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(h.JWKS).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var jwks token.JSONWebKeySet
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &jwks))
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "key-1", jwks.Keys[0].KeyID)
+	assert.Equal(t, "OKP", jwks.Keys[0].KeyType)
+	// 不可洩漏私鑰欄位
+	assert.NotContains(t, rr.Body.String(), `"d"`)
+}
+
+func TestJWKS_NotAvailable(t *testing.T) {
+	// Mock Maker 沒有實作 JWKSProvider (等同 HMAC 簽章)
+	h := NewHandler(new(mocks.Service), new(token_mocks.Maker))
+
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(h.JWKS).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
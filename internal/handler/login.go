@@ -2,23 +2,39 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/auth"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
 )
 
 // LoginRequest 定義請求格式
 type LoginRequest struct {
 	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	// MFACode 為 TOTP 驗證碼或救援碼，僅在帳號已啟用 MFA 時需要
+	MFACode string `json:"mfa_code,omitempty"`
 }
 
 // LoginResponse 定義回傳格式
 type LoginResponse struct {
-	AccessToken string       `json:"access_token"`
-	User        UserResponse `json:"user"`
+	AccessToken           string       `json:"access_token"`
+	AccessTokenExpiresAt  time.Time    `json:"access_token_expires_at"`
+	RefreshToken          string       `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time    `json:"refresh_token_expires_at"`
+	User                  UserResponse `json:"user"`
 }
 
 type UserResponse struct {
-	Username string `json:"username"`
+	Username   string   `json:"username"`
+	Roles      []string `json:"roles,omitempty"`
+	MFAEnabled bool     `json:"mfa_enabled,omitempty"`
 }
 
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
@@ -28,20 +44,112 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 由於沒有 User DB，我們直接簽發 Token
-	// 設定 Token 有效期為 24 小時
-	accessToken, _, err := h.tokenMaker.CreateToken(req.Username, 24*time.Hour)
+	if req.Username == "" || req.Password == "" {
+		http.Error(w, "Username and password are required", http.StatusBadRequest)
+		return
+	}
+
+	// 帳號或來源 IP 連續失敗過多次時暫時鎖定，鎖定期間不檢查密碼
+	ip := clientIP(r)
+	lockedUntil, err := h.service.CheckLoginLockout(r.Context(), req.Username, ip)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if !lockedUntil.IsZero() {
+		retryAfter := int(math.Ceil(time.Until(lockedUntil).Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+		http.Error(w, "Too many failed login attempts, try again later", http.StatusTooManyRequests)
+		return
+	}
+
+	// 先驗證帳號密碼，再簽發 Token
+	if err := h.authenticator.Authenticate(r.Context(), req.Username, req.Password); err != nil {
+		// 未知帳號與密碼錯誤回傳相同訊息，避免洩漏帳號是否存在
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			log.Printf("login failed for %q from %s: %v", req.Username, ip, err)
+			if err := h.service.RecordLoginFailure(r.Context(), req.Username, ip); err != nil {
+				log.Printf("cannot record login failure for %q: %v", req.Username, err)
+			}
+			http.Error(w, "Invalid username or password", http.StatusUnauthorized)
+			return
+		}
+		log.Printf("authentication backend error for %q: %v", req.Username, err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	// 外部目錄 (htpasswd / LDAP) 的使用者在第一次登入時建立本地帳號，角色以本地為準
+	user, err := h.service.EnsureUser(r.Context(), req.Username)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	// 已啟用 MFA 的帳號必須再通過第二因素，錯誤的驗證碼與錯誤密碼一樣計入鎖定
+	mfa := user.TotpEnabledAt.Valid
+	if mfa {
+		if req.MFACode == "" {
+			http.Error(w, "MFA code required", http.StatusUnauthorized)
+			return
+		}
+		if err := h.service.VerifyMFA(r.Context(), user.Username, req.MFACode); err != nil {
+			if errors.Is(err, service.ErrInvalidMFACode) {
+				log.Printf("login failed for %q from %s: %v", req.Username, ip, err)
+				if err := h.service.RecordLoginFailure(r.Context(), req.Username, ip); err != nil {
+					log.Printf("cannot record login failure for %q: %v", req.Username, err)
+				}
+				http.Error(w, "Invalid MFA code", http.StatusUnauthorized)
+				return
+			}
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := h.service.RecordLoginSuccess(r.Context(), req.Username); err != nil {
+		log.Printf("cannot reset login failures for %q: %v", req.Username, err)
+	}
+
+	h.issueLoginTokens(w, r, user, mfa)
+}
+
+// issueLoginTokens 簽發 Access/Refresh Token、建立 Session 並寫出 LoginResponse。
+// mfa 表示本次登入已通過第二因素，會記錄在兩種 Token 中。
+func (h *Handler) issueLoginTokens(w http.ResponseWriter, r *http.Request, user *db.User, mfa bool) {
+	// 短效 Access Token + 長效 Refresh Token
+	accessToken, accessPayload, err := h.tokenMaker.CreateToken(user.Username, user.Roles, mfa, accessTokenDuration)
 	if err != nil {
 		http.Error(w, "Failed to create access token", http.StatusInternalServerError)
 		return
 	}
 
+	refreshToken, refreshPayload, err := h.tokenMaker.CreateRefreshToken(user.Username, mfa, refreshTokenDuration)
+	if err != nil {
+		http.Error(w, "Failed to create refresh token", http.StatusInternalServerError)
+		return
+	}
+
+	// Refresh Token 以 Payload.ID 存入 sessions 表
+	_, err = h.service.CreateSession(r.Context(), service.CreateSessionParams{
+		ID:        refreshPayload.ID,
+		Username:  user.Username,
+		UserAgent: r.UserAgent(),
+		ClientIP:  r.RemoteAddr,
+		ExpiresAt: refreshPayload.ExpiredAt,
+	})
+	if err != nil {
+		http.Error(w, "Failed to create session", http.StatusInternalServerError)
+		return
+	}
+
 	// 回傳結果
 	resp := LoginResponse{
-		AccessToken: accessToken,
-		// Fix S1016: 直接將 req 轉型為 UserResponse
-		// 由於兩個 Struct 欄位名稱與型別一致 (Username string)，Go 允許直接轉型 (忽略 Tags)
-		User: UserResponse(req),
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshPayload.ExpiredAt,
+		User:                  UserResponse{Username: user.Username, Roles: user.Roles, MFAEnabled: user.TotpEnabledAt.Valid},
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -2,12 +2,21 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/auth"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
 	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
 	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks" // 引用剛剛生成的 token mock
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,35 +27,342 @@ func TestLogin(t *testing.T) {
 	mockService := new(mocks.Service)
 	mockTokenMaker := new(token_mocks.Maker) // 新增 TokenMaker Mock
 
-	// 🔴 這裡會報錯：因為目前的 NewHandler 只接受 service，不接受 tokenMaker
 	h := NewHandler(mockService, mockTokenMaker)
 
 	// 2. 準備 Request
 	user := "agent-lead"
-	reqBody := LoginRequest{ // 🔴 這裡會報錯：LoginRequest 尚未定義
+	password := "correct-horse"
+	reqBody := LoginRequest{
 		Username: user,
+		Password: password,
 	}
 	jsonBody, _ := json.Marshal(reqBody)
 
 	// 3. 設定 Mock 行為
-	// 當呼叫 CreateToken 時，回傳一個假 Token
-	mockTokenMaker.On("CreateToken", user, mock.Anything).Return("mock_access_token", nil, nil)
+	// 帳密驗證通過後才會呼叫 CreateToken
+	mockService.On("AuthenticateUser", mock.Anything, user, password).Return(&db.User{Username: user, Roles: []string{token.RoleParticipant}}, nil)
+	mockService.On("CheckLoginLockout", mock.Anything, user, mock.Anything).Return(time.Time{}, nil)
+	mockService.On("RecordLoginSuccess", mock.Anything, user).Return(nil)
+	mockService.On("EnsureUser", mock.Anything, user).Return(&db.User{Username: user, Roles: []string{token.RoleParticipant}}, nil)
+	mockTokenMaker.On("CreateToken", user, []string{token.RoleParticipant}, false, accessTokenDuration).Return("mock_access_token", &token.Payload{
+		ID:        uuid.New(),
+		Username:  user,
+		TokenType: token.TokenTypeAccess,
+		ExpiredAt: time.Now().Add(accessTokenDuration),
+	}, nil)
+	refreshPayload := &token.Payload{
+		ID:        uuid.New(),
+		Username:  user,
+		TokenType: token.TokenTypeRefresh,
+		ExpiredAt: time.Now().Add(refreshTokenDuration),
+	}
+	mockTokenMaker.On("CreateRefreshToken", user, false, refreshTokenDuration).Return("mock_refresh_token", refreshPayload, nil)
+	mockService.On("CreateSession", mock.Anything, mock.MatchedBy(func(arg service.CreateSessionParams) bool {
+		return arg.ID == refreshPayload.ID && arg.Username == user && arg.ExpiresAt.Equal(refreshPayload.ExpiredAt)
+	})).Return(&db.Session{Username: user}, nil)
 
 	req, _ := http.NewRequest("POST", "/api/v1/login", bytes.NewBuffer(jsonBody))
 	rr := httptest.NewRecorder()
 
 	// 4. 執行 Handler
-	// 🔴 這裡會報錯：Login 方法尚未實作
 	http.HandlerFunc(h.Login).ServeHTTP(rr, req)
 
 	// 5. 驗證
 	assert.Equal(t, http.StatusOK, rr.Code)
 
 	// 驗證回傳的 JSON 包含 access_token
-	var resp LoginResponse // 🔴 這裡會報錯：LoginResponse 尚未定義
+	var resp LoginResponse
 	// Fix errcheck: 檢查 Decode 錯誤
 	err := json.NewDecoder(rr.Body).Decode(&resp)
 	assert.NoError(t, err) // 加上這行斷言
 	assert.Equal(t, "mock_access_token", resp.AccessToken)
+	assert.Equal(t, "mock_refresh_token", resp.RefreshToken)
 	assert.Equal(t, user, resp.User.Username) // 假設我們也會回傳 User 資訊
+
+	mockService.AssertExpectations(t)
+	mockTokenMaker.AssertExpectations(t)
+}
+
+func TestLogin_ErrorCases(t *testing.T) {
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "invalid JSON body",
+			requestBody:    `{"username": 123}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "missing password",
+			requestBody:    `{"username": "agent-lead"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "unknown user",
+			requestBody:    `{"username": "ghost", "password": "whatever-pass"}`,
+			expectedStatus: http.StatusUnauthorized,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CheckLoginLockout", mock.Anything, "ghost", mock.Anything).Return(time.Time{}, nil)
+				mockService.On("AuthenticateUser", mock.Anything, "ghost", "whatever-pass").Return(nil, service.ErrUserNotFound)
+				mockService.On("RecordLoginFailure", mock.Anything, "ghost", mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:           "wrong password",
+			requestBody:    `{"username": "agent-lead", "password": "wrong-pass"}`,
+			expectedStatus: http.StatusUnauthorized,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CheckLoginLockout", mock.Anything, "agent-lead", mock.Anything).Return(time.Time{}, nil)
+				mockService.On("AuthenticateUser", mock.Anything, "agent-lead", "wrong-pass").Return(nil, service.ErrIncorrectPassword)
+				mockService.On("RecordLoginFailure", mock.Anything, "agent-lead", mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:           "service layer error",
+			requestBody:    `{"username": "agent-lead", "password": "correct-horse"}`,
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CheckLoginLockout", mock.Anything, "agent-lead", mock.Anything).Return(time.Time{}, nil)
+				mockService.On("AuthenticateUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:           "account locked",
+			requestBody:    `{"username": "agent-lead", "password": "correct-horse"}`,
+			expectedStatus: http.StatusTooManyRequests,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CheckLoginLockout", mock.Anything, "agent-lead", mock.Anything).Return(time.Now().Add(time.Minute), nil)
+			},
+		},
+		{
+			name:           "lockout check error",
+			requestBody:    `{"username": "agent-lead", "password": "correct-horse"}`,
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CheckLoginLockout", mock.Anything, "agent-lead", mock.Anything).Return(time.Time{}, assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			mockTokenMaker := new(token_mocks.Maker)
+			h := NewHandler(mockService, mockTokenMaker)
+
+			tc.setupMock(mockService)
+
+			req, err := http.NewRequest("POST", "/api/v1/login", bytes.NewBufferString(tc.requestBody))
+			assert.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.Login).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+
+			// Token 只能在驗證成功後才簽發
+			mockTokenMaker.AssertNotCalled(t, "CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockTokenMaker.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLogin_MFA(t *testing.T) {
+	user := "judge-lead"
+	mfaUser := &db.User{
+		Username:      user,
+		Roles:         []string{token.RoleJudge},
+		TotpEnabledAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+
+	testCases := []struct {
+		name           string
+		mfaCode        string
+		expectedStatus int
+		setupMocks     func(*mocks.Service, *token_mocks.Maker)
+	}{
+		{
+			name:           "valid TOTP code",
+			mfaCode:        "123456",
+			expectedStatus: http.StatusOK,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockService.On("VerifyMFA", mock.Anything, user, "123456").Return(nil)
+				mockService.On("RecordLoginSuccess", mock.Anything, user).Return(nil)
+				refreshPayload := &token.Payload{ID: uuid.New(), Username: user, TokenType: token.TokenTypeRefresh, ExpiredAt: time.Now().Add(refreshTokenDuration)}
+				// 兩種 Token 都必須帶 MFA 宣告
+				mockTokenMaker.On("CreateToken", user, []string{token.RoleJudge}, true, accessTokenDuration).Return("access", &token.Payload{ExpiredAt: time.Now().Add(accessTokenDuration)}, nil)
+				mockTokenMaker.On("CreateRefreshToken", user, true, refreshTokenDuration).Return("refresh", refreshPayload, nil)
+				mockService.On("CreateSession", mock.Anything, mock.Anything).Return(&db.Session{Username: user}, nil)
+			},
+		},
+		{
+			name:           "missing code",
+			mfaCode:        "",
+			expectedStatus: http.StatusUnauthorized,
+			setupMocks:     func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {},
+		},
+		{
+			name:           "invalid code counts as failed login",
+			mfaCode:        "000000",
+			expectedStatus: http.StatusUnauthorized,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockService.On("VerifyMFA", mock.Anything, user, "000000").Return(service.ErrInvalidMFACode)
+				mockService.On("RecordLoginFailure", mock.Anything, user, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:           "verification error",
+			mfaCode:        "123456",
+			expectedStatus: http.StatusInternalServerError,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockService.On("VerifyMFA", mock.Anything, user, "123456").Return(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			mockTokenMaker := new(token_mocks.Maker)
+			h := NewHandler(mockService, mockTokenMaker)
+
+			mockService.On("CheckLoginLockout", mock.Anything, user, mock.Anything).Return(time.Time{}, nil)
+			mockService.On("AuthenticateUser", mock.Anything, user, "correct-horse").Return(mfaUser, nil)
+			mockService.On("EnsureUser", mock.Anything, user).Return(mfaUser, nil)
+			tc.setupMocks(mockService, mockTokenMaker)
+
+			jsonBody, _ := json.Marshal(LoginRequest{Username: user, Password: "correct-horse", MFACode: tc.mfaCode})
+			req, _ := http.NewRequest("POST", "/api/v1/login", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.Login).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var resp LoginResponse
+				assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+				assert.True(t, resp.User.MFAEnabled)
+			} else {
+				mockTokenMaker.AssertNotCalled(t, "CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				mockService.AssertNotCalled(t, "RecordLoginSuccess", mock.Anything, mock.Anything)
+			}
+			mockService.AssertExpectations(t)
+			mockTokenMaker.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLogin_DoesNotLeakFailureReason(t *testing.T) {
+	mockService := new(mocks.Service)
+	mockTokenMaker := new(token_mocks.Maker)
+	h := NewHandler(mockService, mockTokenMaker)
+
+	mockService.On("CheckLoginLockout", mock.Anything, mock.Anything, mock.Anything).Return(time.Time{}, nil)
+	mockService.On("RecordLoginFailure", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockService.On("AuthenticateUser", mock.Anything, "ghost", mock.Anything).Return(nil, service.ErrUserNotFound)
+	mockService.On("AuthenticateUser", mock.Anything, "agent-lead", mock.Anything).Return(nil, service.ErrIncorrectPassword)
+
+	bodies := make([]string, 0, 2)
+	for _, username := range []string{"ghost", "agent-lead"} {
+		jsonBody, _ := json.Marshal(LoginRequest{Username: username, Password: "wrong-pass"})
+		req, _ := http.NewRequest("POST", "/api/v1/login", bytes.NewBuffer(jsonBody))
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(h.Login).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		bodies = append(bodies, rr.Body.String())
+	}
+
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+// authenticatorFunc 讓測試可以直接以函式替換驗證後端
+type authenticatorFunc func(ctx context.Context, username string, password string) error
+
+func (f authenticatorFunc) Authenticate(ctx context.Context, username string, password string) error {
+	return f(ctx, username, password)
+}
+
+func TestLogin_ExternalAuthenticator(t *testing.T) {
+	directory := authenticatorFunc(func(ctx context.Context, username string, password string) error {
+		if username == "ldap-user" && password == "directory-pass" {
+			return nil
+		}
+		return auth.ErrInvalidCredentials
+	})
+
+	t.Run("first login provisions local account", func(t *testing.T) {
+		mockService := new(mocks.Service)
+		mockTokenMaker := new(token_mocks.Maker)
+		h := NewHandler(mockService, mockTokenMaker, WithAuthenticator(directory))
+
+		refreshPayload := &token.Payload{ID: uuid.New(), Username: "ldap-user", TokenType: token.TokenTypeRefresh, ExpiredAt: time.Now().Add(refreshTokenDuration)}
+		mockService.On("CheckLoginLockout", mock.Anything, "ldap-user", mock.Anything).Return(time.Time{}, nil)
+		mockService.On("RecordLoginSuccess", mock.Anything, "ldap-user").Return(nil)
+		mockService.On("EnsureUser", mock.Anything, "ldap-user").Return(&db.User{Username: "ldap-user", Roles: []string{token.RoleParticipant}}, nil)
+		mockTokenMaker.On("CreateToken", "ldap-user", []string{token.RoleParticipant}, false, accessTokenDuration).Return("access", &token.Payload{ExpiredAt: time.Now().Add(accessTokenDuration)}, nil)
+		mockTokenMaker.On("CreateRefreshToken", "ldap-user", false, refreshTokenDuration).Return("refresh", refreshPayload, nil)
+		mockService.On("CreateSession", mock.Anything, mock.Anything).Return(&db.Session{Username: "ldap-user"}, nil)
+
+		jsonBody, _ := json.Marshal(LoginRequest{Username: "ldap-user", Password: "directory-pass"})
+		req, _ := http.NewRequest("POST", "/api/v1/login", bytes.NewBuffer(jsonBody))
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(h.Login).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		// 外部驗證時不會查本地密碼
+		mockService.AssertNotCalled(t, "AuthenticateUser", mock.Anything, mock.Anything, mock.Anything)
+		mockService.AssertExpectations(t)
+		mockTokenMaker.AssertExpectations(t)
+	})
+
+	t.Run("rejected by directory", func(t *testing.T) {
+		mockService := new(mocks.Service)
+		mockTokenMaker := new(token_mocks.Maker)
+		h := NewHandler(mockService, mockTokenMaker, WithAuthenticator(directory))
+
+		mockService.On("CheckLoginLockout", mock.Anything, "ldap-user", mock.Anything).Return(time.Time{}, nil)
+		mockService.On("RecordLoginFailure", mock.Anything, "ldap-user", mock.Anything).Return(nil)
+
+		jsonBody, _ := json.Marshal(LoginRequest{Username: "ldap-user", Password: "wrong-pass"})
+		req, _ := http.NewRequest("POST", "/api/v1/login", bytes.NewBuffer(jsonBody))
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(h.Login).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockService.AssertNotCalled(t, "EnsureUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLogin_LockedAccountSkipsPasswordCheck(t *testing.T) {
+	mockService := new(mocks.Service)
+	mockTokenMaker := new(token_mocks.Maker)
+	h := NewHandler(mockService, mockTokenMaker)
+
+	mockService.On("CheckLoginLockout", mock.Anything, "agent-lead", "192.0.2.7").Return(time.Now().Add(90*time.Second), nil)
+
+	jsonBody, _ := json.Marshal(LoginRequest{Username: "agent-lead", Password: "correct-horse"})
+	req := httptest.NewRequest("POST", "/api/v1/login", bytes.NewBuffer(jsonBody))
+	req.RemoteAddr = "192.0.2.7:51234"
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(h.Login).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	retryAfter, err := strconv.Atoi(rr.Header().Get("Retry-After"))
+	assert.NoError(t, err)
+	assert.InDelta(t, 90, retryAfter, 2)
+	// 鎖定期間即使密碼正確也不會驗證，也不再累計失敗次數
+	mockService.AssertNotCalled(t, "AuthenticateUser", mock.Anything, mock.Anything, mock.Anything)
+	mockService.AssertNotCalled(t, "RecordLoginFailure", mock.Anything, mock.Anything, mock.Anything)
+	mockService.AssertExpectations(t)
 }
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+)
+
+// MFACodeRequest 帶入 TOTP 驗證碼，停用時也可以使用救援碼
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+// EnrollTOTPResponse 回傳待確認的 secret 與供 Authenticator App 匯入的 otpauth URI
+type EnrollTOTPResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// ConfirmTOTPResponse 帶回救援碼明文，之後無法再取得
+type ConfirmTOTPResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// writeMFAError 將 MFA 相關的 service 錯誤轉成 HTTP 狀態碼
+func writeMFAError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidMFACode):
+		http.Error(w, "Invalid MFA code", http.StatusBadRequest)
+	case errors.Is(err, service.ErrMFAAlreadyEnabled):
+		http.Error(w, "MFA is already enabled", http.StatusConflict)
+	case errors.Is(err, service.ErrMFANotEnabled):
+		http.Error(w, "MFA is not enabled", http.StatusConflict)
+	case errors.Is(err, service.ErrMFANotEnrolled):
+		http.Error(w, "No pending TOTP enrollment", http.StatusConflict)
+	case errors.Is(err, service.ErrUserNotFound):
+		http.Error(w, "User not found", http.StatusNotFound)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// EnrollTOTP 產生新的 TOTP secret，須以 ConfirmTOTP 確認後才會啟用
+func (h *Handler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	enrollment, err := h.service.EnrollTOTP(r.Context(), payload.Username)
+	if err != nil {
+		writeMFAError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(EnrollTOTPResponse{
+		Secret:     enrollment.Secret,
+		OTPAuthURI: enrollment.URI,
+	}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ConfirmTOTP 以第一組驗證碼確認註冊並啟用 MFA，回傳救援碼
+func (h *Handler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	var req MFACodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Code == "" {
+		http.Error(w, "Code is required", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	codes, err := h.service.ConfirmTOTP(r.Context(), payload.Username, req.Code)
+	if err != nil {
+		writeMFAError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(ConfirmTOTPResponse{RecoveryCodes: codes}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// DisableTOTP 停用自己的 MFA，需要目前的驗證碼或救援碼
+func (h *Handler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	var req MFACodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Code == "" {
+		http.Error(w, "Code is required", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.DisableTOTP(r.Context(), payload.Username, req.Code); err != nil {
+		writeMFAError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
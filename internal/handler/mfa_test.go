@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEnrollTOTP(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful enrollment",
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("EnrollTOTP", mock.Anything, "judge-lead").Return(&service.TOTPEnrollment{
+					Secret: "JBSWY3DPEHPK3PXP",
+					URI:    "otpauth://totp/HPL%20Scoreboard:judge-lead?secret=JBSWY3DPEHPK3PXP",
+				}, nil)
+			},
+		},
+		{
+			name:           "already enabled",
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("EnrollTOTP", mock.Anything, "judge-lead").Return(nil, service.ErrMFAAlreadyEnabled)
+			},
+		},
+		{
+			name:           "service error",
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("EnrollTOTP", mock.Anything, "judge-lead").Return(nil, assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := withAuthPayload(httptest.NewRequest(http.MethodPost, "/api/v1/mfa/totp/enroll", nil), "judge-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.EnrollTOTP).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var resp EnrollTOTPResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, "JBSWY3DPEHPK3PXP", resp.Secret)
+				assert.Contains(t, resp.OTPAuthURI, "otpauth://totp/")
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful confirmation",
+			requestBody:    `{"code": "123456"}`,
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("ConfirmTOTP", mock.Anything, "judge-lead", "123456").Return([]string{"AAAA-BBBB-CCCC-DDDD"}, nil)
+			},
+		},
+		{
+			name:           "missing code",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "invalid code",
+			requestBody:    `{"code": "000000"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("ConfirmTOTP", mock.Anything, "judge-lead", "000000").Return(nil, service.ErrInvalidMFACode)
+			},
+		},
+		{
+			name:           "no pending enrollment",
+			requestBody:    `{"code": "123456"}`,
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("ConfirmTOTP", mock.Anything, "judge-lead", "123456").Return(nil, service.ErrMFANotEnrolled)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := withAuthPayload(httptest.NewRequest(http.MethodPost, "/api/v1/mfa/totp/confirm", bytes.NewBufferString(tc.requestBody)), "judge-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.ConfirmTOTP).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var resp ConfirmTOTPResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, []string{"AAAA-BBBB-CCCC-DDDD"}, resp.RecoveryCodes)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDisableTOTP(t *testing.T) {
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "disabled with recovery code",
+			requestBody:    `{"code": "AAAA-BBBB-CCCC-DDDD"}`,
+			expectedStatus: http.StatusNoContent,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("DisableTOTP", mock.Anything, "judge-lead", "AAAA-BBBB-CCCC-DDDD").Return(nil)
+			},
+		},
+		{
+			name:           "missing code",
+			requestBody:    `{"code": ""}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "not enabled",
+			requestBody:    `{"code": "123456"}`,
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("DisableTOTP", mock.Anything, "judge-lead", "123456").Return(service.ErrMFANotEnabled)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := withAuthPayload(httptest.NewRequest(http.MethodPost, "/api/v1/mfa/totp/disable", bytes.NewBufferString(tc.requestBody)), "judge-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.DisableTOTP).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/auth"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"golang.org/x/oauth2"
+)
+
+const (
+	oidcFlowCookie     = "oidc_flow"
+	oidcFlowCookiePath = "/api/v1/auth/oidc"
+	oidcFlowDuration   = 10 * time.Minute
+)
+
+// oidcProvider 是 auth.OIDCProvider 提供給 Handler 的方法
+type oidcProvider interface {
+	AuthCodeURL(state string, codeVerifier string, nonce string) string
+	Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*auth.OIDCIdentity, error)
+}
+
+// WithOIDC 啟用 /api/v1/auth/oidc 登入流程
+func WithOIDC(provider oidcProvider) Option {
+	return func(h *Handler) {
+		h.oidc = provider
+	}
+}
+
+// oidcFlow 在 login 與 callback 之間以 HttpOnly Cookie 保存
+type oidcFlow struct {
+	State        string `json:"state"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+// OIDCLogin 產生 state、PKCE verifier 與 nonce，並將瀏覽器導向 IdP
+func (h *Handler) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	if h.oidc == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	flow := oidcFlow{
+		State:        rand.Text(),
+		CodeVerifier: oauth2.GenerateVerifier(),
+		Nonce:        rand.Text(),
+	}
+	value, err := json.Marshal(flow)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcFlowCookie,
+		Value:    base64.RawURLEncoding.EncodeToString(value),
+		Path:     oidcFlowCookiePath,
+		MaxAge:   int(oidcFlowDuration.Seconds()),
+		HttpOnly: true,
+		Secure:   isHTTPS(r),
+		// IdP 導回時是跨站的 top-level GET，Strict 會讓 Cookie 不被送出
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, h.oidc.AuthCodeURL(flow.State, flow.CodeVerifier, flow.Nonce), http.StatusFound)
+}
+
+// OIDCCallback 驗證 state、以 code 與 PKCE verifier 換取 ID Token，
+// 以 (iss, sub) 找出連結的本地帳號 (第一次登入時建立)，最後簽發與 Login 相同的 Token
+func (h *Handler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	if h.oidc == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	// 一次性的 Cookie，不論成功與否都清除
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcFlowCookie,
+		Value:    "",
+		Path:     oidcFlowCookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isHTTPS(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	query := r.URL.Query()
+	if idpError := query.Get("error"); idpError != "" {
+		log.Printf("OIDC login rejected by identity provider: %s: %s", idpError, query.Get("error_description"))
+		http.Error(w, "Login was rejected by the identity provider", http.StatusUnauthorized)
+		return
+	}
+
+	flow, err := readOIDCFlow(r)
+	if err != nil {
+		http.Error(w, "Login session expired, please start again", http.StatusBadRequest)
+		return
+	}
+
+	state := query.Get("state")
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(flow.State)) != 1 {
+		http.Error(w, "Invalid state parameter", http.StatusBadRequest)
+		return
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		http.Error(w, "Authorization code is required", http.StatusBadRequest)
+		return
+	}
+
+	identity, err := h.oidc.Exchange(r.Context(), code, flow.CodeVerifier, flow.Nonce)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			log.Printf("OIDC login failed: %v", err)
+			http.Error(w, "Invalid authorization code", http.StatusUnauthorized)
+			return
+		}
+		log.Printf("OIDC code exchange error: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	user, err := h.service.EnsureOIDCUser(r.Context(), service.EnsureOIDCUserParams{
+		Issuer:   identity.Issuer,
+		Subject:  identity.Subject,
+		Username: identity.Username,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrUsernameTaken) {
+			// 同名的本地帳號可能有密碼、MFA 或角色，不能因為名稱相同就讓 IdP 身分接管
+			log.Printf("OIDC login refused: username %q is already used by an account not linked to %s", identity.Username, identity.Issuer)
+			http.Error(w, "Username is already used by another account", http.StatusConflict)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	// 本地 TOTP 不在 SSO 流程中檢查，因此 Token 不帶 MFA 宣告
+	h.issueLoginTokens(w, r, user, false)
+}
+
+func readOIDCFlow(r *http.Request) (*oidcFlow, error) {
+	cookie, err := r.Cookie(oidcFlowCookie)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var flow oidcFlow
+	if err := json.Unmarshal(value, &flow); err != nil {
+		return nil, err
+	}
+	if flow.State == "" || flow.CodeVerifier == "" || flow.Nonce == "" {
+		return nil, errors.New("incomplete OIDC flow cookie")
+	}
+	return &flow, nil
+}
+
+// isHTTPS 判斷請求是否經由 HTTPS (含反向代理終止 TLS 的情況)
+func isHTTPS(r *http.Request) bool {
+	return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
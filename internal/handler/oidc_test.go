@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kdotwei/hpl-scoreboard/internal/auth"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOIDCProvider 記錄 AuthCodeURL 的參數，Exchange 只接受對應的 code 與 verifier
+type fakeOIDCProvider struct {
+	state, codeVerifier, nonce string
+}
+
+func (p *fakeOIDCProvider) AuthCodeURL(state string, codeVerifier string, nonce string) string {
+	p.state, p.codeVerifier, p.nonce = state, codeVerifier, nonce
+	return "https://idp.example.edu/authorize?state=" + url.QueryEscape(state)
+}
+
+func (p *fakeOIDCProvider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*auth.OIDCIdentity, error) {
+	if code != "good-code" || codeVerifier != p.codeVerifier || nonce != p.nonce {
+		return nil, auth.ErrInvalidCredentials
+	}
+	return testOIDCIdentity, nil
+}
+
+var testOIDCIdentity = &auth.OIDCIdentity{
+	Issuer:   "https://idp.example.edu",
+	Subject:  "subject-1",
+	Username: "campus-user",
+}
+
+// startOIDCLogin 呼叫 OIDCLogin 並回傳 flow Cookie
+func startOIDCLogin(t *testing.T, h *Handler) *http.Cookie {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/login", nil)
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(h.OIDCLogin).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusFound, rr.Code)
+	assert.Contains(t, rr.Header().Get("Location"), "https://idp.example.edu/authorize")
+
+	cookies := rr.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, oidcFlowCookie, cookies[0].Name)
+	assert.True(t, cookies[0].HttpOnly)
+	return cookies[0]
+}
+
+func TestOIDCLogin(t *testing.T) {
+	provider := &fakeOIDCProvider{}
+	h := NewHandler(new(mocks.Service), new(token_mocks.Maker), WithOIDC(provider))
+
+	startOIDCLogin(t, h)
+
+	assert.NotEmpty(t, provider.state)
+	assert.NotEmpty(t, provider.nonce)
+	// RFC 7636：verifier 長度 43~128
+	assert.GreaterOrEqual(t, len(provider.codeVerifier), 43)
+}
+
+func TestOIDCLoginDisabled(t *testing.T) {
+	h := NewHandler(new(mocks.Service), new(token_mocks.Maker))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/login", nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(h.OIDCLogin).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestOIDCCallback(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          func(p *fakeOIDCProvider) string
+		sendCookie     bool
+		expectedStatus int
+		setupMocks     func(*mocks.Service, *token_mocks.Maker)
+	}{
+		{
+			name: "successful login",
+			query: func(p *fakeOIDCProvider) string {
+				return "code=good-code&state=" + url.QueryEscape(p.state)
+			},
+			sendCookie:     true,
+			expectedStatus: http.StatusOK,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockService.On("EnsureOIDCUser", mock.Anything, service.EnsureOIDCUserParams{
+					Issuer:   "https://idp.example.edu",
+					Subject:  "subject-1",
+					Username: "campus-user",
+				}).Return(&db.User{Username: "campus-user", Roles: []string{token.RoleParticipant}}, nil)
+				mockTokenMaker.On("CreateToken", "campus-user", []string{token.RoleParticipant}, false, accessTokenDuration).
+					Return("access", &token.Payload{ExpiredAt: time.Now().Add(accessTokenDuration)}, nil)
+				mockTokenMaker.On("CreateRefreshToken", "campus-user", false, refreshTokenDuration).
+					Return("refresh", &token.Payload{ID: uuid.New(), ExpiredAt: time.Now().Add(refreshTokenDuration)}, nil)
+				mockService.On("CreateSession", mock.Anything, mock.Anything).Return(&db.Session{Username: "campus-user"}, nil)
+			},
+		},
+		{
+			name: "username belongs to an unlinked account",
+			query: func(p *fakeOIDCProvider) string {
+				return "code=good-code&state=" + url.QueryEscape(p.state)
+			},
+			sendCookie:     true,
+			expectedStatus: http.StatusConflict,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockService.On("EnsureOIDCUser", mock.Anything, mock.Anything).Return(nil, service.ErrUsernameTaken)
+			},
+		},
+		{
+			name: "state mismatch",
+			query: func(p *fakeOIDCProvider) string {
+				return "code=good-code&state=forged"
+			},
+			sendCookie:     true,
+			expectedStatus: http.StatusBadRequest,
+			setupMocks:     func(*mocks.Service, *token_mocks.Maker) {},
+		},
+		{
+			name: "missing flow cookie",
+			query: func(p *fakeOIDCProvider) string {
+				return "code=good-code&state=" + url.QueryEscape(p.state)
+			},
+			sendCookie:     false,
+			expectedStatus: http.StatusBadRequest,
+			setupMocks:     func(*mocks.Service, *token_mocks.Maker) {},
+		},
+		{
+			name: "code rejected by identity provider",
+			query: func(p *fakeOIDCProvider) string {
+				return "code=stolen-code&state=" + url.QueryEscape(p.state)
+			},
+			sendCookie:     true,
+			expectedStatus: http.StatusUnauthorized,
+			setupMocks:     func(*mocks.Service, *token_mocks.Maker) {},
+		},
+		{
+			name: "user denied consent",
+			query: func(p *fakeOIDCProvider) string {
+				return "error=access_denied&state=" + url.QueryEscape(p.state)
+			},
+			sendCookie:     true,
+			expectedStatus: http.StatusUnauthorized,
+			setupMocks:     func(*mocks.Service, *token_mocks.Maker) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := &fakeOIDCProvider{}
+			mockService := new(mocks.Service)
+			mockTokenMaker := new(token_mocks.Maker)
+			h := NewHandler(mockService, mockTokenMaker, WithOIDC(provider))
+
+			tc.setupMocks(mockService, mockTokenMaker)
+			cookie := startOIDCLogin(t, h)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?"+tc.query(provider), nil)
+			if tc.sendCookie {
+				req.AddCookie(cookie)
+			}
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(h.OIDCCallback).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var resp LoginResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, "access", resp.AccessToken)
+				assert.Equal(t, "campus-user", resp.User.Username)
+			} else {
+				mockTokenMaker.AssertNotCalled(t, "CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockService.AssertExpectations(t)
+			mockTokenMaker.AssertExpectations(t)
+		})
+	}
+}
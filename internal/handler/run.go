@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/hplout"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+)
+
+// RunNonceEnv 是建議在執行 HPL 時設定的環境變數名稱，方便記錄在輸出或 env 檔中
+const RunNonceEnv = hplout.RunNonceKey
+
+// RunResponse 回傳本次執行的 nonce；送出成績時放在 run_nonce 欄位
+type RunResponse struct {
+	Nonce     string    `json:"nonce"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Env       string    `json:"env"`
+}
+
+// CreateRun 在開始跑 HPL 前核發 run nonce，成績的 execution_time 必須在核發之後
+func (h *Handler) CreateRun(w http.ResponseWriter, r *http.Request) {
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	runNonce, err := h.service.IssueRunNonce(r.Context(), payload.Username)
+	if err != nil {
+		if errors.Is(err, service.ErrOutsideCompetitionWindow) {
+			http.Error(w, "Runs can only be started during the competition window", http.StatusForbidden)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(RunResponse{
+		Nonce:     runNonce.Nonce,
+		IssuedAt:  runNonce.IssuedAt,
+		ExpiresAt: runNonce.ExpiresAt,
+		Env:       RunNonceEnv + "=" + runNonce.Nonce,
+	}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateRun(t *testing.T) {
+	issuedAt := time.Now()
+	runNonce := &db.RunNonce{
+		Nonce:     "hplr_0123456789abcdef0123456789abcdef",
+		Username:  "agent-lead",
+		IssuedAt:  issuedAt,
+		ExpiresAt: issuedAt.Add(service.DefaultRunNonceTTL),
+	}
+
+	testCases := []struct {
+		name           string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "nonce issued",
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("IssueRunNonce", mock.Anything, "agent-lead").Return(runNonce, nil)
+			},
+		},
+		{
+			name:           "outside competition window",
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("IssueRunNonce", mock.Anything, "agent-lead").Return(nil, service.ErrOutsideCompetitionWindow)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := withAuthPayload(httptest.NewRequest(http.MethodPost, "/api/v1/runs", nil), "agent-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.CreateRun).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				var resp RunResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, runNonce.Nonce, resp.Nonce)
+				assert.Equal(t, RunNonceEnv+"="+runNonce.Nonce, resp.Env)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
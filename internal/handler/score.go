@@ -2,9 +2,12 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
 	"strconv"
 
+	"github.com/google/uuid"
 	"github.com/kdotwei/hpl-scoreboard/internal/middleware"
 	"github.com/kdotwei/hpl-scoreboard/internal/service"
 	"github.com/kdotwei/hpl-scoreboard/internal/token"
@@ -20,6 +23,62 @@ type CreateScoreRequest struct {
 	P             int     `json:"p"`
 	Q             int     `json:"q"`
 	ExecutionTime float64 `json:"execution_time"`
+	// Ranks 必須等於 p × q 與 nodes × ranks_per_node
+	Ranks        int `json:"ranks"`
+	Nodes        int `json:"nodes"`
+	RanksPerNode int `json:"ranks_per_node"`
+	// SystemID 是執行的系統，由 POST /api/v1/systems 登記
+	SystemID string `json:"system_id"`
+	// TeamID 為選填，填入時代表以隊伍名義送出，送出者必須是隊伍成員
+	TeamID string `json:"team_id"`
+	// RunNonce 是執行前由 POST /api/v1/runs 取得的 nonce
+	RunNonce string `json:"run_nonce"`
+	// Variant 與 residual 皆為選填；residual_threshold 未填時為 16
+	Variant           string   `json:"variant"`
+	Residual          *float64 `json:"residual"`
+	ResidualThreshold float64  `json:"residual_threshold"`
+	// AvgPowerWatts 與 EnergyJoules 皆為選填，只填 energy_joules 時平均功耗由 execution_time 推算
+	AvgPowerWatts *float64 `json:"avg_power_watts"`
+	EnergyJoules  *float64 `json:"energy_joules"`
+	// Benchmark 未填時為 hpl；其餘欄位只用於對應的 benchmark
+	Benchmark      string  `json:"benchmark"`
+	HPCGNx         int     `json:"hpcg_nx"`
+	HPCGNy         int     `json:"hpcg_ny"`
+	HPCGNz         int     `json:"hpcg_nz"`
+	StreamCopyMBs  float64 `json:"stream_copy_mbs"`
+	StreamScaleMBs float64 `json:"stream_scale_mbs"`
+	StreamAddMBs   float64 `json:"stream_add_mbs"`
+	StreamTriadMBs float64 `json:"stream_triad_mbs"`
+	MxPPrecision   string  `json:"mxp_precision"`
+}
+
+// writeScoreError 將送出成績的 service 錯誤轉成 HTTP 狀態碼
+func writeScoreError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrLinuxUsernameNotVerified):
+		http.Error(w, "linux_username is not verified for this account", http.StatusForbidden)
+	case errors.Is(err, service.ErrSystemRequired),
+		errors.Is(err, service.ErrSystemNotFound):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrRunNonceRequired),
+		errors.Is(err, service.ErrInvalidRunNonce),
+		errors.Is(err, service.ErrExecutionTimeOutsideRun),
+		errors.Is(err, service.ErrInvalidVariant),
+		errors.Is(err, service.ErrInvalidResidual),
+		errors.Is(err, service.ErrInvalidEnergy),
+		errors.Is(err, service.ErrInvalidBenchmark),
+		errors.Is(err, service.ErrInvalidMetrics):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrNotTeamMember):
+		http.Error(w, "You are not a member of this team", http.StatusForbidden)
+	case errors.Is(err, service.ErrGflopsMismatch),
+		errors.Is(err, service.ErrInvalidRankLayout),
+		errors.Is(err, service.ErrExceedsRpeak),
+		errors.Is(err, service.ErrResidualVerdictMismatch):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
 }
 
 func (h *Handler) CreateScore(w http.ResponseWriter, r *http.Request) {
@@ -41,21 +100,60 @@ func (h *Handler) CreateScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	score, err := h.service.CreateScore(r.Context(), service.CreateScoreParams{
-		UserID:        authPayload.Username,
-		Gflops:        req.Gflops,
-		ProblemSizeN:  req.ProblemSizeN,
-		BlockSizeNb:   req.BlockSizeNb,
-		LinuxUsername: req.LinuxUsername,
-		N:             req.N,
-		NB:            req.NB,
-		P:             req.P,
-		Q:             req.Q,
-		ExecutionTime: req.ExecutionTime,
-	})
+	params := service.CreateScoreParams{
+		UserID:            authPayload.Username,
+		Gflops:            req.Gflops,
+		ProblemSizeN:      req.ProblemSizeN,
+		BlockSizeNb:       req.BlockSizeNb,
+		LinuxUsername:     req.LinuxUsername,
+		N:                 req.N,
+		NB:                req.NB,
+		P:                 req.P,
+		Q:                 req.Q,
+		ExecutionTime:     req.ExecutionTime,
+		Ranks:             req.Ranks,
+		Nodes:             req.Nodes,
+		RanksPerNode:      req.RanksPerNode,
+		RunNonce:          req.RunNonce,
+		Variant:           req.Variant,
+		Residual:          req.Residual,
+		ResidualThreshold: req.ResidualThreshold,
+		AvgPowerWatts:     req.AvgPowerWatts,
+		EnergyJoules:      req.EnergyJoules,
+		Benchmark:         req.Benchmark,
+		HPCGNx:            req.HPCGNx,
+		HPCGNy:            req.HPCGNy,
+		HPCGNz:            req.HPCGNz,
+		StreamCopyMBs:     req.StreamCopyMBs,
+		StreamScaleMBs:    req.StreamScaleMBs,
+		StreamAddMBs:      req.StreamAddMBs,
+		StreamTriadMBs:    req.StreamTriadMBs,
+		MxPPrecision:      req.MxPPrecision,
+	}
+	if identity, ok := clientIdentity(r); ok {
+		params.ClientIdentity = identity.String()
+	}
+	if req.SystemID != "" {
+		systemID, err := uuid.Parse(req.SystemID)
+		if err != nil {
+			http.Error(w, "Invalid system_id", http.StatusBadRequest)
+			return
+		}
+		params.SystemID = systemID
+	}
+	if req.TeamID != "" {
+		teamID, err := uuid.Parse(req.TeamID)
+		if err != nil {
+			http.Error(w, "Invalid team_id", http.StatusBadRequest)
+			return
+		}
+		params.TeamID = teamID
+	}
+
+	score, err := h.service.CreateScore(r.Context(), params)
 
 	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		writeScoreError(w, err)
 		return
 	}
 
@@ -132,6 +230,27 @@ func (h *Handler) ListScoresWithPagination(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	residual, err := parseResidualFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params.Residual = residual
+
+	sort := r.URL.Query().Get("sort")
+	if !service.ValidScoreSort(sort) {
+		http.Error(w, "Invalid sort parameter (must be gflops_per_node or gflops_per_rank)", http.StatusBadRequest)
+		return
+	}
+	params.Sort = sort
+
+	benchmark := r.URL.Query().Get("benchmark")
+	if benchmark != "" && !service.ValidBenchmark(benchmark) {
+		http.Error(w, "Invalid benchmark parameter (must be hpl, hpl-mxp, hpcg or stream)", http.StatusBadRequest)
+		return
+	}
+	params.Benchmark = benchmark
+
 	// Get paginated scores from service
 	response, err := h.service.ListScoresWithPagination(r.Context(), params)
 	if err != nil {
@@ -147,3 +266,91 @@ func (h *Handler) ListScoresWithPagination(w http.ResponseWriter, r *http.Reques
 		return
 	}
 }
+
+// ListGreenLeaderboard 依 GFLOPS/W 排名有回報功耗的成績 (公開)。
+// min_gflops 可以提高最低效能門檻，但不能低於伺服器設定的值。
+func (h *Handler) ListGreenLeaderboard(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePagination(r, 10, 100)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	residual, err := parseResidualFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	params := service.ListScoresParams{
+		Limit:    limit,
+		Offset:   offset,
+		Residual: residual,
+	}
+	if minGflopsStr := r.URL.Query().Get("min_gflops"); minGflopsStr != "" {
+		minGflops, err := strconv.ParseFloat(minGflopsStr, 64)
+		if err != nil || minGflops < 0 || math.IsInf(minGflops, 0) || math.IsNaN(minGflops) {
+			http.Error(w, "Invalid min_gflops parameter (must be >= 0)", http.StatusBadRequest)
+			return
+		}
+		params.MinGflops = minGflops
+	}
+
+	response, err := h.service.ListGreenLeaderboard(r.Context(), params)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListBenchmarkLeaderboard 列出單一 benchmark 的排行榜 (公開)，
+// STREAM 依 triad 頻寬排名，其他 benchmark 依 gflops 排名
+func (h *Handler) ListBenchmarkLeaderboard(w http.ResponseWriter, r *http.Request) {
+	benchmark := r.PathValue("benchmark")
+	if !service.ValidBenchmark(benchmark) {
+		http.Error(w, "Unknown benchmark", http.StatusNotFound)
+		return
+	}
+
+	limit, offset, err := parsePagination(r, 10, 100)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	residual, err := parseResidualFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sort := r.URL.Query().Get("sort")
+	if !service.ValidScoreSort(sort) {
+		http.Error(w, "Invalid sort parameter (must be gflops_per_node or gflops_per_rank)", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.ListScoresWithPagination(r.Context(), service.ListScoresParams{
+		Limit:     limit,
+		Offset:    offset,
+		Residual:  residual,
+		Sort:      sort,
+		Benchmark: benchmark,
+	})
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context" // 👈 1. 新增
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time" // 👈 2. 新增 (為了初始化 token payload)
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kdotwei/hpl-scoreboard/internal/db"
 	"github.com/kdotwei/hpl-scoreboard/internal/middleware" // 👈 3. 新增
@@ -41,6 +43,10 @@ func TestCreateScore(t *testing.T) {
 				P:             4,
 				Q:             4,
 				ExecutionTime: 125.75,
+				Ranks:         16,
+				Nodes:         2,
+				RanksPerNode:  8,
+				RunNonce:      "hplr_0123456789abcdef0123456789abcdef",
 			},
 			mockUser:          "jwt-user",
 			expectedStatus:    http.StatusCreated,
@@ -56,7 +62,11 @@ func TestCreateScore(t *testing.T) {
 						arg.NB == req.NB &&
 						arg.P == req.P &&
 						arg.Q == req.Q &&
-						arg.ExecutionTime == req.ExecutionTime
+						arg.ExecutionTime == req.ExecutionTime &&
+						arg.Ranks == req.Ranks &&
+						arg.Nodes == req.Nodes &&
+						arg.RanksPerNode == req.RanksPerNode &&
+						arg.RunNonce == req.RunNonce
 				})).Return(&db.Score{
 					ID:            pgtype.UUID{Bytes: [16]byte{1, 2, 3}, Valid: true},
 					UserID:        user,
@@ -213,6 +223,180 @@ func TestCreateScore_ErrorCases(t *testing.T) {
 				mockService.On("CreateScore", mock.Anything, mock.Anything).Return(nil, assert.AnError)
 			},
 		},
+		{
+			name:           "unverified linux username rejected",
+			requestBody:    `{"gflops": 123.45, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 50.0}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.Anything).Return(nil, service.ErrLinuxUsernameNotVerified)
+			},
+		},
+		{
+			name:           "used run nonce",
+			requestBody:    `{"gflops": 123.45, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 50.0, "run_nonce": "hplr_used"}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.Anything).Return(nil, service.ErrInvalidRunNonce)
+			},
+		},
+		{
+			name:           "execution time longer than the run",
+			requestBody:    `{"gflops": 123.45, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 99999.0, "run_nonce": "hplr_fresh"}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.Anything).Return(nil, service.ErrExecutionTimeOutsideRun)
+			},
+		},
+		{
+			name:           "gflops inconsistent with n and execution time",
+			requestBody:    `{"gflops": 99999.0, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 50.0, "run_nonce": "hplr_fresh"}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: reported 99999, expected 0.0134", service.ErrGflopsMismatch))
+			},
+		},
+		{
+			name:           "unknown system",
+			requestBody:    `{"gflops": 123.45, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 50.0, "system_id": "6f1c2a4e-8b7d-4c3a-9e21-5d0f3b6a7c88", "run_nonce": "hplr_fresh"}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.MatchedBy(func(arg service.CreateScoreParams) bool {
+					return arg.SystemID.String() == "6f1c2a4e-8b7d-4c3a-9e21-5d0f3b6a7c88"
+				})).Return(nil, service.ErrSystemNotFound)
+			},
+		},
+		{
+			name:           "process grid does not match ranks",
+			requestBody:    `{"gflops": 123.45, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 2, "q": 2, "execution_time": 50.0, "ranks": 8, "nodes": 2, "ranks_per_node": 4, "run_nonce": "hplr_fresh"}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.MatchedBy(func(arg service.CreateScoreParams) bool {
+					return arg.Ranks == 8 && arg.Nodes == 2 && arg.RanksPerNode == 4
+				})).Return(nil, fmt.Errorf("%w: p × q = 2 × 2 = 4, but ranks = 8", service.ErrInvalidRankLayout))
+			},
+		},
+		{
+			name:           "above rpeak",
+			requestBody:    `{"gflops": 123.45, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 50.0, "system_id": "6f1c2a4e-8b7d-4c3a-9e21-5d0f3b6a7c88", "run_nonce": "hplr_fresh"}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("%w: 123.45 GFLOPS is 123.5%% of the 100 GFLOPS Rpeak of tiny on the nodes used", service.ErrExceedsRpeak))
+			},
+		},
+		{
+			name:           "stream bandwidths",
+			requestBody:    `{"benchmark": "stream", "linux_username": "test", "execution_time": 12.5, "ranks": 1, "nodes": 1, "ranks_per_node": 1, "system_id": "6f1c2a4e-8b7d-4c3a-9e21-5d0f3b6a7c88", "run_nonce": "hplr_fresh", "stream_copy_mbs": 180512.3, "stream_scale_mbs": 179880.1, "stream_add_mbs": 196402.7, "stream_triad_mbs": 197033.9}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.MatchedBy(func(arg service.CreateScoreParams) bool {
+					return arg.Benchmark == service.BenchmarkStream && arg.Gflops == 0 && arg.StreamTriadMBs == 197033.9
+				})).Return(&db.Score{UserID: "test-user", Benchmark: service.BenchmarkStream, Metric: pgtype.Float8{Float64: 197033.9, Valid: true}}, nil)
+			},
+		},
+		{
+			name:           "hpcg grid not a multiple of 8",
+			requestBody:    `{"benchmark": "hpcg", "gflops": 312.4, "linux_username": "test", "execution_time": 1860, "ranks": 4, "nodes": 1, "ranks_per_node": 4, "run_nonce": "hplr_fresh", "hpcg_nx": 100, "hpcg_ny": 104, "hpcg_nz": 104}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.MatchedBy(func(arg service.CreateScoreParams) bool {
+					return arg.Benchmark == service.BenchmarkHPCG && arg.HPCGNx == 100
+				})).Return(nil, fmt.Errorf("%w: hpcg_nx, hpcg_ny and hpcg_nz must be multiples of 8 between 8 and 4096", service.ErrInvalidMetrics))
+			},
+		},
+		{
+			name:           "unknown benchmark",
+			requestBody:    `{"benchmark": "linpack", "gflops": 123.45, "linux_username": "test", "execution_time": 50.0, "run_nonce": "hplr_fresh"}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.Anything).Return(nil, service.ErrInvalidBenchmark)
+			},
+		},
+		{
+			name:           "with power and energy",
+			requestBody:    `{"gflops": 123.45, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 50.0, "run_nonce": "hplr_fresh", "avg_power_watts": 410.5, "energy_joules": 20525}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.MatchedBy(func(arg service.CreateScoreParams) bool {
+					return arg.AvgPowerWatts != nil && *arg.AvgPowerWatts == 410.5 && arg.EnergyJoules != nil && *arg.EnergyJoules == 20525
+				})).Return(&db.Score{UserID: "test-user", AvgPowerWatts: pgtype.Float8{Float64: 410.5, Valid: true}}, nil)
+			},
+		},
+		{
+			name:           "energy does not match power",
+			requestBody:    `{"gflops": 123.45, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 50.0, "run_nonce": "hplr_fresh", "avg_power_watts": 410.5, "energy_joules": 100}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("%w: energy_joules = 100, but avg_power_watts × execution_time = 20525 (tolerance 5%%)", service.ErrInvalidEnergy))
+			},
+		},
+		{
+			name:           "failed residual check is recorded",
+			requestBody:    `{"gflops": 123.45, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 50.0, "run_nonce": "hplr_fresh", "variant": "WR11C2R4", "residual": 21.15}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.MatchedBy(func(arg service.CreateScoreParams) bool {
+					return arg.Variant == "WR11C2R4" && arg.Residual != nil && *arg.Residual == 21.15 && arg.ResidualThreshold == 0
+				})).Return(&db.Score{UserID: "test-user", Variant: "WR11C2R4", ResidualPassed: pgtype.Bool{Bool: false, Valid: true}}, nil)
+			},
+		},
+		{
+			name:           "invalid variant",
+			requestBody:    `{"gflops": 123.45, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 50.0, "run_nonce": "hplr_fresh", "variant": "fast"}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.Anything).Return(nil, service.ErrInvalidVariant)
+			},
+		},
+		{
+			name:           "invalid team id",
+			requestBody:    `{"gflops": 123.45, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 50.0, "team_id": "not-a-uuid"}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "not a member of the team",
+			requestBody:    `{"gflops": 123.45, "problem_size_n": 1000, "block_size_nb": 256, "linux_username": "test", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 50.0, "team_id": "6f1c2a4e-8b7d-4c3a-9e21-5d0f3b6a7c88"}`,
+			mockUser:       "test-user",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateScore", mock.Anything, mock.MatchedBy(func(arg service.CreateScoreParams) bool {
+					return arg.TeamID == uuid.MustParse("6f1c2a4e-8b7d-4c3a-9e21-5d0f3b6a7c88")
+				})).Return(nil, service.ErrNotTeamMember)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -532,6 +716,74 @@ func TestListScoresWithPagination(t *testing.T) {
 				// No mock call expected for bad request
 			},
 		},
+		{
+			name:           "failed residual check filter",
+			queryParams:    "?residual=failed",
+			expectedStatus: http.StatusOK,
+			expectedLimit:  10,
+			expectedOffset: 0,
+			setupMock: func(mockService *mocks.Service) {
+				mockResponse := &service.PaginatedScoresResponse{
+					Scores: []db.Score{
+						{ID: pgtype.UUID{Valid: true}, Gflops: 400.0, UserID: "user4", ResidualPassed: pgtype.Bool{Valid: true}},
+					},
+					TotalRecords: 1,
+					Limit:        10,
+				}
+				mockService.On("ListScoresWithPagination", mock.Anything, mock.MatchedBy(func(params service.ListScoresParams) bool {
+					return params.Limit == 10 && params.Residual == service.ResidualFilterFailed
+				})).Return(mockResponse, nil)
+			},
+		},
+		{
+			name:           "sort by gflops per node",
+			queryParams:    "?sort=gflops_per_node",
+			expectedStatus: http.StatusOK,
+			expectedLimit:  10,
+			expectedOffset: 0,
+			setupMock: func(mockService *mocks.Service) {
+				mockResponse := &service.PaginatedScoresResponse{
+					Scores: []db.Score{
+						{ID: pgtype.UUID{Valid: true}, Gflops: 400.0, UserID: "user5", Nodes: 2, GflopsPerNode: pgtype.Float8{Float64: 200.0, Valid: true}},
+					},
+					TotalRecords: 1,
+					Limit:        10,
+				}
+				mockService.On("ListScoresWithPagination", mock.Anything, mock.MatchedBy(func(params service.ListScoresParams) bool {
+					return params.Sort == service.ScoreSortGflopsPerNode && params.Residual == ""
+				})).Return(mockResponse, nil)
+			},
+		},
+		{
+			name:           "invalid benchmark returns bad request",
+			queryParams:    "?benchmark=linpack",
+			expectedStatus: http.StatusBadRequest,
+			expectedLimit:  0,
+			expectedOffset: 0,
+			setupMock: func(mockService *mocks.Service) {
+				// No mock call expected for bad request
+			},
+		},
+		{
+			name:           "invalid sort returns bad request",
+			queryParams:    "?sort=user_id",
+			expectedStatus: http.StatusBadRequest,
+			expectedLimit:  0,
+			expectedOffset: 0,
+			setupMock: func(mockService *mocks.Service) {
+				// No mock call expected for bad request
+			},
+		},
+		{
+			name:           "invalid residual filter returns bad request",
+			queryParams:    "?residual=maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectedLimit:  0,
+			expectedOffset: 0,
+			setupMock: func(mockService *mocks.Service) {
+				// No mock call expected for bad request
+			},
+		},
 		{
 			name:           "service error",
 			queryParams:    "?limit=5",
@@ -577,3 +829,83 @@ func TestListScoresWithPagination(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateScore_ClientCertIdentity(t *testing.T) {
+	mockService := new(mocks.Service)
+	h := NewHandler(mockService, new(token_mocks.Maker))
+
+	mockService.On("CreateScore", mock.Anything, mock.MatchedBy(func(arg service.CreateScoreParams) bool {
+		return arg.UserID == "team-a" && arg.ClientIdentity == "system:cluster-a-node01"
+	})).Return(&db.Score{UserID: "team-a", ClientIdentity: "system:cluster-a-node01"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scores", bytes.NewBufferString(`{"gflops": 123.45, "linux_username": "hpl_user1", "n": 1000, "nb": 256, "p": 1, "q": 1, "execution_time": 50.0}`))
+	// 模擬 RequireClientCert 放入的身分與 Payload
+	ctx := context.WithValue(req.Context(), middleware.ClientIdentityKey, &middleware.ClientIdentity{
+		Kind:     middleware.ClientIdentitySystem,
+		Name:     "cluster-a-node01",
+		Username: "team-a",
+	})
+	ctx = context.WithValue(ctx, middleware.AuthorizationPayloadKey, &token.Payload{Username: "team-a", TokenType: token.TokenTypeClientCert})
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(h.CreateScore).ServeHTTP(rr, req.WithContext(ctx))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var response db.Score
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "system:cluster-a-node01", response.ClientIdentity)
+	mockService.AssertExpectations(t)
+}
+
+func TestListGreenLeaderboard(t *testing.T) {
+	mockService := new(mocks.Service)
+	h := NewHandler(mockService, new(token_mocks.Maker))
+
+	mockService.On("ListGreenLeaderboard", mock.Anything, service.ListScoresParams{Limit: 10, MinGflops: 1000}).Return(&service.PaginatedScoresResponse{
+		Scores:       []db.Score{{Gflops: 2000, GflopsPerWatt: pgtype.Float8{Float64: 4.87, Valid: true}}},
+		TotalRecords: 1,
+		Limit:        10,
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(h.ListGreenLeaderboard).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/green?min_gflops=1000", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp service.PaginatedScoresResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Len(t, resp.Scores, 1)
+	mockService.AssertExpectations(t)
+
+	for _, query := range []string{"?min_gflops=-1", "?min_gflops=NaN", "?min_gflops=lots"} {
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(h.ListGreenLeaderboard).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/green"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+}
+
+func TestListBenchmarkLeaderboard(t *testing.T) {
+	mockService := new(mocks.Service)
+	h := NewHandler(mockService, new(token_mocks.Maker))
+
+	mockService.On("ListScoresWithPagination", mock.Anything, service.ListScoresParams{Limit: 10, Benchmark: service.BenchmarkHPCG}).Return(&service.PaginatedScoresResponse{
+		Scores:       []db.Score{{Benchmark: service.BenchmarkHPCG, Gflops: 312.4, Metric: pgtype.Float8{Float64: 312.4, Valid: true}}},
+		TotalRecords: 1,
+		Limit:        10,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/hpcg", nil)
+	req.SetPathValue("benchmark", "hpcg")
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(h.ListBenchmarkLeaderboard).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp service.PaginatedScoresResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Len(t, resp.Scores, 1)
+	mockService.AssertExpectations(t)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/linpack", nil)
+	req.SetPathValue("benchmark", "linpack")
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(h.ListBenchmarkLeaderboard).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
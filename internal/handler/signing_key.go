@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+)
+
+// CreateSigningKeyRequest 定義建立簽章金鑰的請求格式
+type CreateSigningKeyRequest struct {
+	Name string `json:"name"`
+}
+
+// SigningKeyResponse 描述一把簽章金鑰，不含 secret；id 即簽章時帶入的 X-Signature-Key-Id
+type SigningKeyResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// CreateSigningKeyResponse 額外帶回 secret，之後無法再取得
+type CreateSigningKeyResponse struct {
+	SigningKeyResponse
+	Secret string `json:"secret"`
+}
+
+func newSigningKeyResponse(signingKey db.SigningKey) SigningKeyResponse {
+	return SigningKeyResponse{
+		ID:        signingKey.ID.Bytes,
+		Name:      signingKey.Name,
+		RevokedAt: optionalTime(signingKey.RevokedAt),
+		CreatedAt: signingKey.CreatedAt,
+	}
+}
+
+func (h *Handler) CreateSigningKey(w http.ResponseWriter, r *http.Request) {
+	var req CreateSigningKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == "" {
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	secret, signingKey, err := h.service.CreateSigningKey(r.Context(), payload.Username, req.Name)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(CreateSigningKeyResponse{
+		SigningKeyResponse: newSigningKeyResponse(*signingKey),
+		Secret:             secret,
+	}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) ListSigningKeys(w http.ResponseWriter, r *http.Request) {
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	signingKeys, err := h.service.ListSigningKeys(r.Context(), payload.Username)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]SigningKeyResponse, 0, len(signingKeys))
+	for _, signingKey := range signingKeys {
+		resp = append(resp, newSigningKeyResponse(signingKey))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RevokeSigningKey 撤銷自己的一把簽章金鑰
+func (h *Handler) RevokeSigningKey(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid signing key id", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RevokeSigningKey(r.Context(), id, payload.Username); err != nil {
+		if errors.Is(err, service.ErrSigningKeyNotFound) {
+			http.Error(w, "Signing key not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateSigningKey(t *testing.T) {
+	signingKey := &db.SigningKey{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Username:  "agent-lead",
+		Name:      "cluster-a",
+		Secret:    "hpls_secret",
+		CreatedAt: time.Now(),
+	}
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful creation",
+			requestBody:    `{"name": "cluster-a"}`,
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateSigningKey", mock.Anything, "agent-lead", "cluster-a").Return("hpls_secret", signingKey, nil)
+			},
+		},
+		{
+			name:           "missing name",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := withAuthPayload(httptest.NewRequest(http.MethodPost, "/api/v1/signing-keys", bytes.NewBufferString(tc.requestBody)), "agent-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.CreateSigningKey).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				var resp CreateSigningKeyResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, "hpls_secret", resp.Secret)
+				assert.Equal(t, uuid.UUID(signingKey.ID.Bytes), resp.ID)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListSigningKeys(t *testing.T) {
+	mockService := new(mocks.Service)
+	h := NewHandler(mockService, new(token_mocks.Maker))
+
+	mockService.On("ListSigningKeys", mock.Anything, "agent-lead").Return([]db.SigningKey{
+		{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Name: "cluster-a", Secret: "hpls_secret"},
+	}, nil)
+
+	req := withAuthPayload(httptest.NewRequest(http.MethodGet, "/api/v1/signing-keys", nil), "agent-lead")
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(h.ListSigningKeys).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	// secret 只在建立時回傳一次
+	assert.False(t, strings.Contains(rr.Body.String(), "hpls_secret"))
+	mockService.AssertExpectations(t)
+}
+
+func TestRevokeSigningKey(t *testing.T) {
+	id := uuid.New()
+
+	testCases := []struct {
+		name           string
+		pathID         string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful revocation",
+			pathID:         id.String(),
+			expectedStatus: http.StatusNoContent,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("RevokeSigningKey", mock.Anything, id, "agent-lead").Return(nil)
+			},
+		},
+		{
+			name:           "invalid id",
+			pathID:         "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "not found",
+			pathID:         id.String(),
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("RevokeSigningKey", mock.Anything, id, "agent-lead").Return(service.ErrSigningKeyNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/signing-keys/"+tc.pathID, nil)
+			req.SetPathValue("id", tc.pathID)
+			req = withAuthPayload(req, "agent-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.RevokeSigningKey).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
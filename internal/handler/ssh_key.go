@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+)
+
+// CreateSSHKeyRequest 登記一把 SSH 公鑰，並宣告它對應的叢集帳號
+type CreateSSHKeyRequest struct {
+	LinuxUsername string `json:"linux_username"`
+	PublicKey     string `json:"public_key"`
+}
+
+// VerifySSHKeyRequest 帶入 ssh-keygen -Y sign 產生的 armored 簽章
+type VerifySSHKeyRequest struct {
+	Signature string `json:"signature"`
+}
+
+// SSHKeyResponse 描述一把 SSH 公鑰與其驗證狀態
+type SSHKeyResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	LinuxUsername string     `json:"linux_username"`
+	PublicKey     string     `json:"public_key"`
+	Fingerprint   string     `json:"fingerprint"`
+	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// SSHKeyChallengeResponse 回傳待簽署的 challenge，Command 是在叢集上可直接執行的範例
+type SSHKeyChallengeResponse struct {
+	Challenge string    `json:"challenge"`
+	Namespace string    `json:"namespace"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Command   string    `json:"command"`
+}
+
+func newSSHKeyResponse(sshKey db.SshKey) SSHKeyResponse {
+	return SSHKeyResponse{
+		ID:            sshKey.ID.Bytes,
+		LinuxUsername: sshKey.LinuxUsername,
+		PublicKey:     sshKey.PublicKey,
+		Fingerprint:   sshKey.Fingerprint,
+		VerifiedAt:    optionalTime(sshKey.VerifiedAt),
+		CreatedAt:     sshKey.CreatedAt,
+	}
+}
+
+// writeSSHKeyError 將 SSH 金鑰相關的 service 錯誤轉成 HTTP 狀態碼
+func writeSSHKeyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidSSHKey),
+		errors.Is(err, service.ErrInvalidLinuxUsername):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidSSHSignature):
+		http.Error(w, "Invalid SSH signature", http.StatusBadRequest)
+	case errors.Is(err, service.ErrSSHKeyNotFound):
+		http.Error(w, "SSH key not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrSSHKeyExists):
+		http.Error(w, "SSH key already registered", http.StatusConflict)
+	case errors.Is(err, service.ErrSSHKeyChallengeInvalid):
+		http.Error(w, "No active challenge, request a new one", http.StatusConflict)
+	case errors.Is(err, service.ErrLinuxAccountTaken):
+		http.Error(w, "Linux username is bound to another user", http.StatusConflict)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) CreateSSHKey(w http.ResponseWriter, r *http.Request) {
+	var req CreateSSHKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.LinuxUsername == "" || req.PublicKey == "" {
+		http.Error(w, "linux_username and public_key are required", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	sshKey, err := h.service.CreateSSHKey(r.Context(), service.CreateSSHKeyParams{
+		Username:      payload.Username,
+		LinuxUsername: req.LinuxUsername,
+		PublicKey:     req.PublicKey,
+	})
+	if err != nil {
+		writeSSHKeyError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newSSHKeyResponse(*sshKey)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) ListSSHKeys(w http.ResponseWriter, r *http.Request) {
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	sshKeys, err := h.service.ListSSHKeys(r.Context(), payload.Username)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]SSHKeyResponse, 0, len(sshKeys))
+	for _, sshKey := range sshKeys {
+		resp = append(resp, newSSHKeyResponse(sshKey))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// DeleteSSHKey 刪除自己的一把 SSH 公鑰，已完成的叢集帳號綁定不受影響
+func (h *Handler) DeleteSSHKey(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid SSH key id", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.DeleteSSHKey(r.Context(), id, payload.Username); err != nil {
+		writeSSHKeyError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateSSHKeyChallenge 產生新的 challenge，使用者在叢集上以私鑰簽署後送回 VerifySSHKey
+func (h *Handler) CreateSSHKeyChallenge(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid SSH key id", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	challenge, err := h.service.CreateSSHKeyChallenge(r.Context(), id, payload.Username)
+	if err != nil {
+		writeSSHKeyError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(SSHKeyChallengeResponse{
+		Challenge: challenge.Challenge,
+		Namespace: challenge.Namespace,
+		ExpiresAt: challenge.ExpiresAt,
+		Command:   fmt.Sprintf("printf '%%s' '%s' | ssh-keygen -Y sign -f ~/.ssh/id_ed25519 -n %s", challenge.Challenge, challenge.Namespace),
+	}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// VerifySSHKey 驗證 challenge 的簽章，成功後叢集帳號綁定到目前的使用者
+func (h *Handler) VerifySSHKey(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid SSH key id", http.StatusBadRequest)
+		return
+	}
+
+	var req VerifySSHKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Signature == "" {
+		http.Error(w, "Signature is required", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	sshKey, err := h.service.VerifySSHKey(r.Context(), id, payload.Username, req.Signature)
+	if err != nil {
+		writeSSHKeyError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newSSHKeyResponse(*sshKey)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
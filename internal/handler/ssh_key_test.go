@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"
+
+func TestCreateSSHKey(t *testing.T) {
+	sshKey := &db.SshKey{
+		ID:            pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Username:      "agent-lead",
+		LinuxUsername: "hpl_user1",
+		PublicKey:     testPublicKey,
+		Fingerprint:   "SHA256:abc",
+		CreatedAt:     time.Now(),
+	}
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful creation",
+			requestBody:    `{"linux_username": "hpl_user1", "public_key": "` + testPublicKey + ` me@laptop"}`,
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateSSHKey", mock.Anything, service.CreateSSHKeyParams{
+					Username:      "agent-lead",
+					LinuxUsername: "hpl_user1",
+					PublicKey:     testPublicKey + " me@laptop",
+				}).Return(sshKey, nil)
+			},
+		},
+		{
+			name:           "missing public key",
+			requestBody:    `{"linux_username": "hpl_user1"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "invalid public key",
+			requestBody:    `{"linux_username": "hpl_user1", "public_key": "ssh-dss AAAA"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateSSHKey", mock.Anything, mock.Anything).Return(nil, service.ErrInvalidSSHKey)
+			},
+		},
+		{
+			name:           "key already registered",
+			requestBody:    `{"linux_username": "hpl_user1", "public_key": "` + testPublicKey + `"}`,
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateSSHKey", mock.Anything, mock.Anything).Return(nil, service.ErrSSHKeyExists)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := withAuthPayload(httptest.NewRequest(http.MethodPost, "/api/v1/ssh-keys", bytes.NewBufferString(tc.requestBody)), "agent-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.CreateSSHKey).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				var resp SSHKeyResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, "hpl_user1", resp.LinuxUsername)
+				assert.Nil(t, resp.VerifiedAt)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateSSHKeyChallenge(t *testing.T) {
+	id := uuid.New()
+	mockService := new(mocks.Service)
+	h := NewHandler(mockService, new(token_mocks.Maker))
+
+	mockService.On("CreateSSHKeyChallenge", mock.Anything, id, "agent-lead").Return(&service.SSHKeyChallenge{
+		Challenge: "hpl-scoreboard:agent-lead:hpl_user1:nonce",
+		Namespace: service.SSHSignatureNamespace,
+		ExpiresAt: time.Now().Add(10 * time.Minute),
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/ssh-keys/"+id.String()+"/challenge", nil)
+	req.SetPathValue("id", id.String())
+	req = withAuthPayload(req, "agent-lead")
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(h.CreateSSHKeyChallenge).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp SSHKeyChallengeResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, service.SSHSignatureNamespace, resp.Namespace)
+	assert.Contains(t, resp.Command, "ssh-keygen -Y sign")
+	assert.Contains(t, resp.Command, resp.Challenge)
+	mockService.AssertExpectations(t)
+}
+
+func TestVerifySSHKey(t *testing.T) {
+	id := uuid.New()
+
+	testCases := []struct {
+		name           string
+		pathID         string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful verification",
+			pathID:         id.String(),
+			requestBody:    `{"signature": "-----BEGIN SSH SIGNATURE-----"}`,
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("VerifySSHKey", mock.Anything, id, "agent-lead", "-----BEGIN SSH SIGNATURE-----").Return(&db.SshKey{
+					ID:            pgtype.UUID{Bytes: id, Valid: true},
+					LinuxUsername: "hpl_user1",
+					VerifiedAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+				}, nil)
+			},
+		},
+		{
+			name:           "invalid id",
+			pathID:         "not-a-uuid",
+			requestBody:    `{"signature": "sig"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "missing signature",
+			pathID:         id.String(),
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "bad signature",
+			pathID:         id.String(),
+			requestBody:    `{"signature": "sig"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("VerifySSHKey", mock.Anything, id, "agent-lead", "sig").Return(nil, service.ErrInvalidSSHSignature)
+			},
+		},
+		{
+			name:           "no active challenge",
+			pathID:         id.String(),
+			requestBody:    `{"signature": "sig"}`,
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("VerifySSHKey", mock.Anything, id, "agent-lead", "sig").Return(nil, service.ErrSSHKeyChallengeInvalid)
+			},
+		},
+		{
+			name:           "linux username taken",
+			pathID:         id.String(),
+			requestBody:    `{"signature": "sig"}`,
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("VerifySSHKey", mock.Anything, id, "agent-lead", "sig").Return(nil, service.ErrLinuxAccountTaken)
+			},
+		},
+		{
+			name:           "key not found",
+			pathID:         id.String(),
+			requestBody:    `{"signature": "sig"}`,
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("VerifySSHKey", mock.Anything, id, "agent-lead", "sig").Return(nil, service.ErrSSHKeyNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/ssh-keys/"+tc.pathID+"/verify", bytes.NewBufferString(tc.requestBody))
+			req.SetPathValue("id", tc.pathID)
+			req = withAuthPayload(req, "agent-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.VerifySSHKey).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var resp SSHKeyResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.NotNil(t, resp.VerifiedAt)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteSSHKey(t *testing.T) {
+	id := uuid.New()
+	mockService := new(mocks.Service)
+	h := NewHandler(mockService, new(token_mocks.Maker))
+
+	mockService.On("DeleteSSHKey", mock.Anything, id, "agent-lead").Return(service.ErrSSHKeyNotFound)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/ssh-keys/"+id.String(), nil)
+	req.SetPathValue("id", id.String())
+	req = withAuthPayload(req, "agent-lead")
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(h.DeleteSSHKey).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockService.AssertExpectations(t)
+}
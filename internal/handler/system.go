@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+)
+
+// SystemRequest 定義建立與更新系統的請求格式，更新時所有欄位一併取代
+type SystemRequest struct {
+	Name          string  `json:"name"`
+	Nodes         int     `json:"nodes"`
+	CPUModel      string  `json:"cpu_model"`
+	CoresPerNode  int     `json:"cores_per_node"`
+	ClockGHz      float64 `json:"clock_ghz"`
+	FlopsPerCycle int     `json:"flops_per_cycle"`
+	// Accelerators 為說明文字，例如 "4x NVIDIA A100"；峰值另外填在 accelerator_gflops_per_node
+	Accelerators             string  `json:"accelerators"`
+	AcceleratorGflopsPerNode float64 `json:"accelerator_gflops_per_node"`
+	Interconnect             string  `json:"interconnect"`
+	MPI                      string  `json:"mpi"`
+	BLAS                     string  `json:"blas"`
+}
+
+func (req SystemRequest) params() service.SystemParams {
+	return service.SystemParams{
+		Name:                     req.Name,
+		Nodes:                    req.Nodes,
+		CPUModel:                 req.CPUModel,
+		CoresPerNode:             req.CoresPerNode,
+		ClockGHz:                 req.ClockGHz,
+		FlopsPerCycle:            req.FlopsPerCycle,
+		Accelerators:             req.Accelerators,
+		AcceleratorGflopsPerNode: req.AcceleratorGflopsPerNode,
+		Interconnect:             req.Interconnect,
+		MPI:                      req.MPI,
+		BLAS:                     req.BLAS,
+	}
+}
+
+// writeSystemError 將系統相關的 service 錯誤轉成 HTTP 狀態碼
+func writeSystemError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidSystem):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrNotSystemOwner):
+		http.Error(w, "Only the user who registered the system can change it", http.StatusForbidden)
+	case errors.Is(err, service.ErrSystemNotFound):
+		http.Error(w, "System not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrSystemNameTaken):
+		http.Error(w, "System name already exists", http.StatusConflict)
+	case errors.Is(err, service.ErrSystemInUse):
+		http.Error(w, "System has scores and cannot be deleted", http.StatusConflict)
+	case errors.Is(err, service.ErrSystemNodesInUse):
+		http.Error(w, "System has scores on more nodes than requested", http.StatusConflict)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) CreateSystem(w http.ResponseWriter, r *http.Request) {
+	var req SystemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	system, err := h.service.CreateSystem(r.Context(), payload.Username, req.params())
+	if err != nil {
+		writeSystemError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(system); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListSystems 列出所有登記的系統 (公開)
+func (h *Handler) ListSystems(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePagination(r, 20, 100)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.ListSystems(r.Context(), limit, offset)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetSystem 回傳系統規格與 Rpeak (公開)
+func (h *Handler) GetSystem(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid system id", http.StatusBadRequest)
+		return
+	}
+
+	system, err := h.service.GetSystem(r.Context(), id)
+	if err != nil {
+		writeSystemError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(system); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// UpdateSystem 更新系統規格 (限登記者)，既有成績的 Rpeak 與 efficiency 隨之更新
+func (h *Handler) UpdateSystem(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid system id", http.StatusBadRequest)
+		return
+	}
+
+	var req SystemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	system, err := h.service.UpdateSystem(r.Context(), id, payload.Username, req.params())
+	if err != nil {
+		writeSystemError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(system); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// DeleteSystem 刪除系統 (限登記者)，已有成績的系統不能刪除
+func (h *Handler) DeleteSystem(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid system id", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.DeleteSystem(r.Context(), id, payload.Username); err != nil {
+		writeSystemError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testSystemBody = `{"name": "taiwania-mini", "nodes": 4, "cpu_model": "Xeon Gold 6148", "cores_per_node": 40, "clock_ghz": 2.4, "flops_per_cycle": 32, "interconnect": "EDR InfiniBand", "mpi": "Open MPI 4.1", "blas": "OpenBLAS 0.3"}`
+
+func TestCreateSystem(t *testing.T) {
+	system := &db.System{
+		ID:              pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Name:            "taiwania-mini",
+		Nodes:           4,
+		CpuModel:        "Xeon Gold 6148",
+		CoresPerNode:    40,
+		ClockGhz:        2.4,
+		FlopsPerCycle:   32,
+		NodeRpeakGflops: 3072,
+		RpeakGflops:     12288,
+		CreatedBy:       "agent-lead",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful creation",
+			requestBody:    testSystemBody,
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateSystem", mock.Anything, "agent-lead", mock.MatchedBy(func(arg service.SystemParams) bool {
+					return arg.Name == "taiwania-mini" && arg.Nodes == 4 && arg.CoresPerNode == 40 &&
+						arg.ClockGHz == 2.4 && arg.FlopsPerCycle == 32 && arg.BLAS == "OpenBLAS 0.3"
+				})).Return(system, nil)
+			},
+		},
+		{
+			name:           "invalid body",
+			requestBody:    `{"nodes": "four"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "invalid specs",
+			requestBody:    `{"name": "taiwania-mini", "nodes": 0}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateSystem", mock.Anything, "agent-lead", mock.Anything).
+					Return(nil, fmt.Errorf("%w: nodes must be between 1 and 16777216", service.ErrInvalidSystem))
+			},
+		},
+		{
+			name:           "name taken",
+			requestBody:    testSystemBody,
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateSystem", mock.Anything, "agent-lead", mock.Anything).Return(nil, service.ErrSystemNameTaken)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := withAuthPayload(httptest.NewRequest(http.MethodPost, "/api/v1/systems", bytes.NewBufferString(tc.requestBody)), "agent-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.CreateSystem).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				var resp db.System
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, 12288.0, resp.RpeakGflops)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateSystem(t *testing.T) {
+	systemID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		id             string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful update",
+			id:             systemID.String(),
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UpdateSystem", mock.Anything, systemID, "agent-lead", mock.AnythingOfType("service.SystemParams")).
+					Return(&db.System{ID: pgtype.UUID{Bytes: systemID, Valid: true}, Name: "taiwania-mini"}, nil)
+			},
+		},
+		{
+			name:           "invalid id",
+			id:             "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "registered by someone else",
+			id:             systemID.String(),
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UpdateSystem", mock.Anything, systemID, "agent-lead", mock.Anything).Return(nil, service.ErrNotSystemOwner)
+			},
+		},
+		{
+			name:           "not found",
+			id:             systemID.String(),
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UpdateSystem", mock.Anything, systemID, "agent-lead", mock.Anything).Return(nil, service.ErrSystemNotFound)
+			},
+		},
+		{
+			name:           "fewer nodes than a score",
+			id:             systemID.String(),
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UpdateSystem", mock.Anything, systemID, "agent-lead", mock.Anything).Return(nil, service.ErrSystemNodesInUse)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := withAuthPayload(httptest.NewRequest(http.MethodPut, "/api/v1/systems/"+tc.id, bytes.NewBufferString(testSystemBody)), "agent-lead")
+			req.SetPathValue("id", tc.id)
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.UpdateSystem).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteSystem(t *testing.T) {
+	systemID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		expectedStatus int
+		err            error
+	}{
+		{name: "deleted", expectedStatus: http.StatusNoContent},
+		{name: "has scores", expectedStatus: http.StatusConflict, err: service.ErrSystemInUse},
+		{name: "registered by someone else", expectedStatus: http.StatusForbidden, err: service.ErrNotSystemOwner},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			mockService.On("DeleteSystem", mock.Anything, systemID, "agent-lead").Return(tc.err)
+
+			req := withAuthPayload(httptest.NewRequest(http.MethodDelete, "/api/v1/systems/"+systemID.String(), nil), "agent-lead")
+			req.SetPathValue("id", systemID.String())
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.DeleteSystem).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListSystems(t *testing.T) {
+	mockService := new(mocks.Service)
+	h := NewHandler(mockService, new(token_mocks.Maker))
+
+	mockService.On("ListSystems", mock.Anything, int32(20), int32(0)).Return(&service.PaginatedSystemsResponse{
+		Systems:      []db.System{{Name: "taiwania-mini", RpeakGflops: 12288}},
+		TotalRecords: 1,
+		Limit:        20,
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(h.ListSystems).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/systems", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp service.PaginatedSystemsResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Len(t, resp.Systems, 1)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,504 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+)
+
+const (
+	defaultInvitationTTL     = 7 * 24 * time.Hour
+	maxInvitationTTL         = 30 * 24 * time.Hour
+	defaultInvitationMaxUses = 5
+	maxInvitationMaxUses     = 100
+)
+
+// CreateTeamRequest 定義建立隊伍的請求格式，建立者成為第一位 owner
+type CreateTeamRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateTeamInvitationRequest 的欄位皆為選填，未填時使用預設值
+type CreateTeamInvitationRequest struct {
+	ExpiresInHours int   `json:"expires_in_hours"`
+	MaxUses        int32 `json:"max_uses"`
+}
+
+// JoinTeamRequest 以邀請碼加入隊伍
+type JoinTeamRequest struct {
+	Code string `json:"code"`
+}
+
+// UpdateTeamMemberRoleRequest 設定成員角色 (owner 或 member)
+type UpdateTeamMemberRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// TeamResponse 描述一支隊伍；Role 是目前使用者在隊伍中的角色，Members 只在查詢單一隊伍時回傳
+type TeamResponse struct {
+	ID        uuid.UUID            `json:"id"`
+	Name      string               `json:"name"`
+	CreatedBy string               `json:"created_by"`
+	CreatedAt time.Time            `json:"created_at"`
+	Role      string               `json:"role,omitempty"`
+	Members   []TeamMemberResponse `json:"members,omitempty"`
+}
+
+// TeamMemberResponse 描述一位隊伍成員
+type TeamMemberResponse struct {
+	Username string    `json:"username"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// JoinTeamResponse 回傳加入的隊伍與成員資料
+type JoinTeamResponse struct {
+	TeamID uuid.UUID `json:"team_id"`
+	TeamMemberResponse
+}
+
+// TeamInvitationResponse 描述一組邀請碼，不含邀請碼本身
+type TeamInvitationResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	CreatedBy string     `json:"created_by"`
+	MaxUses   int32      `json:"max_uses"`
+	UseCount  int32      `json:"use_count"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// CreateTeamInvitationResponse 額外帶回邀請碼，之後無法再取得
+type CreateTeamInvitationResponse struct {
+	TeamInvitationResponse
+	Code string `json:"code"`
+}
+
+func newTeamMemberResponse(member db.TeamMember) TeamMemberResponse {
+	return TeamMemberResponse{
+		Username: member.Username,
+		Role:     member.Role,
+		JoinedAt: member.JoinedAt,
+	}
+}
+
+func newTeamInvitationResponse(invitation db.TeamInvitation) TeamInvitationResponse {
+	return TeamInvitationResponse{
+		ID:        invitation.ID.Bytes,
+		CreatedBy: invitation.CreatedBy,
+		MaxUses:   invitation.MaxUses,
+		UseCount:  invitation.UseCount,
+		ExpiresAt: invitation.ExpiresAt,
+		RevokedAt: optionalTime(invitation.RevokedAt),
+		CreatedAt: invitation.CreatedAt,
+	}
+}
+
+// writeTeamError 將隊伍相關的 service 錯誤轉成 HTTP 狀態碼
+func writeTeamError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidTeamRole):
+		http.Error(w, "role must be owner or member", http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidTeamInvitation):
+		http.Error(w, "Invitation code is invalid or expired", http.StatusBadRequest)
+	case errors.Is(err, service.ErrNotTeamMember):
+		http.Error(w, "You are not a member of this team", http.StatusForbidden)
+	case errors.Is(err, service.ErrNotTeamOwner):
+		http.Error(w, "Only team owners can do this", http.StatusForbidden)
+	case errors.Is(err, service.ErrTeamNotFound):
+		http.Error(w, "Team not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrTeamMemberNotFound):
+		http.Error(w, "Team member not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrTeamInvitationNotFound):
+		http.Error(w, "Team invitation not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrTeamNameTaken):
+		http.Error(w, "Team name already exists", http.StatusConflict)
+	case errors.Is(err, service.ErrAlreadyTeamMember):
+		http.Error(w, "You are already a member of this team", http.StatusConflict)
+	case errors.Is(err, service.ErrLastTeamOwner):
+		http.Error(w, "A team must keep at least one owner", http.StatusConflict)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	var req CreateTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == "" {
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	team, err := h.service.CreateTeam(r.Context(), req.Name, payload.Username)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(TeamResponse{
+		ID:        team.ID.Bytes,
+		Name:      team.Name,
+		CreatedBy: team.CreatedBy,
+		CreatedAt: team.CreatedAt,
+		Role:      db.TeamRoleOwner,
+	}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListTeams 列出目前使用者所屬的隊伍
+func (h *Handler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	teams, err := h.service.ListUserTeams(r.Context(), payload.Username)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]TeamResponse, 0, len(teams))
+	for _, team := range teams {
+		resp = append(resp, TeamResponse{
+			ID:        team.ID.Bytes,
+			Name:      team.Name,
+			CreatedBy: team.CreatedBy,
+			CreatedAt: team.CreatedAt,
+			Role:      team.Role,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetTeam 回傳隊伍資訊與成員名單
+func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid team id", http.StatusBadRequest)
+		return
+	}
+
+	team, err := h.service.GetTeam(r.Context(), id)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	members, err := h.service.ListTeamMembers(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := TeamResponse{
+		ID:        team.ID.Bytes,
+		Name:      team.Name,
+		CreatedBy: team.CreatedBy,
+		CreatedAt: team.CreatedAt,
+		Members:   make([]TeamMemberResponse, 0, len(members)),
+	}
+	for _, member := range members {
+		resp.Members = append(resp.Members, newTeamMemberResponse(member))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// DeleteTeam 解散隊伍 (限 owner)，隊伍的成績保留但不再屬於任何隊伍
+func (h *Handler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid team id", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.DeleteTeam(r.Context(), id, payload.Username); err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateTeamInvitation 產生邀請碼 (限 owner)
+func (h *Handler) CreateTeamInvitation(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid team id", http.StatusBadRequest)
+		return
+	}
+
+	var req CreateTeamInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ttl := defaultInvitationTTL
+	if req.ExpiresInHours != 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+		if ttl <= 0 || ttl > maxInvitationTTL {
+			http.Error(w, "expires_in_hours must be 1-720", http.StatusBadRequest)
+			return
+		}
+	}
+
+	maxUses := int32(defaultInvitationMaxUses)
+	if req.MaxUses != 0 {
+		if req.MaxUses < 0 || req.MaxUses > maxInvitationMaxUses {
+			http.Error(w, "max_uses must be 1-100", http.StatusBadRequest)
+			return
+		}
+		maxUses = req.MaxUses
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	code, invitation, err := h.service.CreateTeamInvitation(r.Context(), service.CreateTeamInvitationParams{
+		TeamID:    id,
+		CreatedBy: payload.Username,
+		MaxUses:   maxUses,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(CreateTeamInvitationResponse{
+		TeamInvitationResponse: newTeamInvitationResponse(*invitation),
+		Code:                   code,
+	}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListTeamInvitations 列出隊伍的邀請碼 (限 owner)
+func (h *Handler) ListTeamInvitations(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid team id", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	invitations, err := h.service.ListTeamInvitations(r.Context(), id, payload.Username)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	resp := make([]TeamInvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		resp = append(resp, newTeamInvitationResponse(invitation))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RevokeTeamInvitation 撤銷邀請碼 (限 owner)
+func (h *Handler) RevokeTeamInvitation(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid team id", http.StatusBadRequest)
+		return
+	}
+
+	invitationID, err := uuid.Parse(r.PathValue("invitation_id"))
+	if err != nil {
+		http.Error(w, "Invalid invitation id", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RevokeTeamInvitation(r.Context(), id, invitationID, payload.Username); err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// JoinTeam 以邀請碼加入隊伍，加入後的角色為 member
+func (h *Handler) JoinTeam(w http.ResponseWriter, r *http.Request) {
+	var req JoinTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Code == "" {
+		http.Error(w, "Code is required", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	member, err := h.service.JoinTeam(r.Context(), req.Code, payload.Username)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(JoinTeamResponse{
+		TeamID:             member.TeamID.Bytes,
+		TeamMemberResponse: newTeamMemberResponse(*member),
+	}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// UpdateTeamMemberRole 調整成員角色 (限 owner)，隊伍至少要保留一位 owner
+func (h *Handler) UpdateTeamMemberRole(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid team id", http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateTeamMemberRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	member, err := h.service.UpdateTeamMemberRole(r.Context(), service.UpdateTeamMemberRoleParams{
+		TeamID:    id,
+		Username:  r.PathValue("username"),
+		Role:      req.Role,
+		UpdatedBy: payload.Username,
+	})
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newTeamMemberResponse(*member)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RemoveTeamMember 移除成員；owner 可以移除任何人，member 只能退出自己
+func (h *Handler) RemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid team id", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RemoveTeamMember(r.Context(), id, r.PathValue("username"), payload.Username); err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTeamLeaderboard 依各隊最佳成績排名 (公開)
+func (h *Handler) ListTeamLeaderboard(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePagination(r, 10, 100)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	residual, err := parseResidualFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.ListTeamLeaderboard(r.Context(), service.ListScoresParams{
+		Limit:    limit,
+		Offset:   offset,
+		Residual: residual,
+	})
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTeam(t *testing.T) {
+	team := &db.Team{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Name:      "linpack-lovers",
+		CreatedBy: "agent-lead",
+		CreatedAt: time.Now(),
+	}
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful creation",
+			requestBody:    `{"name": "linpack-lovers"}`,
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateTeam", mock.Anything, "linpack-lovers", "agent-lead").Return(team, nil)
+			},
+		},
+		{
+			name:           "missing name",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "name taken",
+			requestBody:    `{"name": "linpack-lovers"}`,
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateTeam", mock.Anything, "linpack-lovers", "agent-lead").Return(nil, service.ErrTeamNameTaken)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := withAuthPayload(httptest.NewRequest(http.MethodPost, "/api/v1/teams", bytes.NewBufferString(tc.requestBody)), "agent-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.CreateTeam).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				var resp TeamResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, uuid.UUID(team.ID.Bytes), resp.ID)
+				assert.Equal(t, db.TeamRoleOwner, resp.Role)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateTeamInvitation(t *testing.T) {
+	teamID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "defaults",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateTeamInvitation", mock.Anything, mock.MatchedBy(func(arg service.CreateTeamInvitationParams) bool {
+					return arg.TeamID == teamID && arg.CreatedBy == "agent-lead" && arg.MaxUses == defaultInvitationMaxUses &&
+						time.Until(arg.ExpiresAt) > defaultInvitationTTL-time.Minute
+				})).Return("ABCD-EFGH-IJKL-MNOP", &db.TeamInvitation{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}, nil)
+			},
+		},
+		{
+			name:           "too many uses",
+			requestBody:    `{"max_uses": 1000}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "expiry too long",
+			requestBody:    `{"expires_in_hours": 10000}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "not an owner",
+			requestBody:    `{"max_uses": 3}`,
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateTeamInvitation", mock.Anything, mock.Anything).Return("", nil, service.ErrNotTeamOwner)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/teams/"+teamID.String()+"/invitations", bytes.NewBufferString(tc.requestBody))
+			req.SetPathValue("id", teamID.String())
+			req = withAuthPayload(req, "agent-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.CreateTeamInvitation).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				var resp CreateTeamInvitationResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, "ABCD-EFGH-IJKL-MNOP", resp.Code)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJoinTeam(t *testing.T) {
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful join",
+			requestBody:    `{"code": "ABCD-EFGH-IJKL-MNOP"}`,
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("JoinTeam", mock.Anything, "ABCD-EFGH-IJKL-MNOP", "agent-lead").Return(&db.TeamMember{
+					TeamID:   pgtype.UUID{Bytes: uuid.New(), Valid: true},
+					Username: "agent-lead",
+					Role:     db.TeamRoleMember,
+				}, nil)
+			},
+		},
+		{
+			name:           "missing code",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "invalid code",
+			requestBody:    `{"code": "nope"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("JoinTeam", mock.Anything, "nope", "agent-lead").Return(nil, service.ErrInvalidTeamInvitation)
+			},
+		},
+		{
+			name:           "already a member",
+			requestBody:    `{"code": "ABCD-EFGH-IJKL-MNOP"}`,
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("JoinTeam", mock.Anything, "ABCD-EFGH-IJKL-MNOP", "agent-lead").Return(nil, service.ErrAlreadyTeamMember)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := withAuthPayload(httptest.NewRequest(http.MethodPost, "/api/v1/teams/join", bytes.NewBufferString(tc.requestBody)), "agent-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.JoinTeam).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRemoveTeamMember(t *testing.T) {
+	teamID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		username       string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "leave team",
+			username:       "agent-lead",
+			expectedStatus: http.StatusNoContent,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("RemoveTeamMember", mock.Anything, teamID, "agent-lead", "agent-lead").Return(nil)
+			},
+		},
+		{
+			name:           "last owner",
+			username:       "agent-lead",
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("RemoveTeamMember", mock.Anything, teamID, "agent-lead", "agent-lead").Return(service.ErrLastTeamOwner)
+			},
+		},
+		{
+			name:           "member removing someone else",
+			username:       "other",
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("RemoveTeamMember", mock.Anything, teamID, "other", "agent-lead").Return(service.ErrNotTeamOwner)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/teams/"+teamID.String()+"/members/"+tc.username, nil)
+			req.SetPathValue("id", teamID.String())
+			req.SetPathValue("username", tc.username)
+			req = withAuthPayload(req, "agent-lead")
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.RemoveTeamMember).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListTeamLeaderboard(t *testing.T) {
+	mockService := new(mocks.Service)
+	h := NewHandler(mockService, new(token_mocks.Maker))
+
+	mockService.On("ListTeamLeaderboard", mock.Anything, service.ListScoresParams{Limit: 5, Offset: 10}).Return(&service.TeamLeaderboardResponse{
+		Teams: []db.ListTeamLeaderboardRow{
+			{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Name: "linpack-lovers", BestGflops: 3e9, Submissions: 3},
+		},
+		TotalRecords: 11,
+		Limit:        5,
+		Offset:       10,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/teams?limit=5&offset=10", nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(h.ListTeamLeaderboard).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp service.TeamLeaderboardResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Len(t, resp.Teams, 1)
+	assert.Equal(t, "linpack-lovers", resp.Teams[0].Name)
+	mockService.AssertExpectations(t)
+
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(h.ListTeamLeaderboard).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/leaderboards/teams?limit=0", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+)
+
+const (
+	accessTokenDuration  = 15 * time.Minute
+	refreshTokenDuration = 7 * 24 * time.Hour
+)
+
+// RefreshTokenRequest 定義換發 Token 的請求格式
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshTokenResponse 定義換發 Token 的回傳格式
+type RefreshTokenResponse struct {
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+// RefreshToken rotates a refresh token: the presented token is consumed and a
+// new access/refresh token pair is issued in the same session family.
+func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var req RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.RefreshToken == "" {
+		http.Error(w, "Refresh token is required", http.StatusBadRequest)
+		return
+	}
+
+	oldPayload, err := h.tokenMaker.VerifyToken(req.RefreshToken)
+	if err != nil || oldPayload.TokenType != token.TokenTypeRefresh {
+		http.Error(w, "Invalid refresh token", http.StatusUnauthorized)
+		return
+	}
+
+	refreshToken, refreshPayload, err := h.tokenMaker.CreateRefreshToken(oldPayload.Username, oldPayload.MFA, refreshTokenDuration)
+	if err != nil {
+		http.Error(w, "Failed to create refresh token", http.StatusInternalServerError)
+		return
+	}
+
+	_, err = h.service.RotateSession(r.Context(), service.RotateSessionParams{
+		OldID:     oldPayload.ID,
+		NewID:     refreshPayload.ID,
+		Username:  oldPayload.Username,
+		UserAgent: r.UserAgent(),
+		ClientIP:  r.RemoteAddr,
+		ExpiresAt: refreshPayload.ExpiredAt,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrRefreshTokenReused):
+			log.Printf("refresh token reuse detected for %q, session family revoked", oldPayload.Username)
+			http.Error(w, "Invalid refresh token", http.StatusUnauthorized)
+		case errors.Is(err, service.ErrSessionNotFound),
+			errors.Is(err, service.ErrSessionRevoked),
+			errors.Is(err, service.ErrSessionExpired),
+			errors.Is(err, service.ErrSessionMismatch):
+			http.Error(w, "Invalid refresh token", http.StatusUnauthorized)
+		default:
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 角色以資料庫為準，角色異動會在下次換發時生效
+	user, err := h.service.GetUser(r.Context(), oldPayload.Username)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			http.Error(w, "Invalid refresh token", http.StatusUnauthorized)
+			return
+		}
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	// MFA 狀態沿用登入時的結果，換發 Token 不需要再次輸入驗證碼
+	accessToken, accessPayload, err := h.tokenMaker.CreateToken(user.Username, user.Roles, oldPayload.MFA, accessTokenDuration)
+	if err != nil {
+		http.Error(w, "Failed to create access token", http.StatusInternalServerError)
+		return
+	}
+
+	resp := RefreshTokenResponse{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshPayload.ExpiredAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// LogoutRequest 可選擇一併帶入 Refresh Token，以結束整個 Session
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Logout revokes the access token used for the request and, when provided,
+// the refresh token session it belongs to.
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	var req LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.RefreshToken != "" {
+		refreshPayload, err := h.tokenMaker.VerifyToken(req.RefreshToken)
+		if err != nil || refreshPayload.TokenType != token.TokenTypeRefresh || refreshPayload.Username != payload.Username {
+			http.Error(w, "Invalid refresh token", http.StatusUnauthorized)
+			return
+		}
+
+		err = h.service.EndSession(r.Context(), refreshPayload.ID, payload.Username)
+		if err != nil && !errors.Is(err, service.ErrSessionNotFound) {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := h.service.RevokeToken(r.Context(), payload); err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/middleware"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRefreshToken(t *testing.T) {
+	user := "agent-lead"
+	oldPayload := &token.Payload{
+		ID:        uuid.New(),
+		Username:  user,
+		TokenType: token.TokenTypeRefresh,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(refreshTokenDuration),
+	}
+	newRefreshPayload := &token.Payload{
+		ID:        uuid.New(),
+		Username:  user,
+		TokenType: token.TokenTypeRefresh,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(refreshTokenDuration),
+	}
+	accessPayload := &token.Payload{
+		ID:        uuid.New(),
+		Username:  user,
+		TokenType: token.TokenTypeAccess,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(accessTokenDuration),
+	}
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMocks     func(*mocks.Service, *token_mocks.Maker)
+	}{
+		{
+			name:           "successful rotation",
+			requestBody:    `{"refresh_token": "old_refresh_token"}`,
+			expectedStatus: http.StatusOK,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockTokenMaker.On("VerifyToken", "old_refresh_token").Return(oldPayload, nil)
+				mockTokenMaker.On("CreateRefreshToken", user, false, refreshTokenDuration).Return("new_refresh_token", newRefreshPayload, nil)
+				mockService.On("RotateSession", mock.Anything, mock.MatchedBy(func(arg service.RotateSessionParams) bool {
+					return arg.OldID == oldPayload.ID && arg.NewID == newRefreshPayload.ID && arg.Username == user
+				})).Return(&db.Session{Username: user}, nil)
+				// Access Token 帶入資料庫中最新的角色
+				mockService.On("GetUser", mock.Anything, user).Return(&db.User{Username: user, Roles: []string{token.RoleJudge}}, nil)
+				mockTokenMaker.On("CreateToken", user, []string{token.RoleJudge}, false, accessTokenDuration).Return("new_access_token", accessPayload, nil)
+			},
+		},
+		{
+			name:           "rotation keeps MFA claim",
+			requestBody:    `{"refresh_token": "mfa_refresh_token"}`,
+			expectedStatus: http.StatusOK,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mfaPayload := *oldPayload
+				mfaPayload.MFA = true
+				mockTokenMaker.On("VerifyToken", "mfa_refresh_token").Return(&mfaPayload, nil)
+				mockTokenMaker.On("CreateRefreshToken", user, true, refreshTokenDuration).Return("new_refresh_token", newRefreshPayload, nil)
+				mockService.On("RotateSession", mock.Anything, mock.Anything).Return(&db.Session{Username: user}, nil)
+				mockService.On("GetUser", mock.Anything, user).Return(&db.User{Username: user, Roles: []string{token.RoleJudge}}, nil)
+				mockTokenMaker.On("CreateToken", user, []string{token.RoleJudge}, true, accessTokenDuration).Return("new_access_token", accessPayload, nil)
+			},
+		},
+		{
+			name:           "missing refresh token",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMocks:     func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {},
+		},
+		{
+			name:           "invalid refresh token",
+			requestBody:    `{"refresh_token": "garbage"}`,
+			expectedStatus: http.StatusUnauthorized,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockTokenMaker.On("VerifyToken", "garbage").Return(nil, token.ErrInvalidToken)
+			},
+		},
+		{
+			name:           "access token presented as refresh token",
+			requestBody:    `{"refresh_token": "access_token"}`,
+			expectedStatus: http.StatusUnauthorized,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockTokenMaker.On("VerifyToken", "access_token").Return(accessPayload, nil)
+			},
+		},
+		{
+			name:           "reused refresh token",
+			requestBody:    `{"refresh_token": "old_refresh_token"}`,
+			expectedStatus: http.StatusUnauthorized,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockTokenMaker.On("VerifyToken", "old_refresh_token").Return(oldPayload, nil)
+				mockTokenMaker.On("CreateRefreshToken", user, false, refreshTokenDuration).Return("new_refresh_token", newRefreshPayload, nil)
+				mockService.On("RotateSession", mock.Anything, mock.Anything).Return(nil, service.ErrRefreshTokenReused)
+			},
+		},
+		{
+			name:           "revoked session",
+			requestBody:    `{"refresh_token": "old_refresh_token"}`,
+			expectedStatus: http.StatusUnauthorized,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockTokenMaker.On("VerifyToken", "old_refresh_token").Return(oldPayload, nil)
+				mockTokenMaker.On("CreateRefreshToken", user, false, refreshTokenDuration).Return("new_refresh_token", newRefreshPayload, nil)
+				mockService.On("RotateSession", mock.Anything, mock.Anything).Return(nil, service.ErrSessionRevoked)
+			},
+		},
+		{
+			name:           "user deleted after login",
+			requestBody:    `{"refresh_token": "old_refresh_token"}`,
+			expectedStatus: http.StatusUnauthorized,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockTokenMaker.On("VerifyToken", "old_refresh_token").Return(oldPayload, nil)
+				mockTokenMaker.On("CreateRefreshToken", user, false, refreshTokenDuration).Return("new_refresh_token", newRefreshPayload, nil)
+				mockService.On("RotateSession", mock.Anything, mock.Anything).Return(&db.Session{Username: user}, nil)
+				mockService.On("GetUser", mock.Anything, user).Return(nil, service.ErrUserNotFound)
+			},
+		},
+		{
+			name:           "service layer error",
+			requestBody:    `{"refresh_token": "old_refresh_token"}`,
+			expectedStatus: http.StatusInternalServerError,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockTokenMaker.On("VerifyToken", "old_refresh_token").Return(oldPayload, nil)
+				mockTokenMaker.On("CreateRefreshToken", user, false, refreshTokenDuration).Return("new_refresh_token", newRefreshPayload, nil)
+				mockService.On("RotateSession", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			mockTokenMaker := new(token_mocks.Maker)
+			h := NewHandler(mockService, mockTokenMaker)
+
+			tc.setupMocks(mockService, mockTokenMaker)
+
+			req, err := http.NewRequest("POST", "/api/v1/tokens/refresh", bytes.NewBufferString(tc.requestBody))
+			assert.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.RefreshToken).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp RefreshTokenResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, "new_access_token", resp.AccessToken)
+				assert.Equal(t, "new_refresh_token", resp.RefreshToken)
+			} else {
+				// 失敗時不可簽發新的 Access Token
+				mockTokenMaker.AssertNotCalled(t, "CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			mockService.AssertExpectations(t)
+			mockTokenMaker.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	user := "agent-lead"
+	accessPayload := &token.Payload{
+		ID:        uuid.New(),
+		Username:  user,
+		TokenType: token.TokenTypeAccess,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(accessTokenDuration),
+	}
+	refreshPayload := &token.Payload{
+		ID:        uuid.New(),
+		Username:  user,
+		TokenType: token.TokenTypeRefresh,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(refreshTokenDuration),
+	}
+	otherRefreshPayload := &token.Payload{
+		ID:        uuid.New(),
+		Username:  "someone-else",
+		TokenType: token.TokenTypeRefresh,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(refreshTokenDuration),
+	}
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		hasAuthPayload bool
+		expectedStatus int
+		setupMocks     func(*mocks.Service, *token_mocks.Maker)
+	}{
+		{
+			name:           "logout without refresh token",
+			requestBody:    "",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusNoContent,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockService.On("RevokeToken", mock.Anything, accessPayload).Return(nil)
+			},
+		},
+		{
+			name:           "logout ends refresh session",
+			requestBody:    `{"refresh_token": "refresh_token"}`,
+			hasAuthPayload: true,
+			expectedStatus: http.StatusNoContent,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockTokenMaker.On("VerifyToken", "refresh_token").Return(refreshPayload, nil)
+				mockService.On("EndSession", mock.Anything, refreshPayload.ID, user).Return(nil)
+				mockService.On("RevokeToken", mock.Anything, accessPayload).Return(nil)
+			},
+		},
+		{
+			name:           "refresh token of another user",
+			requestBody:    `{"refresh_token": "other_refresh_token"}`,
+			hasAuthPayload: true,
+			expectedStatus: http.StatusUnauthorized,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockTokenMaker.On("VerifyToken", "other_refresh_token").Return(otherRefreshPayload, nil)
+			},
+		},
+		{
+			name:           "missing authorization payload",
+			requestBody:    "",
+			hasAuthPayload: false,
+			expectedStatus: http.StatusUnauthorized,
+			setupMocks:     func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {},
+		},
+		{
+			name:           "service layer error",
+			requestBody:    "",
+			hasAuthPayload: true,
+			expectedStatus: http.StatusInternalServerError,
+			setupMocks: func(mockService *mocks.Service, mockTokenMaker *token_mocks.Maker) {
+				mockService.On("RevokeToken", mock.Anything, accessPayload).Return(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			mockTokenMaker := new(token_mocks.Maker)
+			h := NewHandler(mockService, mockTokenMaker)
+
+			tc.setupMocks(mockService, mockTokenMaker)
+
+			req, err := http.NewRequest("POST", "/api/v1/logout", bytes.NewBufferString(tc.requestBody))
+			assert.NoError(t, err)
+			if tc.hasAuthPayload {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorizationPayloadKey, accessPayload))
+			}
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.Logout).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+			mockTokenMaker.AssertExpectations(t)
+		})
+	}
+}
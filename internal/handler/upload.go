@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/hplout"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+)
+
+const maxUploadBytes = 1 << 20
+
+// UploadScoresResponse 回傳建立的成績
+type UploadScoresResponse struct {
+	Scores []db.Score `json:"scores"`
+}
+
+// UploadScores 接受 multipart/form-data：file 為 HPL.out，system_id、ranks、nodes 與 ranks_per_node 必填，
+// linux_username、team_id 與 run_nonce 為選填欄位 (run_nonce 未填時使用檔案中的 HPL_SCOREBOARD_RUN_NONCE)。
+// 每一行 WR 結果各建立一筆成績，全部在同一個交易中完成；
+// 沒有通過 residual check 的成績也會記錄，但預設不列入排行榜。
+func (h *Handler) UploadScores(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadBytes)
+	if err := r.ParseMultipartForm(maxUploadBytes); err != nil {
+		http.Error(w, "Invalid multipart form", http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	output, err := hplout.Parse(file)
+	if err != nil {
+		http.Error(w, "Invalid HPL output: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := authPayload(r)
+	if !ok {
+		http.Error(w, "Missing authorization payload", http.StatusUnauthorized)
+		return
+	}
+
+	params := service.UploadScoresParams{
+		UserID:            payload.Username,
+		LinuxUsername:     r.FormValue("linux_username"),
+		RunNonce:          r.FormValue("run_nonce"),
+		ResidualThreshold: output.Threshold,
+		Results:           output.Results,
+	}
+	if params.RunNonce == "" {
+		params.RunNonce = output.RunNonce
+	}
+	if identity, ok := clientIdentity(r); ok {
+		params.ClientIdentity = identity.String()
+	}
+	for _, field := range []struct {
+		name string
+		dst  *int
+	}{
+		{"ranks", &params.Ranks},
+		{"nodes", &params.Nodes},
+		{"ranks_per_node", &params.RanksPerNode},
+	} {
+		value, err := strconv.Atoi(r.FormValue(field.name))
+		if err != nil {
+			http.Error(w, field.name+" is required", http.StatusBadRequest)
+			return
+		}
+		*field.dst = value
+	}
+	if systemIDStr := r.FormValue("system_id"); systemIDStr != "" {
+		systemID, err := uuid.Parse(systemIDStr)
+		if err != nil {
+			http.Error(w, "Invalid system_id", http.StatusBadRequest)
+			return
+		}
+		params.SystemID = systemID
+	}
+	if teamIDStr := r.FormValue("team_id"); teamIDStr != "" {
+		teamID, err := uuid.Parse(teamIDStr)
+		if err != nil {
+			http.Error(w, "Invalid team_id", http.StatusBadRequest)
+			return
+		}
+		params.TeamID = teamID
+	}
+
+	scores, err := h.service.UploadScores(r.Context(), params)
+	if err != nil {
+		writeScoreError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(UploadScoresResponse{Scores: scores}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
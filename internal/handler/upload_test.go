@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testHPLOutput = `HPL_SCOREBOARD_RUN_NONCE=hplr_from_file
+- Computational tests pass if scaled residuals are less than                16.0
+T/V                N    NB     P     Q               Time                 Gflops
+--------------------------------------------------------------------------------
+WR11C2R4       29184   192     2     2              34.22             4.8455e+02
+--------------------------------------------------------------------------------
+||Ax-b||_oo/(eps*(||A||_oo*||x||_oo+||b||_oo)*N)=   3.47633052e-03 ...... PASSED
+================================================================================
+T/V                N    NB     P     Q               Time                 Gflops
+--------------------------------------------------------------------------------
+WR11C2R4       29184   256     2     2              31.90             5.1979e+02
+--------------------------------------------------------------------------------
+||Ax-b||_oo/(eps*(||A||_oo*||x||_oo+||b||_oo)*N)=   2.11536612e+01 ...... FAILED
+================================================================================
+`
+
+// uploadRequest 建立 multipart 請求，fields 為額外的表單欄位；
+// 未指定 ranks、nodes 與 ranks_per_node 時填入 2×2 grid 對應的單節點配置
+func uploadRequest(t *testing.T, content string, fields map[string]string) *http.Request {
+	layout := map[string]string{"ranks": "4", "nodes": "1", "ranks_per_node": "4"}
+	for name, value := range fields {
+		layout[name] = value
+	}
+	fields = layout
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if content != "" {
+		part, err := writer.CreateFormFile("file", "HPL.out")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scores/upload", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return withAuthPayload(req, "agent-lead")
+}
+
+func TestUploadScores(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        func(t *testing.T) *http.Request
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name: "every result becomes a score",
+			request: func(t *testing.T) *http.Request {
+				return uploadRequest(t, testHPLOutput, map[string]string{"linux_username": "hpl_user1"})
+			},
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UploadScores", mock.Anything, mock.MatchedBy(func(arg service.UploadScoresParams) bool {
+					return arg.UserID == "agent-lead" &&
+						arg.LinuxUsername == "hpl_user1" &&
+						arg.RunNonce == "hplr_from_file" &&
+						arg.ResidualThreshold == 16.0 &&
+						arg.Ranks == 4 && arg.Nodes == 1 && arg.RanksPerNode == 4 &&
+						len(arg.Results) == 2 &&
+						arg.Results[0].Residual == 3.47633052e-03 &&
+						arg.Results[0].Passed &&
+						arg.Results[1].Residual == 21.1536612 &&
+						!arg.Results[1].Passed
+				})).Return([]db.Score{{UserID: "agent-lead", Gflops: 484.55}, {UserID: "agent-lead", Gflops: 519.79}}, nil)
+			},
+		},
+		{
+			name: "form run nonce wins over the file",
+			request: func(t *testing.T) *http.Request {
+				return uploadRequest(t, testHPLOutput, map[string]string{"run_nonce": "hplr_from_form"})
+			},
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UploadScores", mock.Anything, mock.MatchedBy(func(arg service.UploadScoresParams) bool {
+					return arg.RunNonce == "hplr_from_form"
+				})).Return([]db.Score{{UserID: "agent-lead"}, {UserID: "agent-lead"}}, nil)
+			},
+		},
+		{
+			name: "missing file",
+			request: func(t *testing.T) *http.Request {
+				return uploadRequest(t, "", map[string]string{"run_nonce": "hplr_from_form"})
+			},
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name: "missing ranks",
+			request: func(t *testing.T) *http.Request {
+				return uploadRequest(t, testHPLOutput, map[string]string{"ranks": ""})
+			},
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name: "not an HPL output",
+			request: func(t *testing.T) *http.Request {
+				return uploadRequest(t, "hello\n", nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name: "unchecked result",
+			request: func(t *testing.T) *http.Request {
+				return uploadRequest(t, "WR11C2R4  1000  128  1  1  0.05  1.3e+01\n", nil)
+			},
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UploadScores", mock.Anything, mock.MatchedBy(func(arg service.UploadScoresParams) bool {
+					return len(arg.Results) == 1 && !arg.Results[0].Checked
+				})).Return([]db.Score{{UserID: "agent-lead"}}, nil)
+			},
+		},
+		{
+			name: "residual verdict disagrees with the threshold",
+			request: func(t *testing.T) *http.Request {
+				return uploadRequest(t, testHPLOutput, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UploadScores", mock.Anything, mock.Anything).Return(nil, service.ErrResidualVerdictMismatch)
+			},
+		},
+		{
+			name: "run nonce already used",
+			request: func(t *testing.T) *http.Request {
+				return uploadRequest(t, testHPLOutput, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("UploadScores", mock.Anything, mock.Anything).Return(nil, service.ErrInvalidRunNonce)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			h := NewHandler(mockService, new(token_mocks.Maker))
+			tc.setupMock(mockService)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.UploadScores).ServeHTTP(rr, tc.request(t))
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				var resp UploadScoresResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.NotEmpty(t, resp.Scores)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
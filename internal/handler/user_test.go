@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/service"
+	"github.com/kdotwei/hpl-scoreboard/internal/service/mocks"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateUser(t *testing.T) {
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		setupMock      func(*mocks.Service)
+	}{
+		{
+			name:           "successful registration",
+			requestBody:    `{"username": "agent-lead", "password": "correct-horse"}`,
+			expectedStatus: http.StatusCreated,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateUser", mock.Anything, service.CreateUserParams{
+					Username: "agent-lead",
+					Password: "correct-horse",
+				}).Return(&db.User{Username: "agent-lead", CreatedAt: time.Now()}, nil)
+			},
+		},
+		{
+			name:           "invalid JSON body",
+			requestBody:    `{"username": `,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "missing username",
+			requestBody:    `{"password": "correct-horse"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "password too short",
+			requestBody:    `{"username": "agent-lead", "password": "short"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockService *mocks.Service) {},
+		},
+		{
+			name:           "username already exists",
+			requestBody:    `{"username": "agent-lead", "password": "correct-horse"}`,
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateUser", mock.Anything, mock.Anything).Return(nil, service.ErrUsernameTaken)
+			},
+		},
+		{
+			name:           "service layer error",
+			requestBody:    `{"username": "agent-lead", "password": "correct-horse"}`,
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(mockService *mocks.Service) {
+				mockService.On("CreateUser", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.Service)
+			mockTokenMaker := new(token_mocks.Maker)
+			h := NewHandler(mockService, mockTokenMaker)
+
+			tc.setupMock(mockService)
+
+			req, err := http.NewRequest("POST", "/api/v1/users", bytes.NewBufferString(tc.requestBody))
+			assert.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(h.CreateUser).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp UserResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, "agent-lead", resp.Username)
+				// 回應不可包含密碼雜湊
+				assert.NotContains(t, rr.Body.String(), "password")
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
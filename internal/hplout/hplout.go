@@ -0,0 +1,172 @@
+// Package hplout parses the HPL.out files written by HPLinpack, extracting
+// every result line together with its residual check.
+package hplout
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RunNonceKey 是記錄在 HPL.out 中的 run nonce 名稱，例如在檔案開頭加上
+// HPL_SCOREBOARD_RUN_NONCE=hplr_...
+const RunNonceKey = "HPL_SCOREBOARD_RUN_NONCE"
+
+const maxLineBytes = 64 * 1024
+
+var (
+	ErrNoResults = errors.New("no WR result lines found in HPL output")
+
+	// variantPattern 對應 T/V 欄位，例如 WR11C2R4：W + 儲存順序 + depth + bcast + rfact + nbmin + pfact + ndiv
+	variantPattern  = regexp.MustCompile(`^W[RC]\d\d[LCR]\d+[LCR]\d+$`)
+	runNoncePattern = regexp.MustCompile(RunNonceKey + `=["']?([A-Za-z0-9_-]+)`)
+)
+
+// Result is one WR line of an HPL run and the residual check that follows it
+type Result struct {
+	// Variant 是 T/V 欄位，例如 WR11C2R4
+	Variant string
+	N       int
+	NB      int
+	P       int
+	Q       int
+	// Time 是執行秒數
+	Time   float64
+	Gflops float64
+	// Checked reports whether a residual check line followed the result;
+	// HPL skips the check when the threshold in HPL.dat is negative.
+	Checked bool
+	// Residual 是 scaled residual，有多行時取最大值
+	Residual float64
+	// Passed 是 HPL 印出的判定，所有 residual check 都是 PASSED 時為 true
+	Passed bool
+}
+
+// Output is everything the scoreboard needs from an HPL.out file
+type Output struct {
+	// Threshold 是 "scaled residuals are less than" 後的門檻，找不到時為 0
+	Threshold float64
+	// RunNonce 是檔案中記錄的 HPL_SCOREBOARD_RUN_NONCE，找不到時為空字串
+	RunNonce string
+	Results  []Result
+}
+
+// Parse reads an HPL.out file. Lines that are neither results, residual
+// checks, the threshold nor the run nonce are ignored, so output from several
+// runs or wrapper scripts can be concatenated.
+func Parse(r io.Reader) (*Output, error) {
+	output := &Output{}
+	var current *Result
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineBytes)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if m := runNoncePattern.FindStringSubmatch(line); m != nil && output.RunNonce == "" {
+			output.RunNonce = m[1]
+			continue
+		}
+
+		if strings.Contains(line, "scaled residuals are less than") {
+			fields := strings.Fields(line)
+			threshold, err := strconv.ParseFloat(fields[len(fields)-1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid residual threshold: %w", lineNo, err)
+			}
+			output.Threshold = threshold
+			continue
+		}
+
+		// 檔頭也會印出 ||Ax-b|| 的公式，只有帶 = 與 PASSED / FAILED 的才是結果
+		if isResidualLine(line) {
+			if current == nil {
+				continue
+			}
+			residual, passed, err := parseResidual(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			if !current.Checked {
+				current.Checked, current.Residual, current.Passed = true, residual, passed
+			} else {
+				current.Residual = max(current.Residual, residual)
+				current.Passed = current.Passed && passed
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) >= 7 && ValidVariant(fields[0]) {
+			result, err := parseResult(fields)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			output.Results = append(output.Results, result)
+			current = &output.Results[len(output.Results)-1]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(output.Results) == 0 {
+		return nil, ErrNoResults
+	}
+	return output, nil
+}
+
+// ValidVariant reports whether variant looks like an HPL T/V field, e.g. WR11C2R4
+func ValidVariant(variant string) bool {
+	return variantPattern.MatchString(variant)
+}
+
+// parseResult 解析 "WR11C2R4  29184  192  2  2  34.22  4.8455e+02"
+func parseResult(fields []string) (Result, error) {
+	result := Result{Variant: fields[0]}
+
+	ints := []*int{&result.N, &result.NB, &result.P, &result.Q}
+	for i, dst := range ints {
+		value, err := strconv.Atoi(fields[i+1])
+		if err != nil {
+			return Result{}, fmt.Errorf("invalid %s result line: %w", result.Variant, err)
+		}
+		*dst = value
+	}
+
+	var err error
+	if result.Time, err = strconv.ParseFloat(fields[5], 64); err != nil {
+		return Result{}, fmt.Errorf("invalid %s time: %w", result.Variant, err)
+	}
+	if result.Gflops, err = strconv.ParseFloat(fields[6], 64); err != nil {
+		return Result{}, fmt.Errorf("invalid %s gflops: %w", result.Variant, err)
+	}
+	return result, nil
+}
+
+// isResidualLine 判斷是否為 "||Ax-b||_oo/(...)=  3.47633052e-03 ...... PASSED" 這類結果行
+func isResidualLine(line string) bool {
+	if !strings.HasPrefix(line, "||Ax-b||") || !strings.Contains(line, "=") {
+		return false
+	}
+	return strings.HasSuffix(line, "PASSED") || strings.HasSuffix(line, "FAILED")
+}
+
+// parseResidual 解析 "||Ax-b||_oo/(eps*(||A||_oo*||x||_oo+||b||_oo)*N)=  3.47633052e-03 ...... PASSED"，
+// 回傳數值與行尾 PASSED / FAILED 的判定
+func parseResidual(line string) (float64, bool, error) {
+	fields := strings.Fields(line[strings.LastIndex(line, "=")+1:])
+	if len(fields) < 2 {
+		return 0, false, errors.New("residual check line has no value")
+	}
+	residual, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid residual: %w", err)
+	}
+	// isResidualLine 已確認行尾是 PASSED 或 FAILED
+	return residual, fields[len(fields)-1] == "PASSED", nil
+}
@@ -0,0 +1,200 @@
+package hplout
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const hpl23Output = `HPL_SCOREBOARD_RUN_NONCE=hplr_0123456789abcdef0123456789abcdef
+================================================================================
+HPLinpack 2.3  --  High-Performance Linpack benchmark  --   December 2, 2018
+Written by A. Petitet and R. Clint Whaley,  Innovative Computing Laboratory, UTK
+================================================================================
+
+An explanation of the input/output parameters follows:
+T/V    : Wall time / encoded variant.
+N      : The order of the coefficient matrix A.
+NB     : The partitioning blocking factor.
+P      : The number of process rows.
+Q      : The number of process columns.
+Time   : Time in seconds to solve the linear system.
+Gflops : Rate of execution for solving the linear system.
+
+The following parameter values will be used:
+
+N      :   29184    29184
+NB     :     192      256
+PMAP   : Row-major process mapping
+P      :       2
+Q      :       2
+
+--------------------------------------------------------------------------------
+
+- The matrix A is randomly generated for each test.
+- The following scaled residual check will be computed:
+      ||Ax-b||_oo / ( eps * ( || x ||_oo * || A ||_oo + || b ||_oo ) * N )
+- The relative machine precision (eps) is taken to be               1.110223e-16
+- Computational tests pass if scaled residuals are less than                16.0
+
+================================================================================
+T/V                N    NB     P     Q               Time                 Gflops
+--------------------------------------------------------------------------------
+WR11C2R4       29184   192     2     2              34.22             4.8455e+02
+HPL_pdgesv() start time Wed Dec 18 10:00:00 2024
+
+HPL_pdgesv() end time   Wed Dec 18 10:00:34 2024
+
+--------------------------------------------------------------------------------
+||Ax-b||_oo/(eps*(||A||_oo*||x||_oo+||b||_oo)*N)=   3.47633052e-03 ...... PASSED
+================================================================================
+T/V                N    NB     P     Q               Time                 Gflops
+--------------------------------------------------------------------------------
+WR11C2R4       29184   256     2     2              31.90             5.1979e+02
+HPL_pdgesv() start time Wed Dec 18 10:00:40 2024
+
+HPL_pdgesv() end time   Wed Dec 18 10:01:12 2024
+
+--------------------------------------------------------------------------------
+||Ax-b||_oo/(eps*(||A||_oo*||x||_oo+||b||_oo)*N)=   2.11536612e+01 ...... FAILED
+================================================================================
+
+Finished      2 tests with the following results:
+              1 tests completed and passed residual checks,
+              1 tests completed and failed residual checks,
+              0 tests skipped because of illegal input values.
+--------------------------------------------------------------------------------
+
+End of Tests.
+================================================================================
+`
+
+func TestParse(t *testing.T) {
+	output, err := Parse(strings.NewReader(hpl23Output))
+	require.NoError(t, err)
+
+	assert.Equal(t, 16.0, output.Threshold)
+	assert.Equal(t, "hplr_0123456789abcdef0123456789abcdef", output.RunNonce)
+	require.Len(t, output.Results, 2)
+
+	assert.Equal(t, Result{
+		Variant:  "WR11C2R4",
+		N:        29184,
+		NB:       192,
+		P:        2,
+		Q:        2,
+		Time:     34.22,
+		Gflops:   484.55,
+		Checked:  true,
+		Residual: 3.47633052e-03,
+		Passed:   true,
+	}, output.Results[0])
+
+	assert.Equal(t, 256, output.Results[1].NB)
+	assert.True(t, output.Results[1].Checked)
+	assert.InDelta(t, 21.1536612, output.Results[1].Residual, 1e-9)
+	assert.False(t, output.Results[1].Passed)
+}
+
+func TestParseMultipleResidualLines(t *testing.T) {
+	// HPL 1.0 每個結果會印出三行 residual check
+	output, err := Parse(strings.NewReader(`
+WR00L2L2        1000   120     1     1               0.26              2.564e+00
+--------------------------------------------------------------------------------
+||Ax-b||_oo / ( eps * ||A||_1  * N        ) =        0.0367186 ...... PASSED
+||Ax-b||_oo / ( eps * ||A||_1  * ||x||_1  ) =        0.0330468 ...... PASSED
+||Ax-b||_oo / ( eps * ||A||_oo * ||x||_oo ) =       17.0070834 ...... FAILED
+`))
+	require.NoError(t, err)
+	require.Len(t, output.Results, 1)
+
+	result := output.Results[0]
+	assert.Equal(t, "WR00L2L2", result.Variant)
+	assert.True(t, result.Checked)
+	assert.Equal(t, 17.0070834, result.Residual)
+	// 任何一行 FAILED 就視為未通過
+	assert.False(t, result.Passed)
+	assert.Empty(t, output.RunNonce)
+}
+
+func TestParseConcatenatedOutputs(t *testing.T) {
+	// 同一個檔案中有兩次執行，第二次的檔頭也印出 ||Ax-b|| 公式
+	var concatenated []byte
+	for _, name := range []string{"testdata/run1.out", "testdata/run2.out"} {
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		concatenated = append(concatenated, data...)
+	}
+
+	output, err := Parse(bytes.NewReader(concatenated))
+	require.NoError(t, err)
+
+	assert.Equal(t, "hplr_0123456789abcdef0123456789abcdef", output.RunNonce)
+	require.Len(t, output.Results, 2)
+	assert.Equal(t, 29184, output.Results[0].N)
+	assert.Equal(t, 3.47633052e-03, output.Results[0].Residual)
+	assert.Equal(t, Result{
+		Variant:  "WR11C2R4",
+		N:        40000,
+		NB:       192,
+		P:        2,
+		Q:        4,
+		Time:     58.41,
+		Gflops:   730.54,
+		Checked:  true,
+		Residual: 2.91530164e-03,
+		Passed:   true,
+	}, output.Results[1])
+}
+
+func TestParseUncheckedResult(t *testing.T) {
+	output, err := Parse(strings.NewReader("WR11C2R4  1000  128  1  1  0.05  1.3e+01\n"))
+	require.NoError(t, err)
+	require.Len(t, output.Results, 1)
+	assert.False(t, output.Results[0].Checked)
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		errMsg string
+	}{
+		{
+			name:   "no results",
+			input:  "HPLinpack 2.3\nEnd of Tests.\n",
+			errMsg: ErrNoResults.Error(),
+		},
+		{
+			name:   "malformed gflops",
+			input:  "WR11C2R4  1000  128  1  1  0.05  fast\n",
+			errMsg: "line 1: invalid WR11C2R4 gflops",
+		},
+		{
+			name:   "malformed residual",
+			input:  "WR11C2R4  1000  128  1  1  0.05  1.3e+01\n||Ax-b||_oo/(eps*N)= small ...... PASSED\n",
+			errMsg: "line 2: invalid residual",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tc.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestValidVariant(t *testing.T) {
+	for _, variant := range []string{"WR11C2R4", "WC00L2L2", "WR12R8C16"} {
+		assert.True(t, ValidVariant(variant), variant)
+	}
+	for _, variant := range []string{"", "WR11C2R", "wr11c2r4", "WX11C2R4", "WR11C2R4 "} {
+		assert.False(t, ValidVariant(variant), variant)
+	}
+}
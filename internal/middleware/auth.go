@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -12,10 +13,111 @@ type contextKey string
 
 const AuthorizationPayloadKey contextKey = "authorization_payload"
 
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyVerifier 驗證 X-API-Key，找不到或已撤銷的 Key 回傳 token.ErrInvalidToken
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (*token.Payload, error)
+}
+
+// AuthOption 調整 AuthMiddleware 的行為
+type AuthOption func(*authOptions)
+
+type authOptions struct {
+	apiKeys     APIKeyVerifier
+	apiKeyScope string
+
+	signingKeys    SigningKeyResolver
+	nonces         NonceStore
+	signatureScope string
+}
+
+// WithAPIKeys 讓路由額外接受 X-API-Key，但只接受具備 scope 的 API Key
+func WithAPIKeys(verifier APIKeyVerifier, scope string) AuthOption {
+	return func(o *authOptions) {
+		o.apiKeys = verifier
+		o.apiKeyScope = scope
+	}
+}
+
+// WithSignedRequests 讓路由額外接受 HMAC 簽章請求 (X-Signature)，nonces 用來拒絕重放
+func WithSignedRequests(keys SigningKeyResolver, nonces NonceStore, scope string) AuthOption {
+	return func(o *authOptions) {
+		o.signingKeys = keys
+		o.nonces = nonces
+		o.signatureScope = scope
+	}
+}
+
 // AuthMiddleware 改為回傳一個 Closure，因為它需要依賴 tokenMaker
-func AuthMiddleware(tokenMaker token.Maker) func(http.Handler) http.Handler {
+// 驗證通過的 Token 還會再比對 revocations，已撤銷的 Token 一律拒絕
+func AuthMiddleware(tokenMaker token.Maker, revocations token.RevocationStore, opts ...AuthOption) func(http.Handler) http.Handler {
+	var options authOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// 0. API Key (X-API-Key) 只在明確開啟的路由上使用
+			if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" {
+				if options.apiKeys == nil {
+					http.Error(w, "API keys are not accepted for this endpoint", http.StatusUnauthorized)
+					return
+				}
+
+				payload, err := options.apiKeys.VerifyAPIKey(r.Context(), apiKey)
+				if err != nil {
+					if errors.Is(err, token.ErrInvalidToken) {
+						http.Error(w, "invalid API key", http.StatusUnauthorized)
+						return
+					}
+					http.Error(w, "failed to verify API key", http.StatusInternalServerError)
+					return
+				}
+
+				if !payload.HasScope(options.apiKeyScope) {
+					http.Error(w, "API key does not have the required scope", http.StatusForbidden)
+					return
+				}
+
+				ctx := context.WithValue(r.Context(), AuthorizationPayloadKey, payload)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			// 0.1 HMAC 簽章請求同樣只在明確開啟的路由上使用
+			if r.Header.Get(SignatureHeader) != "" {
+				if options.signingKeys == nil {
+					http.Error(w, "signed requests are not accepted for this endpoint", http.StatusUnauthorized)
+					return
+				}
+
+				payload, err := verifySignedRequest(r, options.signingKeys, options.nonces)
+				if err != nil {
+					switch {
+					case errors.Is(err, token.ErrInvalidToken):
+						http.Error(w, "invalid signing key", http.StatusUnauthorized)
+					case errors.Is(err, ErrInvalidSignature),
+						errors.Is(err, ErrStaleSignature),
+						errors.Is(err, ErrNonceReused):
+						http.Error(w, err.Error(), http.StatusUnauthorized)
+					default:
+						http.Error(w, "failed to verify request signature", http.StatusInternalServerError)
+					}
+					return
+				}
+
+				if !payload.HasScope(options.signatureScope) {
+					http.Error(w, "signing key does not have the required scope", http.StatusForbidden)
+					return
+				}
+
+				ctx := context.WithValue(r.Context(), AuthorizationPayloadKey, payload)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			// 1. 取得 Header
 			authorizationHeader := r.Header.Get("Authorization")
 			if len(authorizationHeader) == 0 {
@@ -45,7 +147,24 @@ func AuthMiddleware(tokenMaker token.Maker) func(http.Handler) http.Handler {
 				return
 			}
 
-			// 4. 將解析出來的 Payload (包含 username) 塞入 Context
+			// Refresh Token 只能用於 /tokens/refresh，不能當作 Access Token
+			if payload.TokenType == token.TokenTypeRefresh {
+				http.Error(w, "invalid token: refresh token cannot be used for authorization", http.StatusUnauthorized)
+				return
+			}
+
+			// 4. 檢查 Token 是否已被撤銷 (logout 或管理員撤銷)
+			revoked, err := revocations.IsRevoked(r.Context(), payload)
+			if err != nil {
+				http.Error(w, "failed to check token revocation", http.StatusInternalServerError)
+				return
+			}
+			if revoked {
+				http.Error(w, "invalid token: token has been revoked", http.StatusUnauthorized)
+				return
+			}
+
+			// 5. 將解析出來的 Payload (包含 username) 塞入 Context
 			ctx := context.WithValue(r.Context(), AuthorizationPayloadKey, payload)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
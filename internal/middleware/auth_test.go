@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -53,7 +54,7 @@ func TestAuthMiddleware_MockBehavior(t *testing.T) {
 
 	// 5. 執行 Middleware
 	// AuthMiddleware(mockTokenMaker) 會回傳一個包裝後的 Handler
-	AuthMiddleware(mockTokenMaker)(nextHandler).ServeHTTP(rr, req)
+	AuthMiddleware(mockTokenMaker, token.NewMemoryRevocationStore())(nextHandler).ServeHTTP(rr, req)
 
 	// 6. 驗證結果
 	assert.Equal(t, http.StatusOK, rr.Code)
@@ -65,3 +66,153 @@ func TestAuthMiddleware_MockBehavior(t *testing.T) {
 	// 驗證 mock 被正確呼叫
 	mockTokenMaker.AssertExpectations(t)
 }
+
+func TestAuthMiddleware_RejectsRefreshToken(t *testing.T) {
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("next handler should not be called with a refresh token")
+	})
+
+	mockTokenMaker := token_mocks.NewMaker(t)
+	mockTokenMaker.On("VerifyToken", "refresh_token_here").Return(&token.Payload{
+		Username:  "real-student-109704065",
+		TokenType: token.TokenTypeRefresh,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(time.Hour),
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/test", nil)
+	req.Header.Set("Authorization", "Bearer refresh_token_here")
+	rr := httptest.NewRecorder()
+
+	AuthMiddleware(mockTokenMaker, token.NewMemoryRevocationStore())(nextHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestAuthMiddleware_RejectsRevokedToken(t *testing.T) {
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("next handler should not be called with a revoked token")
+	})
+
+	payload, err := token.NewPayload("real-student-109704065", time.Hour)
+	assert.NoError(t, err)
+
+	revocations := token.NewMemoryRevocationStore()
+	assert.NoError(t, revocations.RevokeToken(context.Background(), payload.ID, payload.Username, payload.ExpiredAt))
+
+	mockTokenMaker := token_mocks.NewMaker(t)
+	mockTokenMaker.On("VerifyToken", "revoked_token").Return(payload, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/test", nil)
+	req.Header.Set("Authorization", "Bearer revoked_token")
+	rr := httptest.NewRecorder()
+
+	AuthMiddleware(mockTokenMaker, revocations)(nextHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestAuthMiddleware_RejectsTokensOfRevokedUser(t *testing.T) {
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("next handler should not be called with a revoked token")
+	})
+
+	payload, err := token.NewPayload("real-student-109704065", time.Hour)
+	assert.NoError(t, err)
+	payload.IssuedAt = time.Now().Add(-time.Minute)
+
+	revocations := token.NewMemoryRevocationStore()
+	assert.NoError(t, revocations.RevokeUserTokens(context.Background(), payload.Username, time.Now(), time.Now().Add(time.Hour)))
+
+	mockTokenMaker := token_mocks.NewMaker(t)
+	mockTokenMaker.On("VerifyToken", "old_token").Return(payload, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/test", nil)
+	req.Header.Set("Authorization", "Bearer old_token")
+	rr := httptest.NewRecorder()
+
+	AuthMiddleware(mockTokenMaker, revocations)(nextHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+type fakeAPIKeyVerifier map[string]*token.Payload
+
+func (f fakeAPIKeyVerifier) VerifyAPIKey(ctx context.Context, key string) (*token.Payload, error) {
+	payload, ok := f[key]
+	if !ok {
+		return nil, token.ErrInvalidToken
+	}
+	return payload, nil
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	verifier := fakeAPIKeyVerifier{
+		"hpl_submit": {
+			Username:  "batch-user",
+			TokenType: token.TokenTypeAPIKey,
+			Scopes:    []string{token.ScopeScoresSubmit},
+		},
+		"hpl_noscope": {
+			Username:  "batch-user",
+			TokenType: token.TokenTypeAPIKey,
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		apiKey         string
+		opts           []AuthOption
+		expectedStatus int
+	}{
+		{
+			name:           "key with required scope",
+			apiKey:         "hpl_submit",
+			opts:           []AuthOption{WithAPIKeys(verifier, token.ScopeScoresSubmit)},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "key without required scope",
+			apiKey:         "hpl_noscope",
+			opts:           []AuthOption{WithAPIKeys(verifier, token.ScopeScoresSubmit)},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "unknown key",
+			apiKey:         "hpl_unknown",
+			opts:           []AuthOption{WithAPIKeys(verifier, token.ScopeScoresSubmit)},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "route without API key support",
+			apiKey:         "hpl_submit",
+			opts:           nil,
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var capturedPayload *token.Payload
+			nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				capturedPayload, _ = r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+				w.WriteHeader(http.StatusOK)
+			})
+
+			// API Key 不經過 tokenMaker
+			mockTokenMaker := token_mocks.NewMaker(t)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/scores", nil)
+			req.Header.Set(APIKeyHeader, tc.apiKey)
+			rr := httptest.NewRecorder()
+
+			AuthMiddleware(mockTokenMaker, token.NewMemoryRevocationStore(), tc.opts...)(nextHandler).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "batch-user", capturedPayload.Username)
+				assert.Equal(t, token.TokenTypeAPIKey, capturedPayload.TokenType)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net/http"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+)
+
+const ClientIdentityKey contextKey = "client_identity"
+
+// Client identity kinds
+const (
+	ClientIdentityUser   = "user"
+	ClientIdentitySystem = "system"
+)
+
+// ErrUnknownClientCert 表示憑證已通過 CA 驗證，但沒有對應的身分
+var ErrUnknownClientCert = errors.New("client certificate is not mapped to an identity")
+
+// ClientIdentity is who a verified client certificate belongs to: a user, or
+// a system such as a submission node acting for Username
+type ClientIdentity struct {
+	Kind     string
+	Name     string
+	Username string
+}
+
+// String 是寫入成績紀錄的格式，例如 "user:alice" 或 "system:cluster-a-node01"
+func (identity *ClientIdentity) String() string {
+	return identity.Kind + ":" + identity.Name
+}
+
+// ClientCertResolver 將已驗證的用戶端憑證對應到身分，沒有對應時回傳 ErrUnknownClientCert
+type ClientCertResolver interface {
+	ResolveClientCert(cert *x509.Certificate) (*ClientIdentity, error)
+}
+
+// RequireClientCert 要求 TLS 交握時帶入經 CA 驗證的用戶端憑證，並以憑證的身分取代
+// Bearer Token：Context 會放入 ClientIdentity 以及 Username 為對應帳號的 Payload
+func RequireClientCert(resolver ClientCertResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// VerifiedChains 只有在 tls.Config 設定 ClientCAs 且驗證成功時才會有值
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+				http.Error(w, "client certificate required", http.StatusUnauthorized)
+				return
+			}
+
+			identity, err := resolver.ResolveClientCert(r.TLS.VerifiedChains[0][0])
+			if err != nil {
+				if errors.Is(err, ErrUnknownClientCert) {
+					http.Error(w, err.Error(), http.StatusForbidden)
+					return
+				}
+				http.Error(w, "failed to resolve client certificate", http.StatusInternalServerError)
+				return
+			}
+
+			payload := &token.Payload{
+				Username:  identity.Username,
+				TokenType: token.TokenTypeClientCert,
+				Scopes:    []string{token.ScopeScoresSubmit},
+			}
+
+			ctx := context.WithValue(r.Context(), ClientIdentityKey, identity)
+			ctx = context.WithValue(ctx, AuthorizationPayloadKey, payload)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
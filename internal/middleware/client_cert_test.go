@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA 是測試中臨時建立的 CA，用來簽發用戶端憑證
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// issue 簽發 template 描述的葉憑證
+func (ca *testCA) issue(t *testing.T, template *x509.Certificate) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Minute)
+	template.NotAfter = time.Now().Add(time.Hour)
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+type stubClientCertResolver map[string]*ClientIdentity
+
+func (s stubClientCertResolver) ResolveClientCert(cert *x509.Certificate) (*ClientIdentity, error) {
+	identity, ok := s[cert.Subject.CommonName]
+	if !ok {
+		return nil, ErrUnknownClientCert
+	}
+	return identity, nil
+}
+
+func TestRequireClientCert(t *testing.T) {
+	ca := newTestCA(t, "cluster-a-ca")
+	otherCA := newTestCA(t, "untrusted-ca")
+
+	resolver := stubClientCertResolver{
+		"node01.cluster-a": {Kind: ClientIdentitySystem, Name: "cluster-a-node01", Username: "team-a"},
+	}
+
+	var capturedIdentity *ClientIdentity
+	var capturedPayload *token.Payload
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedIdentity, _ = r.Context().Value(ClientIdentityKey).(*ClientIdentity)
+		capturedPayload, _ = r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := httptest.NewUnstartedServer(RequireClientCert(resolver)(next))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	server.TLS = &tls.Config{
+		ClientCAs:  clientCAs,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		transport := server.Client().Transport.(*http.Transport).Clone()
+		transport.TLSClientConfig.Certificates = certs
+		return &http.Client{Transport: transport}
+	}
+
+	t.Run("mapped certificate", func(t *testing.T) {
+		capturedIdentity, capturedPayload = nil, nil
+		cert := ca.issue(t, &x509.Certificate{
+			Subject:     pkix.Name{CommonName: "node01.cluster-a"},
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+
+		resp, err := newClient(cert).Post(server.URL, "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NotNil(t, capturedIdentity)
+		assert.Equal(t, "system:cluster-a-node01", capturedIdentity.String())
+		require.NotNil(t, capturedPayload)
+		assert.Equal(t, "team-a", capturedPayload.Username)
+		assert.Equal(t, token.TokenTypeClientCert, capturedPayload.TokenType)
+		assert.True(t, capturedPayload.HasScope(token.ScopeScoresSubmit))
+	})
+
+	t.Run("no certificate", func(t *testing.T) {
+		resp, err := newClient().Post(server.URL, "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("unmapped certificate", func(t *testing.T) {
+		cert := ca.issue(t, &x509.Certificate{
+			Subject:     pkix.Name{CommonName: "node99.cluster-a"},
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+
+		resp, err := newClient(cert).Post(server.URL, "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("certificate from another CA", func(t *testing.T) {
+		cert := otherCA.issue(t, &x509.Certificate{
+			Subject:     pkix.Name{CommonName: "node01.cluster-a"},
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+
+		// 伺服器只列出信任的 CA，用戶端因此不會送出這張憑證，視同沒有憑證
+		resp, err := newClient(cert).Post(server.URL, "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("plain HTTP request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/scores", nil)
+		rr := httptest.NewRecorder()
+		RequireClientCert(resolver)(next).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
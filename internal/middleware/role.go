@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+)
+
+// RequireRole 只允許具備任一指定角色的使用者通過，必須串在 AuthMiddleware 之後
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			payload, ok := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+			if !ok {
+				http.Error(w, "missing authorization payload", http.StatusUnauthorized)
+				return
+			}
+
+			for _, role := range roles {
+				if payload.HasRole(role) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			http.Error(w, "insufficient privileges", http.StatusForbidden)
+		})
+	}
+}
+
+// RequireMFA 只允許登入時通過第二因素驗證的 Token，必須串在 AuthMiddleware 之後
+func RequireMFA() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			payload, ok := r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+			if !ok {
+				http.Error(w, "missing authorization payload", http.StatusUnauthorized)
+				return
+			}
+
+			if !payload.MFA {
+				http.Error(w, "multi-factor authentication required", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireRole(t *testing.T) {
+	testCases := []struct {
+		name           string
+		payload        *token.Payload
+		roles          []string
+		expectedStatus int
+	}{
+		{
+			name:           "admin user",
+			payload:        &token.Payload{Username: "judge-lead", Roles: []string{token.RoleAdmin}, ExpiredAt: time.Now().Add(time.Hour)},
+			roles:          []string{token.RoleAdmin},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "judge allowed on judge route",
+			payload:        &token.Payload{Username: "judge", Roles: []string{token.RoleParticipant, token.RoleJudge}, ExpiredAt: time.Now().Add(time.Hour)},
+			roles:          []string{token.RoleJudge, token.RoleAdmin},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "judge rejected on admin route",
+			payload:        &token.Payload{Username: "judge", Roles: []string{token.RoleJudge}, ExpiredAt: time.Now().Add(time.Hour)},
+			roles:          []string{token.RoleAdmin},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "participant",
+			payload:        &token.Payload{Username: "student", Roles: []string{token.RoleParticipant}, ExpiredAt: time.Now().Add(time.Hour)},
+			roles:          []string{token.RoleAdmin},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "token without roles",
+			payload:        &token.Payload{Username: "student", ExpiredAt: time.Now().Add(time.Hour)},
+			roles:          []string{token.RoleAdmin},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "missing payload",
+			payload:        nil,
+			roles:          []string{token.RoleAdmin},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/test", nil)
+			if tc.payload != nil {
+				req = req.WithContext(context.WithValue(req.Context(), AuthorizationPayloadKey, tc.payload))
+			}
+			rr := httptest.NewRecorder()
+
+			RequireRole(tc.roles...)(nextHandler).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestRequireMFA(t *testing.T) {
+	testCases := []struct {
+		name           string
+		payload        *token.Payload
+		expectedStatus int
+	}{
+		{
+			name:           "token with MFA",
+			payload:        &token.Payload{Username: "judge", Roles: []string{token.RoleJudge}, MFA: true, ExpiredAt: time.Now().Add(time.Hour)},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "token without MFA",
+			payload:        &token.Payload{Username: "judge", Roles: []string{token.RoleJudge}, ExpiredAt: time.Now().Add(time.Hour)},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "missing payload",
+			payload:        nil,
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/test", nil)
+			if tc.payload != nil {
+				req = req.WithContext(context.WithValue(req.Context(), AuthorizationPayloadKey, tc.payload))
+			}
+			rr := httptest.NewRecorder()
+
+			RequireRole(token.RoleJudge)(RequireMFA()(nextHandler)).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+		})
+	}
+}
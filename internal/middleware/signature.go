@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+)
+
+// Request signing headers
+const (
+	SignatureKeyIDHeader     = "X-Signature-Key-Id"
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+	SignatureNonceHeader     = "X-Signature-Nonce"
+	SignatureHeader          = "X-Signature"
+)
+
+const (
+	// SignatureMaxSkew 是簽章時間戳記與伺服器時間允許的最大差距
+	SignatureMaxSkew = 5 * time.Minute
+
+	minNonceLength     = 16
+	maxNonceLength     = 128
+	maxSignedBodyBytes = 1 << 20
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid request signature")
+	ErrStaleSignature   = errors.New("request signature timestamp is outside the allowed window")
+	ErrNonceReused      = errors.New("request signature nonce has already been used")
+)
+
+// SigningKeyResolver 依 key id 取得 HMAC 共享密鑰與對應的 Payload，
+// 找不到或已撤銷的 key 回傳 token.ErrInvalidToken
+type SigningKeyResolver interface {
+	ResolveSigningKey(ctx context.Context, keyID string) ([]byte, *token.Payload, error)
+}
+
+// NonceStore 記錄用過的 nonce，同一個 key 的 nonce 在 expiresAt 前只能使用一次
+type NonceStore interface {
+	// UseNonce records nonce for keyID and reports false if it was already used
+	UseNonce(ctx context.Context, keyID string, nonce string, expiresAt time.Time) (bool, error)
+	// DeleteExpired removes nonces whose timestamps can no longer pass the skew check
+	DeleteExpired(ctx context.Context) error
+}
+
+// StringToSign 是簽章涵蓋的內容，每個欄位一行：
+// method、path、timestamp (Unix 秒)、nonce、body 的 SHA-256 (hex)
+func StringToSign(method string, path string, timestamp int64, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		strconv.FormatInt(timestamp, 10),
+		nonce,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of stringToSign
+func Sign(secret []byte, stringToSign string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(stringToSign))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifySignedRequest 檢查簽章標頭並回傳 key 對應的 Payload。
+// body 讀取後會放回 r.Body，後面的 handler 可以照常解析。
+func verifySignedRequest(r *http.Request, keys SigningKeyResolver, nonces NonceStore) (*token.Payload, error) {
+	keyID := r.Header.Get(SignatureKeyIDHeader)
+	nonce := r.Header.Get(SignatureNonceHeader)
+	signature := r.Header.Get(SignatureHeader)
+	if keyID == "" || nonce == "" || signature == "" {
+		return nil, fmt.Errorf("%w: %s, %s, %s and %s are required", ErrInvalidSignature,
+			SignatureKeyIDHeader, SignatureTimestampHeader, SignatureNonceHeader, SignatureHeader)
+	}
+	if len(nonce) < minNonceLength || len(nonce) > maxNonceLength {
+		return nil, fmt.Errorf("%w: nonce must be %d to %d characters", ErrInvalidSignature, minNonceLength, maxNonceLength)
+	}
+
+	timestamp, err := strconv.ParseInt(r.Header.Get(SignatureTimestampHeader), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: timestamp must be Unix seconds", ErrInvalidSignature)
+	}
+	signedAt := time.Unix(timestamp, 0)
+	if skew := time.Since(signedAt); skew > SignatureMaxSkew || skew < -SignatureMaxSkew {
+		return nil, ErrStaleSignature
+	}
+
+	secret, payload, err := keys.ResolveSigningKey(r.Context(), keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSignedBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxSignedBodyBytes {
+		return nil, fmt.Errorf("%w: body is too large", ErrInvalidSignature)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	expected := Sign(secret, StringToSign(r.Method, r.URL.EscapedPath(), timestamp, nonce, body))
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return nil, ErrInvalidSignature
+	}
+
+	// 簽章正確後才記錄 nonce，避免他人以偽造的請求消耗 nonce
+	fresh, err := nonces.UseNonce(r.Context(), keyID, nonce, signedAt.Add(SignatureMaxSkew))
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, ErrNonceReused
+	}
+	return payload, nil
+}
+
+// StartNonceCleanup calls DeleteExpired on every tick until ctx is done
+func StartNonceCleanup(ctx context.Context, store NonceStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := store.DeleteExpired(ctx); err != nil {
+					log.Printf("failed to delete expired request nonces: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// MemoryNonceStore is an in-process NonceStore for single-instance deployments and tests
+type MemoryNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+}
+
+// NewMemoryNonceStore creates an empty MemoryNonceStore
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{nonces: make(map[string]time.Time)}
+}
+
+func (store *MemoryNonceStore) UseNonce(ctx context.Context, keyID string, nonce string, expiresAt time.Time) (bool, error) {
+	key := keyID + "\x00" + nonce
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if _, used := store.nonces[key]; used {
+		return false, nil
+	}
+	store.nonces[key] = expiresAt
+	return true, nil
+}
+
+func (store *MemoryNonceStore) DeleteExpired(ctx context.Context) error {
+	now := time.Now()
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for key, expiresAt := range store.nonces {
+		if expiresAt.Before(now) {
+			delete(store.nonces, key)
+		}
+	}
+	return nil
+}
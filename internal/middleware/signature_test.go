@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+	token_mocks "github.com/kdotwei/hpl-scoreboard/internal/token/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSigningKeyID = "0b6f3b9e-6a3e-4d1c-9a51-3f7e2d8c1b00"
+	testNonce        = "c2b1f0e4a7d94e0f"
+)
+
+var testSigningSecret = []byte("hpls_test-secret")
+
+type stubSigningKeyResolver struct{}
+
+func (stubSigningKeyResolver) ResolveSigningKey(ctx context.Context, keyID string) ([]byte, *token.Payload, error) {
+	if keyID != testSigningKeyID {
+		return nil, nil, token.ErrInvalidToken
+	}
+	return testSigningSecret, &token.Payload{
+		Username:  "agent-lead",
+		TokenType: token.TokenTypeSigningKey,
+		Scopes:    []string{token.ScopeScoresSubmit},
+	}, nil
+}
+
+// signedRequest 建立帶有簽章標頭的請求，signPath 與 signBody 可以和實際送出的不同
+func signedRequest(keyID string, timestamp time.Time, nonce string, signPath string, signBody string, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scores", strings.NewReader(body))
+	req.Header.Set(SignatureKeyIDHeader, keyID)
+	req.Header.Set(SignatureTimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(SignatureNonceHeader, nonce)
+	req.Header.Set(SignatureHeader, Sign(testSigningSecret, StringToSign(http.MethodPost, signPath, timestamp.Unix(), nonce, []byte(signBody))))
+	return req
+}
+
+func TestSignedRequests(t *testing.T) {
+	body := `{"gflops": 123.45}`
+
+	testCases := []struct {
+		name           string
+		request        func() *http.Request
+		expectedStatus int
+	}{
+		{
+			name: "valid signature",
+			request: func() *http.Request {
+				return signedRequest(testSigningKeyID, time.Now(), testNonce, "/api/v1/scores", body, body)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "tampered body",
+			request: func() *http.Request {
+				return signedRequest(testSigningKeyID, time.Now(), testNonce, "/api/v1/scores", body, `{"gflops": 99999}`)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "signed for another path",
+			request: func() *http.Request {
+				return signedRequest(testSigningKeyID, time.Now(), testNonce, "/api/v1/api-keys", body, body)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "stale timestamp",
+			request: func() *http.Request {
+				return signedRequest(testSigningKeyID, time.Now().Add(-SignatureMaxSkew-time.Minute), testNonce, "/api/v1/scores", body, body)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "timestamp in the future",
+			request: func() *http.Request {
+				return signedRequest(testSigningKeyID, time.Now().Add(SignatureMaxSkew+time.Minute), testNonce, "/api/v1/scores", body, body)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "short nonce",
+			request: func() *http.Request {
+				return signedRequest(testSigningKeyID, time.Now(), "abc", "/api/v1/scores", body, body)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "unknown key",
+			request: func() *http.Request {
+				return signedRequest("3c1d9a6e-0000-4000-8000-000000000000", time.Now(), testNonce, "/api/v1/scores", body, body)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "missing timestamp",
+			request: func() *http.Request {
+				req := signedRequest(testSigningKeyID, time.Now(), testNonce, "/api/v1/scores", body, body)
+				req.Header.Del(SignatureTimestampHeader)
+				return req
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var capturedBody string
+			var capturedPayload *token.Payload
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				capturedBody = string(data)
+				capturedPayload, _ = r.Context().Value(AuthorizationPayloadKey).(*token.Payload)
+				w.WriteHeader(http.StatusOK)
+			})
+
+			authMiddleware := AuthMiddleware(token_mocks.NewMaker(t), token.NewMemoryRevocationStore(),
+				WithSignedRequests(stubSigningKeyResolver{}, NewMemoryNonceStore(), token.ScopeScoresSubmit))
+
+			rr := httptest.NewRecorder()
+			authMiddleware(next).ServeHTTP(rr, tc.request())
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				// handler 仍然可以讀到完整的 body
+				assert.Equal(t, body, capturedBody)
+				require.NotNil(t, capturedPayload)
+				assert.Equal(t, "agent-lead", capturedPayload.Username)
+			}
+		})
+	}
+}
+
+func TestSignedRequests_ReusedNonce(t *testing.T) {
+	body := `{"gflops": 123.45}`
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	authMiddleware := AuthMiddleware(token_mocks.NewMaker(t), token.NewMemoryRevocationStore(),
+		WithSignedRequests(stubSigningKeyResolver{}, NewMemoryNonceStore(), token.ScopeScoresSubmit))
+
+	signedAt := time.Now()
+	rr := httptest.NewRecorder()
+	authMiddleware(next).ServeHTTP(rr, signedRequest(testSigningKeyID, signedAt, testNonce, "/api/v1/scores", body, body))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// 原封不動重送同一個請求
+	rr = httptest.NewRecorder()
+	authMiddleware(next).ServeHTTP(rr, signedRequest(testSigningKeyID, signedAt, testNonce, "/api/v1/scores", body, body))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Contains(t, rr.Body.String(), "nonce")
+}
+
+func TestSignedRequests_NotEnabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rr := httptest.NewRecorder()
+	AuthMiddleware(token_mocks.NewMaker(t), token.NewMemoryRevocationStore())(next).
+		ServeHTTP(rr, signedRequest(testSigningKeyID, time.Now(), testNonce, "/api/v1/scores", "", ""))
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestMemoryNonceStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryNonceStore()
+
+	fresh, err := store.UseNonce(ctx, "key-1", testNonce, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	assert.True(t, fresh)
+
+	fresh, err = store.UseNonce(ctx, "key-1", testNonce, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, fresh)
+
+	// 不同的 key 各自計算
+	fresh, err = store.UseNonce(ctx, "key-2", testNonce, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, fresh)
+
+	require.NoError(t, store.DeleteExpired(ctx))
+	fresh, err = store.UseNonce(ctx, "key-1", testNonce, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, fresh)
+}
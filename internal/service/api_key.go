@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+)
+
+const (
+	apiKeyPrefix        = "hpl_"
+	apiKeyRandomBytes   = 32
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrInvalidScope   = errors.New("invalid scope")
+)
+
+var validScopes = map[string]bool{
+	token.ScopeScoresSubmit: true,
+}
+
+// hashAPIKey 使用 SHA-256 而非 bcrypt：API Key 是 256 bit 亂數，不需要抗暴力破解的
+// 慢雜湊，而且固定的雜湊值才能直接用來查詢資料庫
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIKey generates a new API key for arg.Username. The plaintext key is
+// returned only here; the database keeps its hash.
+func (s *HPLService) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (string, *db.ApiKey, error) {
+	if len(arg.Scopes) == 0 {
+		return "", nil, fmt.Errorf("%w: at least one scope is required", ErrInvalidScope)
+	}
+	for _, scope := range arg.Scopes {
+		if !validScopes[scope] {
+			return "", nil, fmt.Errorf("%w: %q", ErrInvalidScope, scope)
+		}
+	}
+
+	random := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", nil, err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	apiKey, err := s.store.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		Username:  arg.Username,
+		Name:      arg.Name,
+		Prefix:    key[:apiKeyDisplayLength],
+		HashedKey: hashAPIKey(key),
+		Scopes:    arg.Scopes,
+		ExpiresAt: pgtype.Timestamptz{Time: arg.ExpiresAt, Valid: !arg.ExpiresAt.IsZero()},
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return key, &apiKey, nil
+}
+
+func (s *HPLService) ListAPIKeys(ctx context.Context, username string) ([]db.ApiKey, error) {
+	return s.store.ListAPIKeys(ctx, username)
+}
+
+// RevokeAPIKey revokes one of username's API keys
+func (s *HPLService) RevokeAPIKey(ctx context.Context, id uuid.UUID, username string) error {
+	rows, err := s.store.RevokeAPIKey(ctx, db.RevokeAPIKeyParams{
+		ID:       pgtype.UUID{Bytes: id, Valid: true},
+		Username: username,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// VerifyAPIKey resolves an X-API-Key value to a payload carrying the key's
+// scopes. Unknown, revoked and expired keys yield token.ErrInvalidToken.
+func (s *HPLService) VerifyAPIKey(ctx context.Context, key string) (*token.Payload, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, token.ErrInvalidToken
+	}
+
+	apiKey, err := s.store.UseAPIKey(ctx, hashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, token.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	// API Key 不帶角色，只能存取以 WithAPIKeys 明確開放的路由
+	payload := &token.Payload{
+		ID:        apiKey.ID.Bytes,
+		Username:  apiKey.Username,
+		TokenType: token.TokenTypeAPIKey,
+		Scopes:    apiKey.Scopes,
+		IssuedAt:  apiKey.CreatedAt,
+	}
+	if apiKey.ExpiresAt.Valid {
+		payload.ExpiredAt = apiKey.ExpiresAt.Time
+	}
+	return payload, nil
+}
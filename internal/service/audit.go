@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+)
+
+// Audit event names
+const (
+	AuditEventLoginLockout        = "login_lockout"
+	AuditEventAccountUnlocked     = "account_unlocked"
+	AuditEventMFAEnabled          = "mfa_enabled"
+	AuditEventMFADisabled         = "mfa_disabled"
+	AuditEventMFARecoveryCodeUsed = "mfa_recovery_code_used"
+	AuditEventLinuxAccountRemoved = "linux_account_removed"
+)
+
+func (s *HPLService) ListAuditEvents(ctx context.Context, limit int32, offset int32) ([]db.AuditEvent, error) {
+	return s.store.ListAuditEvents(ctx, db.ListAuditEventsParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Benchmarks a score can be submitted for. HPL-MxP factorizes in lower
+// precision and refines to FP64 accuracy, so its gflops is not bounded by the
+// FP64 Rpeak.
+const (
+	BenchmarkHPL    = "hpl"
+	BenchmarkHPLMxP = "hpl-mxp"
+	BenchmarkHPCG   = "hpcg"
+	BenchmarkStream = "stream"
+)
+
+// maxHPCGDim 是 HPCG local grid 每個維度的上限，一般的執行在 100 到 300 之間
+const maxHPCGDim = 1 << 12
+
+var (
+	ErrInvalidBenchmark = errors.New("benchmark must be hpl, hpl-mxp, hpcg or stream")
+	ErrInvalidMetrics   = errors.New("invalid benchmark metrics")
+)
+
+// mxpPrecisions 是 HPL-MxP 可用的 LU 分解精度
+var mxpPrecisions = map[string]bool{"fp32": true, "tf32": true, "fp16": true, "bf16": true, "fp8": true}
+
+// ValidBenchmark reports whether benchmark is one of the Benchmark values
+func ValidBenchmark(benchmark string) bool {
+	switch benchmark {
+	case BenchmarkHPL, BenchmarkHPLMxP, BenchmarkHPCG, BenchmarkStream:
+		return true
+	}
+	return false
+}
+
+// isHPLFamily 回傳 benchmark 是否解 N × N 的稠密線性系統，
+// 這類成績使用 P × Q process grid、(2/3·n³ + 2·n²) 的 GFLOPS 公式與 residual check
+func isHPLFamily(benchmark string) bool {
+	return benchmark == BenchmarkHPL || benchmark == BenchmarkHPLMxP
+}
+
+// checkBenchmarkMetrics 確認成績只帶有所屬 benchmark 的指標，且這些指標合理
+func checkBenchmarkMetrics(arg CreateScoreParams) error {
+	hasHPCG := arg.HPCGNx != 0 || arg.HPCGNy != 0 || arg.HPCGNz != 0
+	hasStream := arg.StreamCopyMBs != 0 || arg.StreamScaleMBs != 0 || arg.StreamAddMBs != 0 || arg.StreamTriadMBs != 0
+	hasMxP := arg.MxPPrecision != ""
+
+	switch {
+	case hasHPCG && arg.Benchmark != BenchmarkHPCG:
+		return fmt.Errorf("%w: hpcg_nx, hpcg_ny and hpcg_nz are only for hpcg", ErrInvalidMetrics)
+	case hasStream && arg.Benchmark != BenchmarkStream:
+		return fmt.Errorf("%w: stream bandwidths are only for stream", ErrInvalidMetrics)
+	case hasMxP && arg.Benchmark != BenchmarkHPLMxP:
+		return fmt.Errorf("%w: mxp_precision is only for hpl-mxp", ErrInvalidMetrics)
+	}
+
+	switch arg.Benchmark {
+	case BenchmarkHPLMxP:
+		if !mxpPrecisions[arg.MxPPrecision] {
+			return fmt.Errorf("%w: mxp_precision must be fp32, tf32, fp16, bf16 or fp8", ErrInvalidMetrics)
+		}
+	case BenchmarkHPCG:
+		if !isFinite(arg.Gflops) || arg.Gflops <= 0 {
+			return fmt.Errorf("%w: gflops must be the positive HPCG rating", ErrInvalidMetrics)
+		}
+		// HPCG 要求 local grid 的每個維度都是 8 的倍數
+		for _, dim := range []int{arg.HPCGNx, arg.HPCGNy, arg.HPCGNz} {
+			if dim <= 0 || dim > maxHPCGDim || dim%8 != 0 {
+				return fmt.Errorf("%w: hpcg_nx, hpcg_ny and hpcg_nz must be multiples of 8 between 8 and %d", ErrInvalidMetrics, maxHPCGDim)
+			}
+		}
+	case BenchmarkStream:
+		if arg.Gflops != 0 {
+			return fmt.Errorf("%w: stream reports bandwidth, gflops must be 0", ErrInvalidMetrics)
+		}
+		for _, bandwidth := range []float64{arg.StreamCopyMBs, arg.StreamScaleMBs, arg.StreamAddMBs, arg.StreamTriadMBs} {
+			if !isFinite(bandwidth) || bandwidth <= 0 {
+				return fmt.Errorf("%w: stream_copy_mbs, stream_scale_mbs, stream_add_mbs and stream_triad_mbs must be positive", ErrInvalidMetrics)
+			}
+		}
+	}
+	return nil
+}
+
+// streamBandwidth 將沒有回報的頻寬 (0) 存為 NULL
+func streamBandwidth(mbs float64) pgtype.Float8 {
+	return pgtype.Float8{Float64: mbs, Valid: mbs != 0}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsHPLFamily(t *testing.T) {
+	testCases := []struct {
+		benchmark string
+		expected  bool
+	}{
+		{benchmark: BenchmarkHPL, expected: true},
+		{benchmark: BenchmarkHPLMxP, expected: true},
+		{benchmark: BenchmarkHPCG, expected: false},
+		{benchmark: BenchmarkStream, expected: false},
+		{benchmark: "", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.benchmark, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isHPLFamily(tc.benchmark))
+		})
+	}
+}
+
+func TestCheckBenchmarkMetrics(t *testing.T) {
+	stream := func(copyMBs, scaleMBs, addMBs, triadMBs float64) CreateScoreParams {
+		return CreateScoreParams{
+			Benchmark:      BenchmarkStream,
+			StreamCopyMBs:  copyMBs,
+			StreamScaleMBs: scaleMBs,
+			StreamAddMBs:   addMBs,
+			StreamTriadMBs: triadMBs,
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		arg     CreateScoreParams
+		wantErr bool
+	}{
+		{name: "hpl", arg: CreateScoreParams{Benchmark: BenchmarkHPL, Gflops: 730}},
+		{name: "hpl with hpcg grid", arg: CreateScoreParams{Benchmark: BenchmarkHPL, HPCGNx: 104}, wantErr: true},
+		{name: "hpl with stream bandwidth", arg: CreateScoreParams{Benchmark: BenchmarkHPL, StreamTriadMBs: 1}, wantErr: true},
+		{name: "hpl with mxp precision", arg: CreateScoreParams{Benchmark: BenchmarkHPL, MxPPrecision: "fp16"}, wantErr: true},
+
+		{name: "hpl-mxp fp16", arg: CreateScoreParams{Benchmark: BenchmarkHPLMxP, MxPPrecision: "fp16"}},
+		{name: "hpl-mxp fp8", arg: CreateScoreParams{Benchmark: BenchmarkHPLMxP, MxPPrecision: "fp8"}},
+		{name: "hpl-mxp without precision", arg: CreateScoreParams{Benchmark: BenchmarkHPLMxP}, wantErr: true},
+		{name: "hpl-mxp fp64", arg: CreateScoreParams{Benchmark: BenchmarkHPLMxP, MxPPrecision: "fp64"}, wantErr: true},
+
+		{name: "hpcg", arg: CreateScoreParams{Benchmark: BenchmarkHPCG, Gflops: 20, HPCGNx: 104, HPCGNy: 104, HPCGNz: 104}},
+		{name: "hpcg largest grid", arg: CreateScoreParams{Benchmark: BenchmarkHPCG, Gflops: 20, HPCGNx: maxHPCGDim, HPCGNy: 8, HPCGNz: 8}},
+		{name: "hpcg dimension not a multiple of 8", arg: CreateScoreParams{Benchmark: BenchmarkHPCG, Gflops: 20, HPCGNx: 100, HPCGNy: 104, HPCGNz: 104}, wantErr: true},
+		{name: "hpcg missing dimension", arg: CreateScoreParams{Benchmark: BenchmarkHPCG, Gflops: 20, HPCGNx: 104, HPCGNy: 104}, wantErr: true},
+		{name: "hpcg negative dimension", arg: CreateScoreParams{Benchmark: BenchmarkHPCG, Gflops: 20, HPCGNx: -104, HPCGNy: 104, HPCGNz: 104}, wantErr: true},
+		{name: "hpcg dimension above limit", arg: CreateScoreParams{Benchmark: BenchmarkHPCG, Gflops: 20, HPCGNx: maxHPCGDim + 8, HPCGNy: 104, HPCGNz: 104}, wantErr: true},
+		{name: "hpcg without rating", arg: CreateScoreParams{Benchmark: BenchmarkHPCG, HPCGNx: 104, HPCGNy: 104, HPCGNz: 104}, wantErr: true},
+		{name: "hpcg with mxp precision", arg: CreateScoreParams{Benchmark: BenchmarkHPCG, Gflops: 20, HPCGNx: 104, HPCGNy: 104, HPCGNz: 104, MxPPrecision: "fp16"}, wantErr: true},
+
+		{name: "stream", arg: stream(12000, 11800, 12900, 13000)},
+		{name: "stream missing triad", arg: stream(12000, 11800, 12900, 0), wantErr: true},
+		{name: "stream negative bandwidth", arg: stream(-12000, 11800, 12900, 13000), wantErr: true},
+		{name: "stream infinite bandwidth", arg: stream(12000, math.Inf(1), 12900, 13000), wantErr: true},
+		{
+			name: "stream with gflops",
+			arg: CreateScoreParams{
+				Benchmark: BenchmarkStream, Gflops: 1,
+				StreamCopyMBs: 12000, StreamScaleMBs: 11800, StreamAddMBs: 12900, StreamTriadMBs: 13000,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkBenchmarkMetrics(tc.arg)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidMetrics)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckBenchmarkGflops(t *testing.T) {
+	// gflops 與 n、execution_time 完全不符，只有 HPL 類的 benchmark 會被拒絕
+	testCases := []struct {
+		benchmark string
+		wantErr   bool
+	}{
+		{benchmark: BenchmarkHPL, wantErr: true},
+		{benchmark: BenchmarkHPLMxP, wantErr: true},
+		{benchmark: BenchmarkHPCG},
+		{benchmark: BenchmarkStream},
+	}
+
+	s := NewService(nil, nil)
+	for _, tc := range testCases {
+		t.Run(tc.benchmark, func(t *testing.T) {
+			expected, err := s.checkBenchmarkGflops(CreateScoreParams{
+				Benchmark:     tc.benchmark,
+				Gflops:        20,
+				N:             40000,
+				ExecutionTime: 58.41,
+			})
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrGflopsMismatch)
+				return
+			}
+			require.NoError(t, err)
+			assert.Zero(t, expected)
+		})
+	}
+}
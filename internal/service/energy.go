@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+)
+
+// energyTolerance 是 energy_joules 與 avg_power_watts × execution_time 之間允許的相對誤差。
+// 功率計的取樣區間通常比 HPL 的計時多出幾秒。
+const energyTolerance = 0.05
+
+var ErrInvalidEnergy = errors.New("invalid avg_power_watts or energy_joules")
+
+// WithGreenMinGflops 設定 green 排行榜的最低效能門檻 (GFLOPS)，
+// 如同 Green500 只收錄達到 TOP500 門檻的系統
+func WithGreenMinGflops(minGflops float64) Option {
+	return func(s *HPLService) {
+		s.greenMinGflops = minGflops
+	}
+}
+
+// energyCheck 驗證功耗欄位並回傳要存入的平均功耗與耗能。
+// 只回報 energy_joules 時，平均功耗由 energy_joules / execution_time 推算。
+func energyCheck(arg CreateScoreParams) (pgtype.Float8, pgtype.Float8, error) {
+	var power, energy pgtype.Float8
+	if arg.AvgPowerWatts != nil {
+		if !isFinite(*arg.AvgPowerWatts) || *arg.AvgPowerWatts <= 0 {
+			return power, energy, fmt.Errorf("%w: avg_power_watts must be positive", ErrInvalidEnergy)
+		}
+		power = pgtype.Float8{Float64: *arg.AvgPowerWatts, Valid: true}
+	}
+	if arg.EnergyJoules != nil {
+		if !isFinite(*arg.EnergyJoules) || *arg.EnergyJoules <= 0 {
+			return power, energy, fmt.Errorf("%w: energy_joules must be positive", ErrInvalidEnergy)
+		}
+		energy = pgtype.Float8{Float64: *arg.EnergyJoules, Valid: true}
+	}
+
+	switch {
+	case power.Valid && energy.Valid:
+		expected := power.Float64 * arg.ExecutionTime
+		if math.Abs(energy.Float64-expected) > energyTolerance*expected {
+			return power, energy, fmt.Errorf("%w: energy_joules = %.6g, but avg_power_watts × execution_time = %.6g (tolerance %g%%)",
+				ErrInvalidEnergy, energy.Float64, expected, energyTolerance*100)
+		}
+	case energy.Valid:
+		power = pgtype.Float8{Float64: energy.Float64 / arg.ExecutionTime, Valid: true}
+	}
+	return power, energy, nil
+}
+
+// ListGreenLeaderboard ranks scores that reported power by GFLOPS per watt.
+// Scores below the minimum performance are left out the way the Green500 only
+// ranks TOP500 systems; params.MinGflops can raise the configured minimum but
+// not lower it.
+func (s *HPLService) ListGreenLeaderboard(ctx context.Context, params ListScoresParams) (*PaginatedScoresResponse, error) {
+	minGflops := max(params.MinGflops, s.greenMinGflops)
+
+	scores, err := s.store.ListGreenScores(ctx, db.ListGreenScoresParams{
+		MinGflops:      minGflops,
+		ResidualFilter: params.Residual,
+		Limit:          params.Limit,
+		Offset:         params.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalRecords, err := s.store.CountGreenScores(ctx, db.CountGreenScoresParams{
+		MinGflops:      minGflops,
+		ResidualFilter: params.Residual,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedScoresResponse{
+		Scores:       scores,
+		HasMore:      int64(params.Offset+int32(len(scores))) < totalRecords,
+		TotalRecords: totalRecords,
+		Limit:        params.Limit,
+		Offset:       params.Offset,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnergyCheck(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	none := pgtype.Float8{}
+
+	testCases := []struct {
+		name       string
+		power      *float64
+		energy     *float64
+		wantErr    bool
+		wantPower  pgtype.Float8
+		wantEnergy pgtype.Float8
+	}{
+		{name: "neither", wantPower: none, wantEnergy: none},
+		{
+			name:       "power only",
+			power:      ptr(2000),
+			wantPower:  pgtype.Float8{Float64: 2000, Valid: true},
+			wantEnergy: none,
+		},
+		{
+			// 100 秒內 200 kJ，平均 2000 W
+			name:       "energy only derives power",
+			energy:     ptr(200000),
+			wantPower:  pgtype.Float8{Float64: 2000, Valid: true},
+			wantEnergy: pgtype.Float8{Float64: 200000, Valid: true},
+		},
+		{
+			name:       "both agree",
+			power:      ptr(2000),
+			energy:     ptr(200000),
+			wantPower:  pgtype.Float8{Float64: 2000, Valid: true},
+			wantEnergy: pgtype.Float8{Float64: 200000, Valid: true},
+		},
+		{
+			name:       "both agree within tolerance",
+			power:      ptr(2000),
+			energy:     ptr(209000),
+			wantPower:  pgtype.Float8{Float64: 2000, Valid: true},
+			wantEnergy: pgtype.Float8{Float64: 209000, Valid: true},
+		},
+		{name: "both disagree", power: ptr(2000), energy: ptr(211000), wantErr: true},
+		{name: "negative power", power: ptr(-2000), wantErr: true},
+		{name: "zero power", power: ptr(0), wantErr: true},
+		{name: "negative energy", energy: ptr(-200000), wantErr: true},
+		{name: "infinite energy", energy: ptr(math.Inf(1)), wantErr: true},
+		{name: "nan power", power: ptr(math.NaN()), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			power, energy, err := energyCheck(CreateScoreParams{
+				ExecutionTime: 100,
+				AvgPowerWatts: tc.power,
+				EnergyJoules:  tc.energy,
+			})
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrInvalidEnergy)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantPower, power)
+			assert.Equal(t, tc.wantEnergy, energy)
+		})
+	}
+}
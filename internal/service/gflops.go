@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+)
+
+// DefaultGflopsTolerance 是回報的 GFLOPS 與預期值之間允許的相對誤差。
+// HPL.out 的時間只印到小數點後兩位，短時間的執行需要一些空間。
+const DefaultGflopsTolerance = 0.02
+
+const gflopsBackfillBatch = 500
+
+var ErrGflopsMismatch = errors.New("gflops does not match n and execution_time")
+
+// ExpectedGflops returns the rate HPL reports for solving an n×n system in
+// seconds: (2/3·n³ + 2·n²) / seconds / 1e9. It returns 0 when either input is
+// not positive.
+func ExpectedGflops(n int, seconds float64) float64 {
+	if n <= 0 || seconds <= 0 {
+		return 0
+	}
+	size := float64(n)
+	return (2.0/3.0*size*size*size + 2.0*size*size) / seconds / 1e9
+}
+
+// WithGflopsTolerance 設定 gflops 與 ExpectedGflops 之間允許的相對誤差，例如 0.02 代表 2%
+func WithGflopsTolerance(tolerance float64) Option {
+	return func(s *HPLService) {
+		s.gflopsTolerance = tolerance
+	}
+}
+
+func (s *HPLService) gflopsWithinTolerance(gflops float64, expected float64) bool {
+	return expected > 0 && math.Abs(gflops-expected) <= s.gflopsTolerance*expected
+}
+
+// checkGflops 回傳預期的 GFLOPS，超出容許範圍時回傳 ErrGflopsMismatch
+func (s *HPLService) checkGflops(gflops float64, n int, executionTime float64) (float64, error) {
+	expected := ExpectedGflops(n, executionTime)
+	if !s.gflopsWithinTolerance(gflops, expected) {
+		return expected, fmt.Errorf("%w: reported %.6g, expected %.6g from n=%d and execution_time=%g (tolerance %g%%)",
+			ErrGflopsMismatch, gflops, expected, n, executionTime, s.gflopsTolerance*100)
+	}
+	return expected, nil
+}
+
+// checkBenchmarkGflops 只檢查 HPL 類成績的 gflops；HPCG 與 STREAM 的效能無法由
+// n 與 execution_time 推算，預期值記為 0
+func (s *HPLService) checkBenchmarkGflops(arg CreateScoreParams) (float64, error) {
+	if !isHPLFamily(arg.Benchmark) {
+		return 0, nil
+	}
+	return s.checkGflops(arg.Gflops, arg.N, arg.ExecutionTime)
+}
+
+// BackfillGflopsCheck recomputes expected_gflops for every stored score and
+// sets gflops_flagged on the ones outside the tolerance. Rows submitted before
+// n existed fall back to problem_size_n.
+func (s *HPLService) BackfillGflopsCheck(ctx context.Context) (checked int, flagged int, err error) {
+	var after pgtype.UUID
+	for {
+		scores, err := s.store.ListScoresAfter(ctx, db.ListScoresAfterParams{
+			ID:    after,
+			Limit: gflopsBackfillBatch,
+		})
+		if err != nil {
+			return checked, flagged, err
+		}
+
+		for _, score := range scores {
+			if !isHPLFamily(score.Benchmark) {
+				continue
+			}
+
+			n := int(score.N)
+			if n == 0 {
+				n = int(score.ProblemSizeN)
+			}
+			expected := ExpectedGflops(n, score.ExecutionTime)
+			mismatch := !s.gflopsWithinTolerance(score.Gflops, expected)
+
+			err := s.store.SetScoreGflopsCheck(ctx, db.SetScoreGflopsCheckParams{
+				ID:             score.ID,
+				ExpectedGflops: expected,
+				GflopsFlagged:  mismatch,
+			})
+			if err != nil {
+				return checked, flagged, err
+			}
+
+			checked++
+			if mismatch {
+				flagged++
+			}
+		}
+
+		if len(scores) < gflopsBackfillBatch {
+			return checked, flagged, nil
+		}
+		after = scores[len(scores)-1].ID
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectedGflops(t *testing.T) {
+	testCases := []struct {
+		name     string
+		n        int
+		seconds  float64
+		expected float64
+	}{
+		// (2/3 × 1000³ + 2 × 1000²) / 1 / 1e9
+		{name: "formula", n: 1000, seconds: 1, expected: 0.6686666666666666},
+		{name: "hpl run", n: 40000, seconds: 58.41, expected: 730.5233122182274},
+		{name: "zero n", n: 0, seconds: 10, expected: 0},
+		{name: "negative n", n: -1000, seconds: 10, expected: 0},
+		{name: "zero time", n: 1000, seconds: 0, expected: 0},
+		{name: "negative time", n: 1000, seconds: -1, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.expected, ExpectedGflops(tc.n, tc.seconds), 1e-9)
+		})
+	}
+}
+
+func TestCheckGflops(t *testing.T) {
+	// n=40000 在 58.41 秒內約為 730.52 GFLOPS，預設容許 2%
+	expected := ExpectedGflops(40000, 58.41)
+
+	testCases := []struct {
+		name          string
+		gflops        float64
+		n             int
+		executionTime float64
+		opts          []Option
+		wantErr       bool
+	}{
+		{name: "exact", gflops: expected, n: 40000, executionTime: 58.41},
+		{name: "rounded hpl output", gflops: 730.54, n: 40000, executionTime: 58.41},
+		{name: "just inside tolerance above", gflops: expected * 1.0199, n: 40000, executionTime: 58.41},
+		{name: "just inside tolerance below", gflops: expected * 0.9801, n: 40000, executionTime: 58.41},
+		{name: "just outside tolerance above", gflops: expected * 1.0201, n: 40000, executionTime: 58.41, wantErr: true},
+		{name: "just outside tolerance below", gflops: expected * 0.9799, n: 40000, executionTime: 58.41, wantErr: true},
+		{
+			name:          "custom tolerance",
+			gflops:        expected * 1.05,
+			n:             40000,
+			executionTime: 58.41,
+			opts:          []Option{WithGflopsTolerance(0.1)},
+		},
+		{name: "zero n", gflops: 730.54, n: 0, executionTime: 58.41, wantErr: true},
+		{name: "zero time", gflops: 730.54, n: 40000, executionTime: 0, wantErr: true},
+		{name: "negative time", gflops: 730.54, n: 40000, executionTime: -58.41, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewService(nil, nil, tc.opts...)
+
+			got, err := s.checkGflops(tc.gflops, tc.n, tc.executionTime)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrGflopsMismatch)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.InDelta(t, ExpectedGflops(tc.n, tc.executionTime), got, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+)
+
+const (
+	lockoutScopeAccount = "account"
+	lockoutScopeIP      = "ip"
+)
+
+// LockoutPolicy controls login throttling. Once a username or client IP
+// reaches its threshold of consecutive failures, every further failure locks
+// it for BaseLockout doubled per extra failure, capped at MaxLockout.
+// Failures older than ResetAfter are forgotten.
+type LockoutPolicy struct {
+	AccountThreshold int32
+	IPThreshold      int32
+	BaseLockout      time.Duration
+	MaxLockout       time.Duration
+	ResetAfter       time.Duration
+}
+
+// DefaultLockoutPolicy 一般帳號 5 次、單一 IP 20 次失敗後開始鎖定
+var DefaultLockoutPolicy = LockoutPolicy{
+	AccountThreshold: 5,
+	IPThreshold:      20,
+	BaseLockout:      time.Minute,
+	MaxLockout:       time.Hour,
+	ResetAfter:       24 * time.Hour,
+}
+
+// lockoutDuration 回傳第 failures 次失敗後應鎖定的時間
+func (p LockoutPolicy) lockoutDuration(failures int32, threshold int32) time.Duration {
+	if failures < threshold {
+		return 0
+	}
+	duration := p.BaseLockout
+	for i := threshold; i < failures && duration < p.MaxLockout; i++ {
+		duration *= 2
+	}
+	return min(duration, p.MaxLockout)
+}
+
+// CheckLoginLockout returns the time until which logins for username or from
+// clientIP are locked, or the zero time if login is allowed.
+func (s *HPLService) CheckLoginLockout(ctx context.Context, username string, clientIP string) (time.Time, error) {
+	failures, err := s.store.GetLoginFailures(ctx, db.GetLoginFailuresParams{
+		Username: username,
+		ClientIp: clientIP,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var lockedUntil time.Time
+	now := time.Now()
+	for _, failure := range failures {
+		if failure.LockedUntil.Valid && failure.LockedUntil.Time.After(now) && failure.LockedUntil.Time.After(lockedUntil) {
+			lockedUntil = failure.LockedUntil.Time
+		}
+	}
+	return lockedUntil, nil
+}
+
+// RecordLoginFailure counts a failed login against both username and clientIP
+// and locks whichever reached its threshold. Each lockout is audited.
+func (s *HPLService) RecordLoginFailure(ctx context.Context, username string, clientIP string) error {
+	policy := s.lockout
+	subjects := []struct {
+		scope     string
+		subject   string
+		threshold int32
+	}{
+		{lockoutScopeAccount, username, policy.AccountThreshold},
+		{lockoutScopeIP, clientIP, policy.IPThreshold},
+	}
+
+	for _, sub := range subjects {
+		failure, err := s.store.RecordLoginFailure(ctx, db.RecordLoginFailureParams{
+			Scope:       sub.scope,
+			Subject:     sub.subject,
+			ResetBefore: time.Now().Add(-policy.ResetAfter),
+		})
+		if err != nil {
+			return err
+		}
+
+		duration := policy.lockoutDuration(failure.Failures, sub.threshold)
+		if duration == 0 {
+			continue
+		}
+
+		lockedUntil := time.Now().Add(duration)
+		if err := s.store.LockLogin(ctx, db.LockLoginParams{
+			LockedUntil: pgtype.Timestamptz{Time: lockedUntil, Valid: true},
+			Scope:       sub.scope,
+			Subject:     sub.subject,
+		}); err != nil {
+			return err
+		}
+
+		if _, err := s.store.CreateAuditEvent(ctx, db.CreateAuditEventParams{
+			Event:    AuditEventLoginLockout,
+			Subject:  sub.subject,
+			ClientIp: clientIP,
+			Details:  fmt.Sprintf("%s locked for %s after %d failed logins", sub.scope, duration, failure.Failures),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordLoginSuccess resets the failure count of username. The client IP
+// count is kept, so a valid account cannot be used to reset it.
+func (s *HPLService) RecordLoginSuccess(ctx context.Context, username string) error {
+	_, err := s.store.ClearLoginFailures(ctx, db.ClearLoginFailuresParams{
+		Scope:   lockoutScopeAccount,
+		Subject: username,
+	})
+	return err
+}
+
+// UnlockUser lifts the lockout of username and records who did it
+func (s *HPLService) UnlockUser(ctx context.Context, username string, unlockedBy string) error {
+	if _, err := s.store.ClearLoginFailures(ctx, db.ClearLoginFailuresParams{
+		Scope:   lockoutScopeAccount,
+		Subject: username,
+	}); err != nil {
+		return err
+	}
+
+	_, err := s.store.CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		Event:   AuditEventAccountUnlocked,
+		Actor:   unlockedBy,
+		Subject: username,
+	})
+	return err
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	db_mocks "github.com/kdotwei/hpl-scoreboard/internal/db/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	testCases := []struct {
+		name      string
+		policy    LockoutPolicy
+		failures  int32
+		threshold int32
+		expected  time.Duration
+	}{
+		{name: "below account threshold", policy: DefaultLockoutPolicy, failures: 4, threshold: 5, expected: 0},
+		{name: "at account threshold", policy: DefaultLockoutPolicy, failures: 5, threshold: 5, expected: time.Minute},
+		{name: "one past threshold doubles", policy: DefaultLockoutPolicy, failures: 6, threshold: 5, expected: 2 * time.Minute},
+		{name: "two past threshold doubles twice", policy: DefaultLockoutPolicy, failures: 7, threshold: 5, expected: 4 * time.Minute},
+		{name: "last step under the cap", policy: DefaultLockoutPolicy, failures: 10, threshold: 5, expected: 32 * time.Minute},
+		{name: "capped at max lockout", policy: DefaultLockoutPolicy, failures: 11, threshold: 5, expected: time.Hour},
+		{name: "far past the cap", policy: DefaultLockoutPolicy, failures: 1000, threshold: 5, expected: time.Hour},
+		{name: "below ip threshold", policy: DefaultLockoutPolicy, failures: 19, threshold: 20, expected: 0},
+		{name: "at ip threshold", policy: DefaultLockoutPolicy, failures: 20, threshold: 20, expected: time.Minute},
+		{
+			// 上限不是 BaseLockout 的 2 的次方倍時，停在上限
+			name:      "cap between doublings",
+			policy:    LockoutPolicy{BaseLockout: time.Minute, MaxLockout: 90 * time.Minute},
+			failures:  12,
+			threshold: 5,
+			expected:  90 * time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.policy.lockoutDuration(tc.failures, tc.threshold))
+		})
+	}
+}
+
+func TestRecordLoginFailure(t *testing.T) {
+	const (
+		username = "agent-lead"
+		clientIP = "203.0.113.7"
+	)
+
+	testCases := []struct {
+		name            string
+		accountFailures int32
+		ipFailures      int32
+		wantAccountLock time.Duration
+		wantIPLock      time.Duration
+	}{
+		{name: "below both thresholds", accountFailures: 1, ipFailures: 1},
+		{name: "account reaches its threshold", accountFailures: 5, ipFailures: 5, wantAccountLock: time.Minute},
+		{name: "account backoff doubles", accountFailures: 7, ipFailures: 7, wantAccountLock: 4 * time.Minute},
+		{name: "both capped at max lockout", accountFailures: 50, ipFailures: 50, wantAccountLock: time.Hour, wantIPLock: time.Hour},
+		{name: "ip reaches its threshold", accountFailures: 1, ipFailures: 20, wantIPLock: time.Minute},
+		{name: "both reach their thresholds", accountFailures: 5, ipFailures: 21, wantAccountLock: time.Minute, wantIPLock: 2 * time.Minute},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := new(db_mocks.Store)
+			expectFailure := func(scope string, subject string, failures int32, lock time.Duration) {
+				store.On("RecordLoginFailure", mock.Anything, mock.MatchedBy(func(arg db.RecordLoginFailureParams) bool {
+					resetBefore := time.Now().Add(-DefaultLockoutPolicy.ResetAfter)
+					return arg.Scope == scope && arg.Subject == subject &&
+						arg.ResetBefore.Sub(resetBefore).Abs() < time.Minute
+				})).Return(db.LoginFailure{Scope: scope, Subject: subject, Failures: failures}, nil).Once()
+				if lock == 0 {
+					return
+				}
+
+				store.On("LockLogin", mock.Anything, mock.MatchedBy(func(arg db.LockLoginParams) bool {
+					return arg.Scope == scope && arg.Subject == subject && arg.LockedUntil.Valid &&
+						time.Until(arg.LockedUntil.Time).Round(time.Minute) == lock
+				})).Return(nil).Once()
+				store.On("CreateAuditEvent", mock.Anything, db.CreateAuditEventParams{
+					Event:    AuditEventLoginLockout,
+					Subject:  subject,
+					ClientIp: clientIP,
+					Details:  fmt.Sprintf("%s locked for %s after %d failed logins", scope, lock, failures),
+				}).Return(db.AuditEvent{}, nil).Once()
+			}
+			expectFailure(lockoutScopeAccount, username, tc.accountFailures, tc.wantAccountLock)
+			expectFailure(lockoutScopeIP, clientIP, tc.ipFailures, tc.wantIPLock)
+
+			s := NewService(store, nil)
+			err := s.RecordLoginFailure(context.Background(), username, clientIP)
+			assert.NoError(t, err)
+			store.AssertExpectations(t)
+		})
+	}
+}
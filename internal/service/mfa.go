@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/totp"
+)
+
+const (
+	totpIssuer = "HPL Scoreboard"
+
+	recoveryCodeCount = 10
+	groupedCodeBytes  = 10
+	groupedCodeGroup  = 4
+)
+
+var (
+	ErrMFAAlreadyEnabled = errors.New("mfa already enabled")
+	ErrMFANotEnabled     = errors.New("mfa not enabled")
+	ErrMFANotEnrolled    = errors.New("no pending mfa enrollment")
+	ErrInvalidMFACode    = errors.New("invalid mfa code")
+)
+
+// TOTPEnrollment is a pending TOTP secret waiting to be confirmed
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// hashGroupedCode 雜湊 generateGroupedCode 產生的碼 (救援碼與隊伍邀請碼)，
+// 比對前忽略大小寫、空白與分隔用的 '-'
+func hashGroupedCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateGroupedCode 產生 80 bit 的隨機碼，格式為 XXXX-XXXX-XXXX-XXXX。
+// 亂數夠長，因此和 API Key 一樣只存 SHA-256 雜湊。
+func generateGroupedCode() (code string, hashed string, err error) {
+	random := make([]byte, groupedCodeBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", err
+	}
+	raw := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(random)
+
+	var groups []string
+	for i := 0; i < len(raw); i += groupedCodeGroup {
+		groups = append(groups, raw[i:i+groupedCodeGroup])
+	}
+	return strings.Join(groups, "-"), hashGroupedCode(raw), nil
+}
+
+// generateRecoveryCodes 產生一組一次性救援碼
+func generateRecoveryCodes() (codes []string, hashed []string, err error) {
+	for range recoveryCodeCount {
+		code, hashedCode, err := generateGroupedCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code)
+		hashed = append(hashed, hashedCode)
+	}
+	return codes, hashed, nil
+}
+
+// EnrollTOTP starts TOTP enrollment for username. The secret only takes
+// effect once ConfirmTOTP has checked a code generated from it; starting
+// again replaces a pending secret.
+func (s *HPLService) EnrollTOTP(ctx context.Context, username string) (*TOTPEnrollment, error) {
+	user, err := s.GetUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user.TotpEnabledAt.Valid {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.store.SetTOTPSecret(ctx, db.SetTOTPSecretParams{
+		Username:   username,
+		TotpSecret: secret,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	return &TOTPEnrollment{
+		Secret: secret,
+		URI:    totp.URI(totpIssuer, username, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables TOTP for username once code matches the pending secret
+// and returns a fresh set of recovery codes. The plaintext codes are only
+// returned here.
+func (s *HPLService) ConfirmTOTP(ctx context.Context, username string, code string) ([]string, error) {
+	user, err := s.GetUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user.TotpEnabledAt.Valid {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.TotpSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+
+	if err := s.verifyTOTP(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashed, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.EnableTOTPTx(ctx, db.EnableTOTPTxParams{
+		Username:            username,
+		HashedRecoveryCodes: hashed,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrTOTPNotPending) {
+			return nil, ErrMFANotEnrolled
+		}
+		return nil, err
+	}
+
+	if _, err := s.store.CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		Event:   AuditEventMFAEnabled,
+		Actor:   username,
+		Subject: username,
+	}); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// VerifyMFA checks a TOTP code or an unused recovery code for username.
+// Each TOTP code and recovery code is accepted only once.
+func (s *HPLService) VerifyMFA(ctx context.Context, username string, code string) error {
+	user, err := s.GetUser(ctx, username)
+	if err != nil {
+		return err
+	}
+	if !user.TotpEnabledAt.Valid {
+		return ErrMFANotEnabled
+	}
+	return s.verifyMFACode(ctx, user, code)
+}
+
+// DisableTOTP turns off TOTP for username after checking code, and discards
+// the remaining recovery codes.
+func (s *HPLService) DisableTOTP(ctx context.Context, username string, code string) error {
+	if err := s.VerifyMFA(ctx, username, code); err != nil {
+		return err
+	}
+	return s.disableTOTP(ctx, username, username)
+}
+
+// ResetMFA turns off TOTP for username without a code, for users who lost
+// both their authenticator and their recovery codes.
+func (s *HPLService) ResetMFA(ctx context.Context, username string, resetBy string) error {
+	if _, err := s.GetUser(ctx, username); err != nil {
+		return err
+	}
+	return s.disableTOTP(ctx, username, resetBy)
+}
+
+func (s *HPLService) disableTOTP(ctx context.Context, username string, actor string) error {
+	if err := s.store.DisableTOTPTx(ctx, username); err != nil {
+		return err
+	}
+
+	_, err := s.store.CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		Event:   AuditEventMFADisabled,
+		Actor:   actor,
+		Subject: username,
+	})
+	return err
+}
+
+// verifyMFACode 6 位數字視為 TOTP，其餘視為救援碼
+func (s *HPLService) verifyMFACode(ctx context.Context, user *db.User, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits && strings.Trim(code, "0123456789") == "" {
+		return s.verifyTOTP(ctx, user, code)
+	}
+
+	rows, err := s.store.UseRecoveryCode(ctx, db.UseRecoveryCodeParams{
+		Username:   user.Username,
+		HashedCode: hashGroupedCode(code),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrInvalidMFACode
+	}
+
+	_, err = s.store.CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		Event:   AuditEventMFARecoveryCodeUsed,
+		Actor:   user.Username,
+		Subject: user.Username,
+	})
+	return err
+}
+
+// verifyTOTP 檢查驗證碼並記錄使用過的 time step，同一組驗證碼不能重放
+func (s *HPLService) verifyTOTP(ctx context.Context, user *db.User, code string) error {
+	step, ok := totp.Validate(user.TotpSecret, code, time.Now())
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	rows, err := s.store.UseTOTPStep(ctx, db.UseTOTPStepParams{
+		Step:     step,
+		Username: user.Username,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	db_mocks "github.com/kdotwei/hpl-scoreboard/internal/db/mocks"
+	"github.com/kdotwei/hpl-scoreboard/internal/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashGroupedCode(t *testing.T) {
+	expected := hashGroupedCode("ABCD-EFGH-IJKL-MNOP")
+
+	for _, code := range []string{
+		"ABCDEFGHIJKLMNOP",
+		"abcd-efgh-ijkl-mnop",
+		"ABCD EFGH IJKL MNOP",
+		" abcd-EFGH ijkl-mnop ",
+		"A-B-C-D-E-F-G-H-I-J-K-L-M-N-O-P",
+	} {
+		t.Run(code, func(t *testing.T) {
+			assert.Equal(t, expected, hashGroupedCode(code))
+		})
+	}
+
+	assert.NotEqual(t, expected, hashGroupedCode("ABCD-EFGH-IJKL-MNOQ"))
+}
+
+// newTOTPUser 回傳已啟用 TOTP 的使用者，以及目前 time step 的驗證碼
+func newTOTPUser(t *testing.T) (db.User, string, int64) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	step := totp.Step(time.Now())
+	code, err := totp.Code(secret, step)
+	require.NoError(t, err)
+
+	user := db.User{
+		Username:      "agent-lead",
+		TotpSecret:    secret,
+		TotpEnabledAt: pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+	}
+	return user, code, step
+}
+
+func TestVerifyMFACode(t *testing.T) {
+	user, code, step := newTOTPUser(t)
+	recoveryCode := "abcd-efgh-ijkl-mnop"
+	stepParams := db.UseTOTPStepParams{Step: step, Username: user.Username}
+	wrongCode := "000000"
+	if code == wrongCode {
+		wrongCode = "111111"
+	}
+
+	testCases := []struct {
+		name      string
+		code      string
+		setupMock func(*db_mocks.Store)
+		wantErr   error
+	}{
+		{
+			name: "totp",
+			code: code,
+			setupMock: func(store *db_mocks.Store) {
+				store.On("UseTOTPStep", mock.Anything, stepParams).Return(int64(1), nil)
+			},
+		},
+		{
+			name: "totp with surrounding spaces",
+			code: " " + code + "\n",
+			setupMock: func(store *db_mocks.Store) {
+				store.On("UseTOTPStep", mock.Anything, stepParams).Return(int64(1), nil)
+			},
+		},
+		{
+			// 同一個 time step 已經用過
+			name: "replayed totp",
+			code: code,
+			setupMock: func(store *db_mocks.Store) {
+				store.On("UseTOTPStep", mock.Anything, stepParams).Return(int64(0), nil)
+			},
+			wantErr: ErrInvalidMFACode,
+		},
+		{
+			name:      "wrong totp",
+			code:      wrongCode,
+			setupMock: func(*db_mocks.Store) {},
+			wantErr:   ErrInvalidMFACode,
+		},
+		{
+			name: "recovery code",
+			code: recoveryCode,
+			setupMock: func(store *db_mocks.Store) {
+				store.On("UseRecoveryCode", mock.Anything, db.UseRecoveryCodeParams{
+					Username:   user.Username,
+					HashedCode: hashGroupedCode(recoveryCode),
+				}).Return(int64(1), nil)
+				store.On("CreateAuditEvent", mock.Anything, db.CreateAuditEventParams{
+					Event:   AuditEventMFARecoveryCodeUsed,
+					Actor:   user.Username,
+					Subject: user.Username,
+				}).Return(db.AuditEvent{}, nil)
+			},
+		},
+		{
+			name: "used recovery code",
+			code: recoveryCode,
+			setupMock: func(store *db_mocks.Store) {
+				store.On("UseRecoveryCode", mock.Anything, db.UseRecoveryCodeParams{
+					Username:   user.Username,
+					HashedCode: hashGroupedCode(recoveryCode),
+				}).Return(int64(0), nil)
+			},
+			wantErr: ErrInvalidMFACode,
+		},
+		{
+			// 長度不是 6 位數字，當作救援碼比對
+			name: "seven digits are a recovery code",
+			code: "1234567",
+			setupMock: func(store *db_mocks.Store) {
+				store.On("UseRecoveryCode", mock.Anything, db.UseRecoveryCodeParams{
+					Username:   user.Username,
+					HashedCode: hashGroupedCode("1234567"),
+				}).Return(int64(0), nil)
+			},
+			wantErr: ErrInvalidMFACode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := new(db_mocks.Store)
+			tc.setupMock(store)
+			s := NewService(store, nil)
+
+			err := s.verifyMFACode(context.Background(), &user, tc.code)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	enabled, code, step := newTOTPUser(t)
+	pending := enabled
+	pending.TotpEnabledAt = pgtype.Timestamptz{}
+	stepParams := db.UseTOTPStepParams{Step: step, Username: pending.Username}
+	recoveryCodes := mock.MatchedBy(func(arg db.EnableTOTPTxParams) bool {
+		return arg.Username == pending.Username && len(arg.HashedRecoveryCodes) == recoveryCodeCount
+	})
+
+	testCases := []struct {
+		name      string
+		setupMock func(*db_mocks.Store)
+		wantErr   error
+	}{
+		{
+			name: "enabled",
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetUser", mock.Anything, pending.Username).Return(pending, nil)
+				store.On("UseTOTPStep", mock.Anything, stepParams).Return(int64(1), nil)
+				store.On("EnableTOTPTx", mock.Anything, recoveryCodes).Return(nil)
+				store.On("CreateAuditEvent", mock.Anything, db.CreateAuditEventParams{
+					Event:   AuditEventMFAEnabled,
+					Actor:   pending.Username,
+					Subject: pending.Username,
+				}).Return(db.AuditEvent{}, nil)
+			},
+		},
+		{
+			name: "already enabled",
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetUser", mock.Anything, pending.Username).Return(enabled, nil)
+			},
+			wantErr: ErrMFAAlreadyEnabled,
+		},
+		{
+			name: "not enrolled",
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetUser", mock.Anything, pending.Username).Return(db.User{Username: pending.Username}, nil)
+			},
+			wantErr: ErrMFANotEnrolled,
+		},
+		{
+			name: "replayed totp",
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetUser", mock.Anything, pending.Username).Return(pending, nil)
+				store.On("UseTOTPStep", mock.Anything, stepParams).Return(int64(0), nil)
+			},
+			wantErr: ErrInvalidMFACode,
+		},
+		{
+			// 確認前另一個請求已經啟用或重新申請
+			name: "enrollment no longer pending",
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetUser", mock.Anything, pending.Username).Return(pending, nil)
+				store.On("UseTOTPStep", mock.Anything, stepParams).Return(int64(1), nil)
+				store.On("EnableTOTPTx", mock.Anything, recoveryCodes).Return(db.ErrTOTPNotPending)
+			},
+			wantErr: ErrMFANotEnrolled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := new(db_mocks.Store)
+			tc.setupMock(store)
+			s := NewService(store, nil)
+
+			codes, err := s.ConfirmTOTP(context.Background(), pending.Username, code)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, codes)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, codes, recoveryCodeCount)
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
@@ -9,6 +9,12 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	service "github.com/kdotwei/hpl-scoreboard/internal/service"
+
+	time "time"
+
+	token "github.com/kdotwei/hpl-scoreboard/internal/token"
+
+	uuid "github.com/google/uuid"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -16,6 +22,191 @@ type Service struct {
 	mock.Mock
 }
 
+// AuthenticateUser provides a mock function with given fields: ctx, username, password
+func (_m *Service) AuthenticateUser(ctx context.Context, username string, password string) (*db.User, error) {
+	ret := _m.Called(ctx, username, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthenticateUser")
+	}
+
+	var r0 *db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*db.User, error)); ok {
+		return rf(ctx, username, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *db.User); ok {
+		r0 = rf(ctx, username, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckLoginLockout provides a mock function with given fields: ctx, username, clientIP
+func (_m *Service) CheckLoginLockout(ctx context.Context, username string, clientIP string) (time.Time, error) {
+	ret := _m.Called(ctx, username, clientIP)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckLoginLockout")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (time.Time, error)); ok {
+		return rf(ctx, username, clientIP)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) time.Time); ok {
+		r0 = rf(ctx, username, clientIP)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, clientIP)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmTOTP provides a mock function with given fields: ctx, username, code
+func (_m *Service) ConfirmTOTP(ctx context.Context, username string, code string) ([]string, error) {
+	ret := _m.Called(ctx, username, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmTOTP")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return rf(ctx, username, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, username, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAPIKey provides a mock function with given fields: ctx, arg
+func (_m *Service) CreateAPIKey(ctx context.Context, arg service.CreateAPIKeyParams) (string, *db.ApiKey, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKey")
+	}
+
+	var r0 string
+	var r1 *db.ApiKey
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.CreateAPIKeyParams) (string, *db.ApiKey, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.CreateAPIKeyParams) string); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.CreateAPIKeyParams) *db.ApiKey); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*db.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, service.CreateAPIKeyParams) error); ok {
+		r2 = rf(ctx, arg)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateSSHKey provides a mock function with given fields: ctx, arg
+func (_m *Service) CreateSSHKey(ctx context.Context, arg service.CreateSSHKeyParams) (*db.SshKey, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSSHKey")
+	}
+
+	var r0 *db.SshKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.CreateSSHKeyParams) (*db.SshKey, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.CreateSSHKeyParams) *db.SshKey); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.SshKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.CreateSSHKeyParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSSHKeyChallenge provides a mock function with given fields: ctx, id, username
+func (_m *Service) CreateSSHKeyChallenge(ctx context.Context, id uuid.UUID, username string) (*service.SSHKeyChallenge, error) {
+	ret := _m.Called(ctx, id, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSSHKeyChallenge")
+	}
+
+	var r0 *service.SSHKeyChallenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*service.SSHKeyChallenge, error)); ok {
+		return rf(ctx, id, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *service.SSHKeyChallenge); ok {
+		r0 = rf(ctx, id, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.SSHKeyChallenge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, id, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateScore provides a mock function with given fields: ctx, arg
 func (_m *Service) CreateScore(ctx context.Context, arg service.CreateScoreParams) (*db.Score, error) {
 	ret := _m.Called(ctx, arg)
@@ -46,29 +237,29 @@ func (_m *Service) CreateScore(ctx context.Context, arg service.CreateScoreParam
 	return r0, r1
 }
 
-// ListScores provides a mock function with given fields: ctx, limit, offset
-func (_m *Service) ListScores(ctx context.Context, limit int32, offset int32) ([]db.Score, error) {
-	ret := _m.Called(ctx, limit, offset)
+// CreateSession provides a mock function with given fields: ctx, arg
+func (_m *Service) CreateSession(ctx context.Context, arg service.CreateSessionParams) (*db.Session, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListScores")
+		panic("no return value specified for CreateSession")
 	}
 
-	var r0 []db.Score
+	var r0 *db.Session
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) ([]db.Score, error)); ok {
-		return rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, service.CreateSessionParams) (*db.Session, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) []db.Score); ok {
-		r0 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, service.CreateSessionParams) *db.Session); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Score)
+			r0 = ret.Get(0).(*db.Session)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
-		r1 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, service.CreateSessionParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -76,29 +267,1444 @@ func (_m *Service) ListScores(ctx context.Context, limit int32, offset int32) ([
 	return r0, r1
 }
 
-// ListScoresWithPagination provides a mock function with given fields: ctx, params
-func (_m *Service) ListScoresWithPagination(ctx context.Context, params service.ListScoresParams) (*service.PaginatedScoresResponse, error) {
-	ret := _m.Called(ctx, params)
+// CreateSigningKey provides a mock function with given fields: ctx, username, name
+func (_m *Service) CreateSigningKey(ctx context.Context, username string, name string) (string, *db.SigningKey, error) {
+	ret := _m.Called(ctx, username, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListScoresWithPagination")
+		panic("no return value specified for CreateSigningKey")
 	}
 
-	var r0 *service.PaginatedScoresResponse
+	var r0 string
+	var r1 *db.SigningKey
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, *db.SigningKey, error)); ok {
+		return rf(ctx, username, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, username, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *db.SigningKey); ok {
+		r1 = rf(ctx, username, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*db.SigningKey)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, username, name)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateSystem provides a mock function with given fields: ctx, createdBy, arg
+func (_m *Service) CreateSystem(ctx context.Context, createdBy string, arg service.SystemParams) (*db.System, error) {
+	ret := _m.Called(ctx, createdBy, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSystem")
+	}
+
+	var r0 *db.System
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, service.ListScoresParams) (*service.PaginatedScoresResponse, error)); ok {
-		return rf(ctx, params)
+	if rf, ok := ret.Get(0).(func(context.Context, string, service.SystemParams) (*db.System, error)); ok {
+		return rf(ctx, createdBy, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, service.ListScoresParams) *service.PaginatedScoresResponse); ok {
-		r0 = rf(ctx, params)
+	if rf, ok := ret.Get(0).(func(context.Context, string, service.SystemParams) *db.System); ok {
+		r0 = rf(ctx, createdBy, arg)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*service.PaginatedScoresResponse)
+			r0 = ret.Get(0).(*db.System)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, service.ListScoresParams) error); ok {
-		r1 = rf(ctx, params)
+	if rf, ok := ret.Get(1).(func(context.Context, string, service.SystemParams) error); ok {
+		r1 = rf(ctx, createdBy, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTeam provides a mock function with given fields: ctx, name, owner
+func (_m *Service) CreateTeam(ctx context.Context, name string, owner string) (*db.Team, error) {
+	ret := _m.Called(ctx, name, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTeam")
+	}
+
+	var r0 *db.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*db.Team, error)); ok {
+		return rf(ctx, name, owner)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *db.Team); ok {
+		r0 = rf(ctx, name, owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTeamInvitation provides a mock function with given fields: ctx, arg
+func (_m *Service) CreateTeamInvitation(ctx context.Context, arg service.CreateTeamInvitationParams) (string, *db.TeamInvitation, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTeamInvitation")
+	}
+
+	var r0 string
+	var r1 *db.TeamInvitation
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.CreateTeamInvitationParams) (string, *db.TeamInvitation, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.CreateTeamInvitationParams) string); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.CreateTeamInvitationParams) *db.TeamInvitation); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*db.TeamInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, service.CreateTeamInvitationParams) error); ok {
+		r2 = rf(ctx, arg)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateUser provides a mock function with given fields: ctx, arg
+func (_m *Service) CreateUser(ctx context.Context, arg service.CreateUserParams) (*db.User, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 *db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.CreateUserParams) (*db.User, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.CreateUserParams) *db.User); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.CreateUserParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSSHKey provides a mock function with given fields: ctx, id, username
+func (_m *Service) DeleteSSHKey(ctx context.Context, id uuid.UUID, username string) error {
+	ret := _m.Called(ctx, id, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSSHKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteScore provides a mock function with given fields: ctx, id
+func (_m *Service) DeleteScore(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteScore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSystem provides a mock function with given fields: ctx, id, username
+func (_m *Service) DeleteSystem(ctx context.Context, id uuid.UUID, username string) error {
+	ret := _m.Called(ctx, id, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSystem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTeam provides a mock function with given fields: ctx, id, deletedBy
+func (_m *Service) DeleteTeam(ctx context.Context, id uuid.UUID, deletedBy string) error {
+	ret := _m.Called(ctx, id, deletedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTeam")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, deletedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableTOTP provides a mock function with given fields: ctx, username, code
+func (_m *Service) DisableTOTP(ctx context.Context, username string, code string) error {
+	ret := _m.Called(ctx, username, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisqualifyScore provides a mock function with given fields: ctx, arg
+func (_m *Service) DisqualifyScore(ctx context.Context, arg service.DisqualifyScoreParams) (*db.Score, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisqualifyScore")
+	}
+
+	var r0 *db.Score
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.DisqualifyScoreParams) (*db.Score, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.DisqualifyScoreParams) *db.Score); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Score)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.DisqualifyScoreParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EndSession provides a mock function with given fields: ctx, sessionID, username
+func (_m *Service) EndSession(ctx context.Context, sessionID uuid.UUID, username string) error {
+	ret := _m.Called(ctx, sessionID, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EndSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, sessionID, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTOTP provides a mock function with given fields: ctx, username
+func (_m *Service) EnrollTOTP(ctx context.Context, username string) (*service.TOTPEnrollment, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollTOTP")
+	}
+
+	var r0 *service.TOTPEnrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*service.TOTPEnrollment, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *service.TOTPEnrollment); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.TOTPEnrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnsureOIDCUser provides a mock function with given fields: ctx, arg
+func (_m *Service) EnsureOIDCUser(ctx context.Context, arg service.EnsureOIDCUserParams) (*db.User, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureOIDCUser")
+	}
+
+	var r0 *db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.EnsureOIDCUserParams) (*db.User, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.EnsureOIDCUserParams) *db.User); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.EnsureOIDCUserParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnsureUser provides a mock function with given fields: ctx, username
+func (_m *Service) EnsureUser(ctx context.Context, username string) (*db.User, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureUser")
+	}
+
+	var r0 *db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSystem provides a mock function with given fields: ctx, id
+func (_m *Service) GetSystem(ctx context.Context, id uuid.UUID) (*db.System, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSystem")
+	}
+
+	var r0 *db.System
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*db.System, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *db.System); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.System)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTeam provides a mock function with given fields: ctx, id
+func (_m *Service) GetTeam(ctx context.Context, id uuid.UUID) (*db.Team, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeam")
+	}
+
+	var r0 *db.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*db.Team, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *db.Team); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: ctx, username
+func (_m *Service) GetUser(ctx context.Context, username string) (*db.User, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantRole provides a mock function with given fields: ctx, username, role
+func (_m *Service) GrantRole(ctx context.Context, username string, role string) error {
+	ret := _m.Called(ctx, username, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IssueRunNonce provides a mock function with given fields: ctx, username
+func (_m *Service) IssueRunNonce(ctx context.Context, username string) (*db.RunNonce, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueRunNonce")
+	}
+
+	var r0 *db.RunNonce
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.RunNonce, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.RunNonce); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.RunNonce)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JoinTeam provides a mock function with given fields: ctx, code, username
+func (_m *Service) JoinTeam(ctx context.Context, code string, username string) (*db.TeamMember, error) {
+	ret := _m.Called(ctx, code, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JoinTeam")
+	}
+
+	var r0 *db.TeamMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*db.TeamMember, error)); ok {
+		return rf(ctx, code, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *db.TeamMember); ok {
+		r0 = rf(ctx, code, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.TeamMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, code, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAPIKeys provides a mock function with given fields: ctx, username
+func (_m *Service) ListAPIKeys(ctx context.Context, username string) ([]db.ApiKey, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAPIKeys")
+	}
+
+	var r0 []db.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]db.ApiKey, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.ApiKey); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuditEvents provides a mock function with given fields: ctx, limit, offset
+func (_m *Service) ListAuditEvents(ctx context.Context, limit int32, offset int32) ([]db.AuditEvent, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEvents")
+	}
+
+	var r0 []db.AuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) ([]db.AuditEvent, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) []db.AuditEvent); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGreenLeaderboard provides a mock function with given fields: ctx, params
+func (_m *Service) ListGreenLeaderboard(ctx context.Context, params service.ListScoresParams) (*service.PaginatedScoresResponse, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGreenLeaderboard")
+	}
+
+	var r0 *service.PaginatedScoresResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.ListScoresParams) (*service.PaginatedScoresResponse, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.ListScoresParams) *service.PaginatedScoresResponse); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.PaginatedScoresResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.ListScoresParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReviewQueue provides a mock function with given fields: ctx, limit, offset
+func (_m *Service) ListReviewQueue(ctx context.Context, limit int32, offset int32) (*service.PaginatedScoresResponse, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReviewQueue")
+	}
+
+	var r0 *service.PaginatedScoresResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) (*service.PaginatedScoresResponse, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) *service.PaginatedScoresResponse); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.PaginatedScoresResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSSHKeys provides a mock function with given fields: ctx, username
+func (_m *Service) ListSSHKeys(ctx context.Context, username string) ([]db.SshKey, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSSHKeys")
+	}
+
+	var r0 []db.SshKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]db.SshKey, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.SshKey); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SshKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListScores provides a mock function with given fields: ctx, limit, offset
+func (_m *Service) ListScores(ctx context.Context, limit int32, offset int32) ([]db.Score, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListScores")
+	}
+
+	var r0 []db.Score
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) ([]db.Score, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) []db.Score); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Score)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListScoresWithPagination provides a mock function with given fields: ctx, params
+func (_m *Service) ListScoresWithPagination(ctx context.Context, params service.ListScoresParams) (*service.PaginatedScoresResponse, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListScoresWithPagination")
+	}
+
+	var r0 *service.PaginatedScoresResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.ListScoresParams) (*service.PaginatedScoresResponse, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.ListScoresParams) *service.PaginatedScoresResponse); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.PaginatedScoresResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.ListScoresParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSigningKeys provides a mock function with given fields: ctx, username
+func (_m *Service) ListSigningKeys(ctx context.Context, username string) ([]db.SigningKey, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSigningKeys")
+	}
+
+	var r0 []db.SigningKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]db.SigningKey, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.SigningKey); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SigningKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSystems provides a mock function with given fields: ctx, limit, offset
+func (_m *Service) ListSystems(ctx context.Context, limit int32, offset int32) (*service.PaginatedSystemsResponse, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSystems")
+	}
+
+	var r0 *service.PaginatedSystemsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) (*service.PaginatedSystemsResponse, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, int32) *service.PaginatedSystemsResponse); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.PaginatedSystemsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTeamInvitations provides a mock function with given fields: ctx, teamID, username
+func (_m *Service) ListTeamInvitations(ctx context.Context, teamID uuid.UUID, username string) ([]db.TeamInvitation, error) {
+	ret := _m.Called(ctx, teamID, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTeamInvitations")
+	}
+
+	var r0 []db.TeamInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]db.TeamInvitation, error)); ok {
+		return rf(ctx, teamID, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []db.TeamInvitation); ok {
+		r0 = rf(ctx, teamID, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TeamInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, teamID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTeamLeaderboard provides a mock function with given fields: ctx, params
+func (_m *Service) ListTeamLeaderboard(ctx context.Context, params service.ListScoresParams) (*service.TeamLeaderboardResponse, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTeamLeaderboard")
+	}
+
+	var r0 *service.TeamLeaderboardResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.ListScoresParams) (*service.TeamLeaderboardResponse, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.ListScoresParams) *service.TeamLeaderboardResponse); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.TeamLeaderboardResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.ListScoresParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTeamMembers provides a mock function with given fields: ctx, id
+func (_m *Service) ListTeamMembers(ctx context.Context, id uuid.UUID) ([]db.TeamMember, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTeamMembers")
+	}
+
+	var r0 []db.TeamMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]db.TeamMember, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []db.TeamMember); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TeamMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserTeams provides a mock function with given fields: ctx, username
+func (_m *Service) ListUserTeams(ctx context.Context, username string) ([]db.ListUserTeamsRow, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserTeams")
+	}
+
+	var r0 []db.ListUserTeamsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]db.ListUserTeamsRow, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.ListUserTeamsRow); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListUserTeamsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordLoginFailure provides a mock function with given fields: ctx, username, clientIP
+func (_m *Service) RecordLoginFailure(ctx context.Context, username string, clientIP string) error {
+	ret := _m.Called(ctx, username, clientIP)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordLoginFailure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, clientIP)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordLoginSuccess provides a mock function with given fields: ctx, username
+func (_m *Service) RecordLoginSuccess(ctx context.Context, username string) error {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordLoginSuccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveLinuxAccount provides a mock function with given fields: ctx, linuxUsername, removedBy
+func (_m *Service) RemoveLinuxAccount(ctx context.Context, linuxUsername string, removedBy string) error {
+	ret := _m.Called(ctx, linuxUsername, removedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveLinuxAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, linuxUsername, removedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveTeamMember provides a mock function with given fields: ctx, teamID, username, removedBy
+func (_m *Service) RemoveTeamMember(ctx context.Context, teamID uuid.UUID, username string, removedBy string) error {
+	ret := _m.Called(ctx, teamID, username, removedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTeamMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r0 = rf(ctx, teamID, username, removedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetMFA provides a mock function with given fields: ctx, username, resetBy
+func (_m *Service) ResetMFA(ctx context.Context, username string, resetBy string) error {
+	ret := _m.Called(ctx, username, resetBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, resetBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResolveSigningKey provides a mock function with given fields: ctx, keyID
+func (_m *Service) ResolveSigningKey(ctx context.Context, keyID string) ([]byte, *token.Payload, error) {
+	ret := _m.Called(ctx, keyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveSigningKey")
+	}
+
+	var r0 []byte
+	var r1 *token.Payload
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, *token.Payload, error)); ok {
+		return rf(ctx, keyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = rf(ctx, keyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) *token.Payload); ok {
+		r1 = rf(ctx, keyID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*token.Payload)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, keyID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReviewScore provides a mock function with given fields: ctx, arg
+func (_m *Service) ReviewScore(ctx context.Context, arg service.ReviewScoreParams) (*db.Score, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReviewScore")
+	}
+
+	var r0 *db.Score
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.ReviewScoreParams) (*db.Score, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.ReviewScoreParams) *db.Score); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Score)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.ReviewScoreParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAPIKey provides a mock function with given fields: ctx, id, username
+func (_m *Service) RevokeAPIKey(ctx context.Context, id uuid.UUID, username string) error {
+	ret := _m.Called(ctx, id, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSigningKey provides a mock function with given fields: ctx, id, username
+func (_m *Service) RevokeSigningKey(ctx context.Context, id uuid.UUID, username string) error {
+	ret := _m.Called(ctx, id, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSigningKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeTeamInvitation provides a mock function with given fields: ctx, teamID, id, revokedBy
+func (_m *Service) RevokeTeamInvitation(ctx context.Context, teamID uuid.UUID, id uuid.UUID, revokedBy string) error {
+	ret := _m.Called(ctx, teamID, id, revokedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeTeamInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, teamID, id, revokedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeToken provides a mock function with given fields: ctx, payload
+func (_m *Service) RevokeToken(ctx context.Context, payload *token.Payload) error {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *token.Payload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserTokens provides a mock function with given fields: ctx, username, retainUntil
+func (_m *Service) RevokeUserTokens(ctx context.Context, username string, retainUntil time.Time) error {
+	ret := _m.Called(ctx, username, retainUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, username, retainUntil)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateSession provides a mock function with given fields: ctx, arg
+func (_m *Service) RotateSession(ctx context.Context, arg service.RotateSessionParams) (*db.Session, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateSession")
+	}
+
+	var r0 *db.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.RotateSessionParams) (*db.Session, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.RotateSessionParams) *db.Session); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.RotateSessionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnlockUser provides a mock function with given fields: ctx, username, unlockedBy
+func (_m *Service) UnlockUser(ctx context.Context, username string, unlockedBy string) error {
+	ret := _m.Called(ctx, username, unlockedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, unlockedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSystem provides a mock function with given fields: ctx, id, username, arg
+func (_m *Service) UpdateSystem(ctx context.Context, id uuid.UUID, username string, arg service.SystemParams) (*db.System, error) {
+	ret := _m.Called(ctx, id, username, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSystem")
+	}
+
+	var r0 *db.System
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, service.SystemParams) (*db.System, error)); ok {
+		return rf(ctx, id, username, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, service.SystemParams) *db.System); ok {
+		r0 = rf(ctx, id, username, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.System)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, service.SystemParams) error); ok {
+		r1 = rf(ctx, id, username, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTeamMemberRole provides a mock function with given fields: ctx, arg
+func (_m *Service) UpdateTeamMemberRole(ctx context.Context, arg service.UpdateTeamMemberRoleParams) (*db.TeamMember, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTeamMemberRole")
+	}
+
+	var r0 *db.TeamMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.UpdateTeamMemberRoleParams) (*db.TeamMember, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.UpdateTeamMemberRoleParams) *db.TeamMember); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.TeamMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.UpdateTeamMemberRoleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserRoles provides a mock function with given fields: ctx, username, roles
+func (_m *Service) UpdateUserRoles(ctx context.Context, username string, roles []string) (*db.User, error) {
+	ret := _m.Called(ctx, username, roles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserRoles")
+	}
+
+	var r0 *db.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*db.User, error)); ok {
+		return rf(ctx, username, roles)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *db.User); ok {
+		r0 = rf(ctx, username, roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, username, roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadScores provides a mock function with given fields: ctx, arg
+func (_m *Service) UploadScores(ctx context.Context, arg service.UploadScoresParams) ([]db.Score, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadScores")
+	}
+
+	var r0 []db.Score
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.UploadScoresParams) ([]db.Score, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.UploadScoresParams) []db.Score); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Score)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.UploadScoresParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyAPIKey provides a mock function with given fields: ctx, key
+func (_m *Service) VerifyAPIKey(ctx context.Context, key string) (*token.Payload, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyAPIKey")
+	}
+
+	var r0 *token.Payload
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*token.Payload, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *token.Payload); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*token.Payload)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyMFA provides a mock function with given fields: ctx, username, code
+func (_m *Service) VerifyMFA(ctx context.Context, username string, code string) error {
+	ret := _m.Called(ctx, username, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifySSHKey provides a mock function with given fields: ctx, id, username, signature
+func (_m *Service) VerifySSHKey(ctx context.Context, id uuid.UUID, username string, signature string) (*db.SshKey, error) {
+	ret := _m.Called(ctx, id, username, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySSHKey")
+	}
+
+	var r0 *db.SshKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) (*db.SshKey, error)); ok {
+		return rf(ctx, id, username, signature)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) *db.SshKey); ok {
+		r0 = rf(ctx, id, username, signature)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.SshKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r1 = rf(ctx, id, username, signature)
 	} else {
 		r1 = ret.Error(1)
 	}
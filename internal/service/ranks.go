@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+)
+
+// maxRanks 是單次執行允許的 MPI rank 上限，遠大於現有最大的系統，也避免相乘溢位
+const maxRanks = 1 << 24
+
+// Leaderboard orderings
+const (
+	ScoreSortGflops        = ""
+	ScoreSortGflopsPerNode = "gflops_per_node"
+	ScoreSortGflopsPerRank = "gflops_per_rank"
+)
+
+var ErrInvalidRankLayout = errors.New("process grid does not match the declared ranks and nodes")
+
+// ValidScoreSort reports whether sort is one of the ScoreSort values
+func ValidScoreSort(sort string) bool {
+	switch sort {
+	case ScoreSortGflops, ScoreSortGflopsPerNode, ScoreSortGflopsPerRank:
+		return true
+	}
+	return false
+}
+
+// checkRankLayout 確認 p × q = ranks = nodes × ranks_per_node；
+// 沒有 process grid 的 benchmark 只檢查 nodes × ranks_per_node
+func checkRankLayout(arg CreateScoreParams) error {
+	for _, value := range []int{arg.Ranks, arg.Nodes, arg.RanksPerNode} {
+		if value <= 0 || value > maxRanks {
+			return fmt.Errorf("%w: ranks, nodes and ranks_per_node must be between 1 and %d", ErrInvalidRankLayout, maxRanks)
+		}
+	}
+	switch {
+	case !isHPLFamily(arg.Benchmark):
+		if arg.P != 0 || arg.Q != 0 {
+			return fmt.Errorf("%w: %s has no process grid, p and q must be 0", ErrInvalidRankLayout, arg.Benchmark)
+		}
+	case arg.P <= 0 || arg.P > maxRanks || arg.Q <= 0 || arg.Q > maxRanks:
+		return fmt.Errorf("%w: p and q must be between 1 and %d", ErrInvalidRankLayout, maxRanks)
+	case arg.P*arg.Q != arg.Ranks:
+		return fmt.Errorf("%w: p × q = %d × %d = %d, but ranks = %d", ErrInvalidRankLayout, arg.P, arg.Q, arg.P*arg.Q, arg.Ranks)
+	}
+	if arg.Nodes*arg.RanksPerNode != arg.Ranks {
+		return fmt.Errorf("%w: nodes × ranks_per_node = %d × %d = %d, but ranks = %d",
+			ErrInvalidRankLayout, arg.Nodes, arg.RanksPerNode, arg.Nodes*arg.RanksPerNode, arg.Ranks)
+	}
+	return nil
+}
+
+// checkSystemNodes 確認成績使用的節點數不超過系統的節點數
+func checkSystemNodes(arg CreateScoreParams, system *db.System) error {
+	if arg.Nodes > int(system.Nodes) {
+		return fmt.Errorf("%w: nodes = %d, but %s has %d", ErrInvalidRankLayout, arg.Nodes, system.Name, system.Nodes)
+	}
+	return nil
+}
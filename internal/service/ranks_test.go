@@ -0,0 +1,115 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRankLayout(t *testing.T) {
+	testCases := []struct {
+		name    string
+		arg     CreateScoreParams
+		wantErr bool
+	}{
+		{
+			name: "2 × 4 grid on 2 nodes",
+			arg:  CreateScoreParams{Benchmark: BenchmarkHPL, P: 2, Q: 4, Ranks: 8, Nodes: 2, RanksPerNode: 4},
+		},
+		{
+			name: "single rank",
+			arg:  CreateScoreParams{Benchmark: BenchmarkHPL, P: 1, Q: 1, Ranks: 1, Nodes: 1, RanksPerNode: 1},
+		},
+		{
+			name:    "zero ranks",
+			arg:     CreateScoreParams{Benchmark: BenchmarkHPL, P: 2, Q: 4, Ranks: 0, Nodes: 2, RanksPerNode: 4},
+			wantErr: true,
+		},
+		{
+			name:    "zero nodes",
+			arg:     CreateScoreParams{Benchmark: BenchmarkHPL, P: 2, Q: 4, Ranks: 8, Nodes: 0, RanksPerNode: 4},
+			wantErr: true,
+		},
+		{
+			name:    "negative ranks per node",
+			arg:     CreateScoreParams{Benchmark: BenchmarkHPL, P: 2, Q: 4, Ranks: 8, Nodes: 2, RanksPerNode: -4},
+			wantErr: true,
+		},
+		{
+			name:    "ranks above limit",
+			arg:     CreateScoreParams{Benchmark: BenchmarkHPL, P: 1, Q: maxRanks + 1, Ranks: maxRanks + 1, Nodes: 1, RanksPerNode: maxRanks + 1},
+			wantErr: true,
+		},
+		{
+			name:    "zero p",
+			arg:     CreateScoreParams{Benchmark: BenchmarkHPL, P: 0, Q: 4, Ranks: 8, Nodes: 2, RanksPerNode: 4},
+			wantErr: true,
+		},
+		{
+			name:    "negative grid",
+			arg:     CreateScoreParams{Benchmark: BenchmarkHPL, P: -2, Q: -4, Ranks: 8, Nodes: 2, RanksPerNode: 4},
+			wantErr: true,
+		},
+		{
+			name:    "grid does not match ranks",
+			arg:     CreateScoreParams{Benchmark: BenchmarkHPL, P: 2, Q: 2, Ranks: 8, Nodes: 2, RanksPerNode: 4},
+			wantErr: true,
+		},
+		{
+			name:    "nodes × ranks_per_node does not match ranks",
+			arg:     CreateScoreParams{Benchmark: BenchmarkHPL, P: 2, Q: 4, Ranks: 8, Nodes: 4, RanksPerNode: 4},
+			wantErr: true,
+		},
+		{
+			name: "hpcg without grid",
+			arg:  CreateScoreParams{Benchmark: BenchmarkHPCG, Ranks: 8, Nodes: 2, RanksPerNode: 4},
+		},
+		{
+			name:    "stream with grid",
+			arg:     CreateScoreParams{Benchmark: BenchmarkStream, P: 1, Q: 1, Ranks: 1, Nodes: 1, RanksPerNode: 1},
+			wantErr: true,
+		},
+		{
+			name:    "stream ranks mismatch",
+			arg:     CreateScoreParams{Benchmark: BenchmarkStream, Ranks: 3, Nodes: 1, RanksPerNode: 4},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkRankLayout(tc.arg)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRankLayout)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckSystemNodes(t *testing.T) {
+	system := &db.System{Name: "cluster", Nodes: 4}
+
+	testCases := []struct {
+		name    string
+		nodes   int
+		wantErr bool
+	}{
+		{name: "fewer nodes", nodes: 2},
+		{name: "all nodes", nodes: 4},
+		{name: "more nodes than the system", nodes: 5, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkSystemNodes(CreateScoreParams{Nodes: tc.nodes}, system)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRankLayout)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/hplout"
+)
+
+// DefaultResidualThreshold 是 HPL.dat 預設的 scaled residual 門檻
+const DefaultResidualThreshold = 16.0
+
+// Residual filters for leaderboards. The zero value hides scores that failed
+// the residual check; scores without a reported residual are always shown
+// except by ResidualFilterPassed and ResidualFilterFailed.
+const (
+	ResidualFilterDefault = ""
+	ResidualFilterPassed  = "passed"
+	ResidualFilterFailed  = "failed"
+	ResidualFilterAll     = "all"
+)
+
+var (
+	ErrInvalidVariant  = errors.New("variant must look like WR11C2R4")
+	ErrInvalidResidual = errors.New("residual must be a non-negative number and residual_threshold a positive one")
+	// ErrResidualVerdictMismatch 表示 HPL 印出的 PASSED / FAILED 與 residual、門檻不一致，
+	// 例如檔案缺少門檻行而 HPL.dat 使用的不是預設門檻
+	ErrResidualVerdictMismatch = errors.New("residual check result does not match residual and threshold")
+)
+
+// ValidResidualFilter reports whether filter is one of the ResidualFilter values
+func ValidResidualFilter(filter string) bool {
+	switch filter {
+	case ResidualFilterDefault, ResidualFilterPassed, ResidualFilterFailed, ResidualFilterAll:
+		return true
+	}
+	return false
+}
+
+// residualCheck 驗證 variant 與 residual，回傳要存入的 residual、門檻與是否通過。
+// 沒有 residual 時三者皆為 NULL；門檻為 0 時使用 DefaultResidualThreshold。
+// 有 HPL 的判定時必須與重新計算的結果一致。
+func residualCheck(arg CreateScoreParams) (pgtype.Float8, pgtype.Float8, pgtype.Bool, error) {
+	if arg.Variant != "" && !hplout.ValidVariant(arg.Variant) {
+		return pgtype.Float8{}, pgtype.Float8{}, pgtype.Bool{}, ErrInvalidVariant
+	}
+
+	if arg.Residual == nil {
+		if arg.ResidualThreshold != 0 || arg.ResidualPassed != nil {
+			return pgtype.Float8{}, pgtype.Float8{}, pgtype.Bool{}, ErrInvalidResidual
+		}
+		return pgtype.Float8{}, pgtype.Float8{}, pgtype.Bool{}, nil
+	}
+
+	residual, threshold := *arg.Residual, arg.ResidualThreshold
+	if threshold == 0 {
+		threshold = DefaultResidualThreshold
+	}
+	if !isFinite(residual) || residual < 0 || !isFinite(threshold) || threshold < 0 {
+		return pgtype.Float8{}, pgtype.Float8{}, pgtype.Bool{}, ErrInvalidResidual
+	}
+
+	passed := residual < threshold
+	if arg.ResidualPassed != nil && *arg.ResidualPassed != passed {
+		return pgtype.Float8{}, pgtype.Float8{}, pgtype.Bool{}, fmt.Errorf("%w: hpl reported passed=%t, but residual %g against threshold %g gives passed=%t",
+			ErrResidualVerdictMismatch, *arg.ResidualPassed, residual, threshold, passed)
+	}
+
+	return pgtype.Float8{Float64: residual, Valid: true},
+		pgtype.Float8{Float64: threshold, Valid: true},
+		pgtype.Bool{Bool: passed, Valid: true},
+		nil
+}
+
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResidualCheck(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	verdict := func(v bool) *bool { return &v }
+
+	testCases := []struct {
+		name          string
+		arg           CreateScoreParams
+		wantErr       error
+		wantThreshold float64
+		wantPassed    pgtype.Bool
+	}{
+		{name: "no residual", arg: CreateScoreParams{}},
+		{
+			name:          "passed",
+			arg:           CreateScoreParams{Residual: ptr(3.47e-03), ResidualThreshold: 16},
+			wantThreshold: 16,
+			wantPassed:    pgtype.Bool{Bool: true, Valid: true},
+		},
+		{
+			name:          "failed",
+			arg:           CreateScoreParams{Residual: ptr(21.15), ResidualThreshold: 16},
+			wantThreshold: 16,
+			wantPassed:    pgtype.Bool{Bool: false, Valid: true},
+		},
+		{
+			name:          "default threshold",
+			arg:           CreateScoreParams{Residual: ptr(3.47e-03)},
+			wantThreshold: DefaultResidualThreshold,
+			wantPassed:    pgtype.Bool{Bool: true, Valid: true},
+		},
+		{
+			name:          "hpl verdict agrees",
+			arg:           CreateScoreParams{Residual: ptr(21.15), ResidualThreshold: 16, ResidualPassed: verdict(false)},
+			wantThreshold: 16,
+			wantPassed:    pgtype.Bool{Bool: false, Valid: true},
+		},
+		{
+			// HPL.dat 的門檻是 32，但檔案中沒有門檻行，以預設的 16 判斷會不同
+			name:    "hpl verdict disagrees with the default threshold",
+			arg:     CreateScoreParams{Residual: ptr(21.15), ResidualPassed: verdict(true)},
+			wantErr: ErrResidualVerdictMismatch,
+		},
+		{
+			name:    "hpl verdict disagrees",
+			arg:     CreateScoreParams{Residual: ptr(3.47e-03), ResidualThreshold: 16, ResidualPassed: verdict(false)},
+			wantErr: ErrResidualVerdictMismatch,
+		},
+		{name: "verdict without residual", arg: CreateScoreParams{ResidualPassed: verdict(true)}, wantErr: ErrInvalidResidual},
+		{name: "threshold without residual", arg: CreateScoreParams{ResidualThreshold: 16}, wantErr: ErrInvalidResidual},
+		{name: "negative residual", arg: CreateScoreParams{Residual: ptr(-1)}, wantErr: ErrInvalidResidual},
+		{name: "invalid variant", arg: CreateScoreParams{Variant: "WR11"}, wantErr: ErrInvalidVariant},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			residual, threshold, passed, err := residualCheck(tc.arg)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.arg.Residual != nil, residual.Valid)
+			assert.Equal(t, tc.wantThreshold, threshold.Float64)
+			assert.Equal(t, tc.wantPassed, passed)
+		})
+	}
+}
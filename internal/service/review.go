@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+)
+
+// DefaultReviewEfficiency 是需要人工審核的 efficiency 門檻。
+// 調校良好的 CPU 系統 HPL 效率大約 70% 到 85%，超過 90% 值得再確認一次。
+const DefaultReviewEfficiency = 0.9
+
+// Review statuses. Only accepted scores appear on leaderboards.
+const (
+	ReviewStatusAccepted = "accepted"
+	ReviewStatusPending  = "pending"
+	ReviewStatusRejected = "rejected"
+)
+
+var (
+	ErrExceedsRpeak    = errors.New("gflops exceeds the theoretical peak of the system")
+	ErrScoreNotPending = errors.New("score is not waiting for review")
+)
+
+// WithReviewEfficiency 設定需要人工審核的 efficiency 門檻，例如 0.9 代表 Rpeak 的 90%
+func WithReviewEfficiency(threshold float64) Option {
+	return func(s *HPLService) {
+		s.reviewEfficiency = threshold
+	}
+}
+
+// reviewDecision 依 efficiency 決定成績的審核狀態並回傳原因。
+// 超過 Rpeak 的成績不可能是真的，直接拒絕 (ErrExceedsRpeak)。
+// HPL-MxP 與 STREAM 不以 FP64 Rpeak 衡量，直接通過。
+func (s *HPLService) reviewDecision(benchmark string, gflops float64, rpeak float64, system string) (string, string, error) {
+	switch benchmark {
+	case BenchmarkHPLMxP:
+		return ReviewStatusAccepted, "hpl-mxp runs in mixed precision and is not checked against the FP64 Rpeak", nil
+	case BenchmarkStream:
+		return ReviewStatusAccepted, "stream measures memory bandwidth and is not checked against Rpeak", nil
+	}
+
+	efficiency := gflops / rpeak
+	if gflops > rpeak {
+		return "", "", fmt.Errorf("%w: %.6g GFLOPS is %.1f%% of the %.6g GFLOPS Rpeak of %s on the nodes used",
+			ErrExceedsRpeak, gflops, efficiency*100, rpeak, system)
+	}
+	if efficiency > s.reviewEfficiency {
+		return ReviewStatusPending, fmt.Sprintf("efficiency %.1f%% is above the review threshold of %g%%",
+			efficiency*100, s.reviewEfficiency*100), nil
+	}
+	return ReviewStatusAccepted, fmt.Sprintf("efficiency %.1f%% is within the review threshold of %g%%",
+		efficiency*100, s.reviewEfficiency*100), nil
+}
+
+// rereviewScore 在系統的每節點峰值改變後重新評估成績，只會變得更嚴格：
+// 超過新 Rpeak 的成績改為 rejected，超過門檻的成績 (包含評審通過的) 重新進入審核佇列。
+// 規格變大不會讓 pending 的成績自動通過，否則提交後再灌水規格就能繞過審核。
+func (s *HPLService) rereviewScore(system string) func(db.Score) (string, string) {
+	return func(score db.Score) (string, string) {
+		if !score.RpeakGflops.Valid {
+			return score.ReviewStatus, score.ReviewReason
+		}
+
+		status, reason, err := s.reviewDecision(score.Benchmark, score.Gflops, score.RpeakGflops.Float64, system)
+		switch {
+		case err != nil:
+			return ReviewStatusRejected, "system specs changed: " + err.Error()
+		case status == ReviewStatusPending:
+			return ReviewStatusPending, "system specs changed: " + reason
+		}
+		return score.ReviewStatus, score.ReviewReason
+	}
+}
+
+// ListReviewQueue lists the scores waiting for review, oldest first
+func (s *HPLService) ListReviewQueue(ctx context.Context, limit int32, offset int32) (*PaginatedScoresResponse, error) {
+	scores, err := s.store.ListPendingScores(ctx, db.ListPendingScoresParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalRecords, err := s.store.CountPendingScores(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedScoresResponse{
+		Scores:       scores,
+		HasMore:      int64(offset+int32(len(scores))) < totalRecords,
+		TotalRecords: totalRecords,
+		Limit:        limit,
+		Offset:       offset,
+	}, nil
+}
+
+// ReviewScore records a judge's decision on a pending score. Approved scores
+// join the leaderboards; the reason replaces the automatic one.
+func (s *HPLService) ReviewScore(ctx context.Context, arg ReviewScoreParams) (*db.Score, error) {
+	status := ReviewStatusRejected
+	if arg.Approve {
+		status = ReviewStatusAccepted
+	}
+
+	score, err := s.store.ReviewScore(ctx, db.ReviewScoreParams{
+		ReviewStatus: status,
+		ReviewReason: arg.Reason,
+		ReviewedBy:   arg.ReviewedBy,
+		ID:           pgtype.UUID{Bytes: arg.ID, Valid: true},
+	})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		// 區分成績不存在與已經審核過
+		if _, err := s.store.GetScore(ctx, pgtype.UUID{Bytes: arg.ID, Valid: true}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrScoreNotFound
+			}
+			return nil, err
+		}
+		return nil, ErrScoreNotPending
+	}
+	return &score, nil
+}
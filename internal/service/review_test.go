@@ -0,0 +1,166 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewDecision(t *testing.T) {
+	testCases := []struct {
+		name       string
+		benchmark  string
+		gflops     float64
+		rpeak      float64
+		opts       []Option
+		wantErr    error
+		wantStatus string
+		wantReason string
+	}{
+		{
+			name:       "typical efficiency",
+			benchmark:  BenchmarkHPL,
+			gflops:     800,
+			rpeak:      1000,
+			wantStatus: ReviewStatusAccepted,
+			wantReason: "efficiency 80.0% is within the review threshold of 90%",
+		},
+		{
+			name:       "at the threshold",
+			benchmark:  BenchmarkHPL,
+			gflops:     900,
+			rpeak:      1000,
+			wantStatus: ReviewStatusAccepted,
+			wantReason: "efficiency 90.0% is within the review threshold of 90%",
+		},
+		{
+			name:       "above the threshold",
+			benchmark:  BenchmarkHPL,
+			gflops:     950,
+			rpeak:      1000,
+			wantStatus: ReviewStatusPending,
+			wantReason: "efficiency 95.0% is above the review threshold of 90%",
+		},
+		{
+			name:       "at rpeak",
+			benchmark:  BenchmarkHPL,
+			gflops:     1000,
+			rpeak:      1000,
+			wantStatus: ReviewStatusPending,
+			wantReason: "efficiency 100.0% is above the review threshold of 90%",
+		},
+		{
+			name:      "above rpeak",
+			benchmark: BenchmarkHPL,
+			gflops:    1001,
+			rpeak:     1000,
+			wantErr:   ErrExceedsRpeak,
+		},
+		{
+			name:       "custom threshold",
+			benchmark:  BenchmarkHPL,
+			gflops:     800,
+			rpeak:      1000,
+			opts:       []Option{WithReviewEfficiency(0.75)},
+			wantStatus: ReviewStatusPending,
+			wantReason: "efficiency 80.0% is above the review threshold of 75%",
+		},
+		{
+			name:       "hpcg",
+			benchmark:  BenchmarkHPCG,
+			gflops:     20,
+			rpeak:      1000,
+			wantStatus: ReviewStatusAccepted,
+			wantReason: "efficiency 2.0% is within the review threshold of 90%",
+		},
+		{
+			name:       "hpl-mxp above the fp64 rpeak",
+			benchmark:  BenchmarkHPLMxP,
+			gflops:     5000,
+			rpeak:      1000,
+			wantStatus: ReviewStatusAccepted,
+			wantReason: "hpl-mxp runs in mixed precision and is not checked against the FP64 Rpeak",
+		},
+		{
+			name:       "stream skips rpeak",
+			benchmark:  BenchmarkStream,
+			gflops:     0,
+			rpeak:      1000,
+			wantStatus: ReviewStatusAccepted,
+			wantReason: "stream measures memory bandwidth and is not checked against Rpeak",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewService(nil, nil, tc.opts...)
+
+			status, reason, err := s.reviewDecision(tc.benchmark, tc.gflops, tc.rpeak, "cluster")
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				assert.Contains(t, err.Error(), "cluster")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, status)
+			assert.Equal(t, tc.wantReason, reason)
+		})
+	}
+}
+
+func TestRereviewScore(t *testing.T) {
+	rpeak := pgtype.Float8{Float64: 1000, Valid: true}
+
+	testCases := []struct {
+		name       string
+		score      db.Score
+		wantStatus string
+		wantReason string
+	}{
+		{
+			name:       "still within the threshold",
+			score:      db.Score{Benchmark: BenchmarkHPL, Gflops: 800, RpeakGflops: rpeak, ReviewStatus: ReviewStatusAccepted, ReviewReason: "old"},
+			wantStatus: ReviewStatusAccepted,
+			wantReason: "old",
+		},
+		{
+			name:       "accepted score now above the threshold",
+			score:      db.Score{Benchmark: BenchmarkHPL, Gflops: 950, RpeakGflops: rpeak, ReviewStatus: ReviewStatusAccepted},
+			wantStatus: ReviewStatusPending,
+			wantReason: "system specs changed: efficiency 95.0% is above the review threshold of 90%",
+		},
+		{
+			name:       "pending score is not accepted automatically",
+			score:      db.Score{Benchmark: BenchmarkHPL, Gflops: 500, RpeakGflops: rpeak, ReviewStatus: ReviewStatusPending, ReviewReason: "old"},
+			wantStatus: ReviewStatusPending,
+			wantReason: "old",
+		},
+		{
+			name:       "now above rpeak",
+			score:      db.Score{Benchmark: BenchmarkHPL, Gflops: 1200, RpeakGflops: rpeak, ReviewStatus: ReviewStatusAccepted},
+			wantStatus: ReviewStatusRejected,
+		},
+		{
+			name:       "stream has no rpeak",
+			score:      db.Score{Benchmark: BenchmarkStream, ReviewStatus: ReviewStatusAccepted, ReviewReason: "old"},
+			wantStatus: ReviewStatusAccepted,
+			wantReason: "old",
+		},
+	}
+
+	review := NewService(nil, nil).rereviewScore("cluster")
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, reason := review(tc.score)
+			assert.Equal(t, tc.wantStatus, status)
+			if tc.wantStatus == ReviewStatusRejected {
+				assert.Contains(t, reason, "system specs changed: "+ErrExceedsRpeak.Error())
+				return
+			}
+			assert.Equal(t, tc.wantReason, reason)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+)
+
+func (s *HPLService) RevokeToken(ctx context.Context, payload *token.Payload) error {
+	return s.revocations.RevokeToken(ctx, payload.ID, payload.Username, payload.ExpiredAt)
+}
+
+// EndSession revokes the refresh token session sessionID together with every
+// session rotated from the same login.
+func (s *HPLService) EndSession(ctx context.Context, sessionID uuid.UUID, username string) error {
+	session, err := s.store.GetSession(ctx, pgtype.UUID{Bytes: sessionID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+
+	if session.Username != username {
+		return ErrSessionMismatch
+	}
+	return s.store.RevokeSessionFamily(ctx, session.FamilyID)
+}
+
+// RevokeUserTokens revokes every access token issued to username so far,
+// all of the user's refresh token sessions, API keys and signing keys.
+// retainUntil must not be earlier than the expiry of the longest-lived token
+// that could have been issued.
+func (s *HPLService) RevokeUserTokens(ctx context.Context, username string, retainUntil time.Time) error {
+	if err := s.revocations.RevokeUserTokens(ctx, username, time.Now(), retainUntil); err != nil {
+		return err
+	}
+	if err := s.store.RevokeUserSessions(ctx, username); err != nil {
+		return err
+	}
+	if err := s.store.RevokeUserAPIKeys(ctx, username); err != nil {
+		return err
+	}
+	return s.store.RevokeUserSigningKeys(ctx, username)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+)
+
+var ErrInvalidRole = errors.New("invalid role")
+
+// validRoles 必須與 users_roles_check 限制一致
+var validRoles = map[string]bool{
+	token.RoleParticipant: true,
+	token.RoleJudge:       true,
+	token.RoleAdmin:       true,
+}
+
+func (s *HPLService) GetUser(ctx context.Context, username string) (*db.User, error) {
+	user, err := s.store.GetUser(ctx, username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateUserRoles replaces the roles of username
+func (s *HPLService) UpdateUserRoles(ctx context.Context, username string, roles []string) (*db.User, error) {
+	for _, role := range roles {
+		if !validRoles[role] {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRole, role)
+		}
+	}
+
+	user, err := s.store.UpdateUserRoles(ctx, db.UpdateUserRolesParams{
+		Roles:    roles,
+		Username: username,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GrantRole adds role to username if the user exists and does not have it yet
+func (s *HPLService) GrantRole(ctx context.Context, username string, role string) error {
+	if !validRoles[role] {
+		return fmt.Errorf("%w: %q", ErrInvalidRole, role)
+	}
+	return s.store.AddUserRole(ctx, db.AddUserRoleParams{
+		Role:     role,
+		Username: username,
+	})
+}
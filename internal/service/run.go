@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+)
+
+const (
+	// DefaultRunNonceTTL 是 run nonce 預設的有效時間，需涵蓋一次完整的 HPL 執行
+	DefaultRunNonceTTL = 24 * time.Hour
+
+	runNoncePrefix = "hplr_"
+	runNonceBytes  = 16
+)
+
+var (
+	ErrRunNonceRequired         = errors.New("run_nonce is required")
+	ErrInvalidRunNonce          = errors.New("run nonce is invalid, expired or already used")
+	ErrExecutionTimeOutsideRun  = errors.New("execution_time does not fit between run nonce issuance and submission")
+	ErrOutsideCompetitionWindow = errors.New("outside the competition window")
+)
+
+// WithRunNonceTTL 設定 run nonce 的有效時間
+func WithRunNonceTTL(ttl time.Duration) Option {
+	return func(s *HPLService) {
+		s.runNonceTTL = ttl
+	}
+}
+
+// WithCompetitionWindow 只在 [start, end) 之間核發 run nonce，且 nonce 最晚在 end 失效。
+// 零值代表該端不設限。
+func WithCompetitionWindow(start time.Time, end time.Time) Option {
+	return func(s *HPLService) {
+		s.competitionStart = start
+		s.competitionEnd = end
+	}
+}
+
+// IssueRunNonce starts a run for username. The nonce is embedded in the run's
+// output and must accompany the resulting score.
+func (s *HPLService) IssueRunNonce(ctx context.Context, username string) (*db.RunNonce, error) {
+	now := time.Now()
+	if (!s.competitionStart.IsZero() && now.Before(s.competitionStart)) ||
+		(!s.competitionEnd.IsZero() && !now.Before(s.competitionEnd)) {
+		return nil, ErrOutsideCompetitionWindow
+	}
+
+	expiresAt := now.Add(s.runNonceTTL)
+	if !s.competitionEnd.IsZero() && expiresAt.After(s.competitionEnd) {
+		expiresAt = s.competitionEnd
+	}
+
+	random := make([]byte, runNonceBytes)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+
+	runNonce, err := s.store.CreateRunNonce(ctx, db.CreateRunNonceParams{
+		Nonce:     runNoncePrefix + hex.EncodeToString(random),
+		Username:  username,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &runNonce, nil
+}
+
+// checkRunNonce 確認 nonce 屬於 username 且仍可使用，並且 executionTime 秒
+// 能放進核發到 submittedAt 之間。真正的消耗在 CreateScoresTx 內完成。
+func (s *HPLService) checkRunNonce(ctx context.Context, nonce string, username string, executionTime float64, submittedAt time.Time) error {
+	if nonce == "" {
+		return ErrRunNonceRequired
+	}
+
+	runNonce, err := s.store.GetRunNonce(ctx, nonce)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvalidRunNonce
+		}
+		return err
+	}
+	if runNonce.Username != username || runNonce.UsedAt.Valid || !submittedAt.Before(runNonce.ExpiresAt) {
+		return ErrInvalidRunNonce
+	}
+
+	elapsed := submittedAt.Sub(runNonce.IssuedAt).Seconds()
+	if executionTime <= 0 || executionTime > elapsed {
+		return ErrExecutionTimeOutsideRun
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	db_mocks "github.com/kdotwei/hpl-scoreboard/internal/db/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckRunNonce(t *testing.T) {
+	submittedAt := time.Now()
+	// 一小時前核發，仍未使用
+	issued := db.RunNonce{
+		Nonce:     "hplr_0123456789abcdef0123456789abcdef",
+		Username:  "agent-lead",
+		IssuedAt:  submittedAt.Add(-time.Hour),
+		ExpiresAt: submittedAt.Add(23 * time.Hour),
+	}
+
+	testCases := []struct {
+		name          string
+		nonce         string
+		username      string
+		executionTime float64
+		setupMock     func(*db_mocks.Store)
+		wantErr       error
+	}{
+		{
+			name:          "valid",
+			nonce:         issued.Nonce,
+			username:      "agent-lead",
+			executionTime: 1800,
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetRunNonce", mock.Anything, issued.Nonce).Return(issued, nil)
+			},
+		},
+		{
+			name:          "missing nonce",
+			nonce:         "",
+			username:      "agent-lead",
+			executionTime: 1800,
+			setupMock:     func(*db_mocks.Store) {},
+			wantErr:       ErrRunNonceRequired,
+		},
+		{
+			name:          "unknown nonce",
+			nonce:         "hplr_unknown",
+			username:      "agent-lead",
+			executionTime: 1800,
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetRunNonce", mock.Anything, "hplr_unknown").Return(db.RunNonce{}, pgx.ErrNoRows)
+			},
+			wantErr: ErrInvalidRunNonce,
+		},
+		{
+			name:          "issued to another user",
+			nonce:         issued.Nonce,
+			username:      "someone-else",
+			executionTime: 1800,
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetRunNonce", mock.Anything, issued.Nonce).Return(issued, nil)
+			},
+			wantErr: ErrInvalidRunNonce,
+		},
+		{
+			name:          "already used",
+			nonce:         issued.Nonce,
+			username:      "agent-lead",
+			executionTime: 1800,
+			setupMock: func(store *db_mocks.Store) {
+				used := issued
+				used.UsedAt = pgtype.Timestamptz{Time: submittedAt.Add(-time.Minute), Valid: true}
+				store.On("GetRunNonce", mock.Anything, issued.Nonce).Return(used, nil)
+			},
+			wantErr: ErrInvalidRunNonce,
+		},
+		{
+			name:          "expired",
+			nonce:         issued.Nonce,
+			username:      "agent-lead",
+			executionTime: 1800,
+			setupMock: func(store *db_mocks.Store) {
+				expired := issued
+				expired.ExpiresAt = submittedAt.Add(-time.Minute)
+				store.On("GetRunNonce", mock.Anything, issued.Nonce).Return(expired, nil)
+			},
+			wantErr: ErrInvalidRunNonce,
+		},
+		{
+			name:          "longer than the time since issuance",
+			nonce:         issued.Nonce,
+			username:      "agent-lead",
+			executionTime: 3601,
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetRunNonce", mock.Anything, issued.Nonce).Return(issued, nil)
+			},
+			wantErr: ErrExecutionTimeOutsideRun,
+		},
+		{
+			name:          "zero execution time",
+			nonce:         issued.Nonce,
+			username:      "agent-lead",
+			executionTime: 0,
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetRunNonce", mock.Anything, issued.Nonce).Return(issued, nil)
+			},
+			wantErr: ErrExecutionTimeOutsideRun,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := new(db_mocks.Store)
+			tc.setupMock(store)
+			s := NewService(store, nil)
+
+			err := s.checkRunNonce(context.Background(), tc.nonce, tc.username, tc.executionTime, submittedAt)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateScoresTotalExecutionTime(t *testing.T) {
+	nonce := "hplr_0123456789abcdef0123456789abcdef"
+	// 40 分鐘前核發；兩次 30 分鐘的執行各自放得進，加起來放不進
+	issued := db.RunNonce{
+		Nonce:     nonce,
+		Username:  "agent-lead",
+		IssuedAt:  time.Now().Add(-40 * time.Minute),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	result := CreateScoreParams{
+		UserID:        "agent-lead",
+		Benchmark:     BenchmarkHPL,
+		Gflops:        ExpectedGflops(40000, 1800),
+		N:             40000,
+		NB:            192,
+		P:             2,
+		Q:             4,
+		Ranks:         8,
+		Nodes:         2,
+		RanksPerNode:  4,
+		ExecutionTime: 1800,
+		RunNonce:      nonce,
+	}
+
+	store := new(db_mocks.Store)
+	store.On("GetRunNonce", mock.Anything, nonce).Return(issued, nil).Twice()
+	s := NewService(store, nil)
+
+	err := s.checkRunNonce(context.Background(), nonce, "agent-lead", result.ExecutionTime, time.Now())
+	assert.NoError(t, err)
+
+	_, err = s.createScores(context.Background(), []CreateScoreParams{result, result})
+	assert.ErrorIs(t, err, ErrExecutionTimeOutsideRun)
+	store.AssertExpectations(t)
+}
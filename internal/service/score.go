@@ -2,50 +2,268 @@ package service
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kdotwei/hpl-scoreboard/internal/db"
 )
 
+var ErrScoreNotFound = errors.New("score not found")
+
+// CreateScore records a single score after the checks described on
+// createScores.
 func (s *HPLService) CreateScore(ctx context.Context, arg CreateScoreParams) (*db.Score, error) {
-	result, err := s.store.CreateScore(ctx, db.CreateScoreParams{
-		UserID:        arg.UserID,
-		Gflops:        arg.Gflops,
-		ProblemSizeN:  int32(arg.ProblemSizeN),
-		BlockSizeNb:   int32(arg.BlockSizeNb),
-		LinuxUsername: arg.LinuxUsername,
-		N:             int32(arg.N),
-		Nb:            int32(arg.NB), // 修正編譯錯誤：sqlc 生成的是 Nb
-		P:             int32(arg.P),
-		Q:             int32(arg.Q),
-		ExecutionTime: arg.ExecutionTime,
-		SubmittedAt:   time.Now(), // 確保帶上時間戳記
+	scores, err := s.createScores(ctx, []CreateScoreParams{arg})
+	if err != nil {
+		return nil, err
+	}
+	return &scores[0], nil
+}
+
+// UploadScores records one score per result of an HPL.out file. The results
+// share a run nonce and are checked the same way as CreateScore; since HPL
+// runs them one after another, their total time must fit in the run. Results
+// that failed the residual check are recorded like CreateScore does, but the
+// PASSED / FAILED printed by HPL must agree with the residual and threshold.
+func (s *HPLService) UploadScores(ctx context.Context, arg UploadScoresParams) ([]db.Score, error) {
+	args := make([]CreateScoreParams, 0, len(arg.Results))
+	for _, result := range arg.Results {
+		// HPL 跳過 residual check 時不記錄 residual 與門檻
+		var residual *float64
+		var threshold float64
+		var passed *bool
+		if result.Checked {
+			residual, threshold, passed = &result.Residual, arg.ResidualThreshold, &result.Passed
+		}
+		args = append(args, CreateScoreParams{
+			UserID:            arg.UserID,
+			Gflops:            result.Gflops,
+			ProblemSizeN:      result.N,
+			BlockSizeNb:       result.NB,
+			LinuxUsername:     arg.LinuxUsername,
+			N:                 result.N,
+			NB:                result.NB,
+			P:                 result.P,
+			Q:                 result.Q,
+			ExecutionTime:     result.Time,
+			ClientIdentity:    arg.ClientIdentity,
+			TeamID:            arg.TeamID,
+			RunNonce:          arg.RunNonce,
+			Variant:           result.Variant,
+			Residual:          residual,
+			ResidualThreshold: threshold,
+			ResidualPassed:    passed,
+			Ranks:             arg.Ranks,
+			Nodes:             arg.Nodes,
+			RanksPerNode:      arg.RanksPerNode,
+			SystemID:          arg.SystemID,
+		})
+	}
+	return s.createScores(ctx, args)
+}
+
+// createScores 在同一個交易中記錄一次執行的所有成績。
+// 送出者、隊伍與 run nonce 等欄位各筆相同，取自第一筆。依序檢查：
+//   - benchmark 與其指標：ErrInvalidBenchmark、ErrInvalidMetrics
+//   - P × Q 與 ranks、nodes：ErrInvalidRankLayout
+//   - HPL 類的 gflops 與 n、execution_time：ErrGflopsMismatch
+//   - variant、residual 與功耗：ErrInvalidVariant、ErrInvalidResidual、ErrResidualVerdictMismatch、ErrInvalidEnergy
+//   - run nonce 與總執行時間：ErrRunNonceRequired、ErrInvalidRunNonce、ErrExecutionTimeOutsideRun
+//   - 系統與 Rpeak：ErrSystemRequired、ErrSystemNotFound、ErrExceedsRpeak
+//   - 隊伍成員與 linux_username：ErrNotTeamMember、ErrLinuxUsernameNotVerified
+//
+// 超過審核門檻的成績進入審核佇列，未通過 residual check 的成績會記錄但不列入排行榜。
+func (s *HPLService) createScores(ctx context.Context, args []CreateScoreParams) ([]db.Score, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no scores to create")
+	}
+	submitter := args[0]
+	submittedAt := time.Now()
+
+	var executionTime float64
+	expectedGflops := make([]float64, len(args))
+	residuals := make([]pgtype.Float8, len(args))
+	thresholds := make([]pgtype.Float8, len(args))
+	passed := make([]pgtype.Bool, len(args))
+	powers := make([]pgtype.Float8, len(args))
+	energies := make([]pgtype.Float8, len(args))
+	for i, arg := range args {
+		if arg.Benchmark == "" {
+			arg.Benchmark = BenchmarkHPL
+			args[i].Benchmark = BenchmarkHPL
+		}
+		if !ValidBenchmark(arg.Benchmark) {
+			return nil, ErrInvalidBenchmark
+		}
+		if err := checkBenchmarkMetrics(arg); err != nil {
+			return nil, err
+		}
+
+		if arg.ExecutionTime <= 0 {
+			return nil, ErrExecutionTimeOutsideRun
+		}
+		executionTime += arg.ExecutionTime
+
+		if err := checkRankLayout(arg); err != nil {
+			return nil, err
+		}
+
+		var err error
+		expectedGflops[i], err = s.checkBenchmarkGflops(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		residuals[i], thresholds[i], passed[i], err = residualCheck(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		powers[i], energies[i], err = energyCheck(arg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkRunNonce(ctx, submitter.RunNonce, submitter.UserID, executionTime, submittedAt); err != nil {
+		return nil, err
+	}
+
+	if submitter.SystemID == uuid.Nil {
+		return nil, ErrSystemRequired
+	}
+	system, err := s.GetSystem(ctx, submitter.SystemID)
+	if err != nil {
+		return nil, err
+	}
+	rpeaks := make([]pgtype.Float8, len(args))
+	statuses := make([]string, len(args))
+	reasons := make([]string, len(args))
+	for i, arg := range args {
+		if err := checkSystemNodes(arg, system); err != nil {
+			return nil, err
+		}
+		// 以實際使用的節點數計算峰值；STREAM 沒有浮點運算，不記錄峰值
+		rpeak := system.NodeRpeakGflops * float64(arg.Nodes)
+		if arg.Benchmark != BenchmarkStream {
+			rpeaks[i] = pgtype.Float8{Float64: rpeak, Valid: true}
+		}
+		statuses[i], reasons[i], err = s.reviewDecision(arg.Benchmark, arg.Gflops, rpeak, system.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var teamID pgtype.UUID
+	if submitter.TeamID != uuid.Nil {
+		if err := s.requireTeamMember(ctx, submitter.TeamID, submitter.UserID); err != nil {
+			return nil, err
+		}
+		teamID = pgtype.UUID{Bytes: submitter.TeamID, Valid: true}
+	}
+
+	verified, err := s.store.IsLinuxAccountVerified(ctx, db.IsLinuxAccountVerifiedParams{
+		LinuxUsername: submitter.LinuxUsername,
+		Username:      submitter.UserID,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	if !verified && s.requireVerifiedLinuxUsername {
+		return nil, ErrLinuxUsernameNotVerified
+	}
+
+	txArg := db.CreateScoresTxParams{
+		RunNonce: submitter.RunNonce,
+		Username: submitter.UserID,
+	}
+	for i, arg := range args {
+		txArg.Scores = append(txArg.Scores, db.CreateScoreParams{
+			UserID:                submitter.UserID,
+			Gflops:                arg.Gflops,
+			ProblemSizeN:          int32(arg.ProblemSizeN),
+			BlockSizeNb:           int32(arg.BlockSizeNb),
+			LinuxUsername:         submitter.LinuxUsername,
+			N:                     int32(arg.N),
+			Nb:                    int32(arg.NB), // 修正編譯錯誤：sqlc 生成的是 Nb
+			P:                     int32(arg.P),
+			Q:                     int32(arg.Q),
+			ExecutionTime:         arg.ExecutionTime,
+			SubmittedAt:           submittedAt, // 確保帶上時間戳記
+			LinuxUsernameVerified: verified,
+			ClientIdentity:        submitter.ClientIdentity,
+			TeamID:                teamID,
+			RunNonce:              submitter.RunNonce,
+			ExpectedGflops:        expectedGflops[i],
+			Variant:               arg.Variant,
+			Residual:              residuals[i],
+			ResidualThreshold:     thresholds[i],
+			ResidualPassed:        passed[i],
+			Ranks:                 int32(arg.Ranks),
+			Nodes:                 int32(arg.Nodes),
+			RanksPerNode:          int32(arg.RanksPerNode),
+			SystemID:              system.ID,
+			RpeakGflops:           rpeaks[i],
+			ReviewStatus:          statuses[i],
+			ReviewReason:          reasons[i],
+			AvgPowerWatts:         powers[i],
+			EnergyJoules:          energies[i],
+			Benchmark:             arg.Benchmark,
+			HpcgNx:                int32(arg.HPCGNx),
+			HpcgNy:                int32(arg.HPCGNy),
+			HpcgNz:                int32(arg.HPCGNz),
+			StreamCopyMbs:         streamBandwidth(arg.StreamCopyMBs),
+			StreamScaleMbs:        streamBandwidth(arg.StreamScaleMBs),
+			StreamAddMbs:          streamBandwidth(arg.StreamAddMBs),
+			StreamTriadMbs:        streamBandwidth(arg.StreamTriadMBs),
+			MxpPrecision:          arg.MxPPrecision,
+		})
+	}
+
+	scores, err := s.store.CreateScoresTx(ctx, txArg)
+	if err != nil {
+		if errors.Is(err, db.ErrRunNonceInvalid) {
+			return nil, ErrInvalidRunNonce
+		}
+		return nil, err
+	}
+	return scores, nil
 }
 
+// ListScores lists the top HPL scores, hiding the ones that failed the residual check
 func (s *HPLService) ListScores(ctx context.Context, limit int32, offset int32) ([]db.Score, error) {
 	return s.store.ListTopScores(ctx, db.ListTopScoresParams{
-		Limit:  limit,
-		Offset: offset,
+		Benchmark: BenchmarkHPL,
+		Limit:     limit,
+		Offset:    offset,
 	})
 }
 
 func (s *HPLService) ListScoresWithPagination(ctx context.Context, params ListScoresParams) (*PaginatedScoresResponse, error) {
 	// Get scores with pagination
+	benchmark := params.Benchmark
+	if benchmark == "" {
+		benchmark = BenchmarkHPL
+	}
+
 	scores, err := s.store.ListTopScores(ctx, db.ListTopScoresParams{
-		Limit:  params.Limit,
-		Offset: params.Offset,
+		Benchmark:      benchmark,
+		ResidualFilter: params.Residual,
+		SortBy:         params.Sort,
+		Limit:          params.Limit,
+		Offset:         params.Offset,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	// Get total count for frontend reference
-	totalRecords, err := s.store.CountTotalScores(ctx)
+	totalRecords, err := s.store.CountTotalScores(ctx, db.CountTotalScoresParams{
+		Benchmark:      benchmark,
+		ResidualFilter: params.Residual,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -63,3 +281,30 @@ func (s *HPLService) ListScoresWithPagination(ctx context.Context, params ListSc
 
 	return response, nil
 }
+
+func (s *HPLService) DeleteScore(ctx context.Context, id uuid.UUID) error {
+	rows, err := s.store.DeleteScore(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrScoreNotFound
+	}
+	return nil
+}
+
+// DisqualifyScore hides a score from leaderboards while keeping it on record
+func (s *HPLService) DisqualifyScore(ctx context.Context, arg DisqualifyScoreParams) (*db.Score, error) {
+	score, err := s.store.DisqualifyScore(ctx, db.DisqualifyScoreParams{
+		DisqualifiedBy:         arg.DisqualifiedBy,
+		DisqualificationReason: arg.Reason,
+		ID:                     pgtype.UUID{Bytes: arg.ID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrScoreNotFound
+		}
+		return nil, err
+	}
+	return &score, nil
+}
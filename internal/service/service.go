@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/hplout"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
 )
 
 // CreateScoreParams 是 Service 層的輸入參數
@@ -18,12 +22,174 @@ type CreateScoreParams struct {
 	P             int
 	Q             int
 	ExecutionTime float64
+	// ClientIdentity 是以 mTLS 送出時憑證對應的身分，其他方式送出時為空字串
+	ClientIdentity string
+	// TeamID 不為 uuid.Nil 時成績屬於該隊伍，UserID 必須是隊伍成員
+	TeamID uuid.UUID
+	// RunNonce 是執行前由 IssueRunNonce 核發的 nonce
+	RunNonce string
+	// Variant 是 HPL 的 T/V 欄位，例如 WR11C2R4，可為空字串
+	Variant string
+	// Residual 是 scaled residual，nil 代表沒有回報；ResidualThreshold 為 0 時使用 DefaultResidualThreshold
+	Residual          *float64
+	ResidualThreshold float64
+	// ResidualPassed 是 HPL 印出的 PASSED / FAILED，nil 代表沒有；
+	// 與依門檻重新判斷的結果不同時拒絕 (ErrResidualVerdictMismatch)
+	ResidualPassed *bool
+	// Ranks 必須等於 P × Q 與 Nodes × RanksPerNode
+	Ranks        int
+	Nodes        int
+	RanksPerNode int
+	// SystemID 是執行的系統，Nodes 不能超過系統的節點數
+	SystemID uuid.UUID
+	// AvgPowerWatts 與 EnergyJoules 為選填，nil 代表沒有量測；兩者皆有時必須與 ExecutionTime 一致
+	AvgPowerWatts *float64
+	EnergyJoules  *float64
+	// Benchmark 為 Benchmark 其中之一，空字串代表 hpl
+	Benchmark string
+	// HPCGNx、HPCGNy 與 HPCGNz 是 HPCG 每個 rank 的 local grid，只用於 hpcg
+	HPCGNx int
+	HPCGNy int
+	HPCGNz int
+	// Stream 頻寬 (MB/s) 只用於 stream，此時 Gflops 必須為 0
+	StreamCopyMBs  float64
+	StreamScaleMBs float64
+	StreamAddMBs   float64
+	StreamTriadMBs float64
+	// MxPPrecision 是 HPL-MxP 的 LU 分解精度，只用於 hpl-mxp
+	MxPPrecision string
+}
+
+// UploadScoresParams describes the results of one HPL.out file and who
+// submitted them
+type UploadScoresParams struct {
+	UserID         string
+	LinuxUsername  string
+	ClientIdentity string
+	TeamID         uuid.UUID
+	RunNonce       string
+	// Ranks、Nodes 與 RanksPerNode 由送出者填寫，每個結果的 P × Q 都必須等於 Ranks
+	Ranks        int
+	Nodes        int
+	RanksPerNode int
+	SystemID     uuid.UUID
+	// ResidualThreshold 是 HPL.out 中的 residual 門檻，0 代表檔案中沒有
+	ResidualThreshold float64
+	Results           []hplout.Result
+}
+
+// CreateUserParams contains the fields needed to register a new user
+type CreateUserParams struct {
+	Username string
+	Password string
+}
+
+// EnsureOIDCUserParams identifies a user asserted by an OIDC identity provider
+type EnsureOIDCUserParams struct {
+	Issuer   string
+	Subject  string
+	Username string
+}
+
+// CreateSessionParams describes a refresh token session started at login.
+// ID is the refresh token's Payload.ID.
+type CreateSessionParams struct {
+	ID        uuid.UUID
+	Username  string
+	UserAgent string
+	ClientIP  string
+	ExpiresAt time.Time
+}
+
+// RotateSessionParams describes the exchange of refresh token OldID for NewID
+type RotateSessionParams struct {
+	OldID     uuid.UUID
+	NewID     uuid.UUID
+	Username  string
+	UserAgent string
+	ClientIP  string
+	ExpiresAt time.Time
+}
+
+// DisqualifyScoreParams contains the judge's decision on a score
+type DisqualifyScoreParams struct {
+	ID             uuid.UUID
+	Reason         string
+	DisqualifiedBy string
+}
+
+// ReviewScoreParams contains the judge's decision on a score in the review
+// queue
+type ReviewScoreParams struct {
+	ID         uuid.UUID
+	Approve    bool
+	Reason     string
+	ReviewedBy string
+}
+
+// CreateAPIKeyParams describes a new API key. A zero ExpiresAt means the key
+// does not expire.
+type CreateAPIKeyParams struct {
+	Username  string
+	Name      string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+// CreateSSHKeyParams describes an SSH public key in authorized_keys format
+// that claims LinuxUsername on the cluster
+type CreateSSHKeyParams struct {
+	Username      string
+	LinuxUsername string
+	PublicKey     string
+}
+
+// CreateTeamInvitationParams describes an invitation code that can be used
+// MaxUses times before ExpiresAt
+type CreateTeamInvitationParams struct {
+	TeamID    uuid.UUID
+	CreatedBy string
+	MaxUses   int32
+	ExpiresAt time.Time
+}
+
+// UpdateTeamMemberRoleParams describes an owner changing a member's role
+type UpdateTeamMemberRoleParams struct {
+	TeamID    uuid.UUID
+	Username  string
+	Role      string
+	UpdatedBy string
+}
+
+// SystemParams describes the hardware and software of a system. Rpeak is
+// Nodes × (CoresPerNode × ClockGHz × FlopsPerCycle + AcceleratorGflopsPerNode).
+type SystemParams struct {
+	Name          string
+	Nodes         int
+	CPUModel      string
+	CoresPerNode  int
+	ClockGHz      float64
+	FlopsPerCycle int
+	// Accelerators 為說明文字，AcceleratorGflopsPerNode 是每個節點加速器的雙精度峰值
+	Accelerators             string
+	AcceleratorGflopsPerNode float64
+	Interconnect             string
+	MPI                      string
+	BLAS                     string
 }
 
 // ListScoresParams contains parameters for listing scores with pagination
 type ListScoresParams struct {
 	Limit  int32
 	Offset int32
+	// Residual 為 ResidualFilter 其中之一，預設排除 residual check 失敗的成績
+	Residual string
+	// Sort 為 ScoreSort 其中之一，預設依 gflops 排序；隊伍排行榜不使用
+	Sort string
+	// Benchmark 為 Benchmark 其中之一，空字串代表 hpl；隊伍與 green 排行榜只有 hpl
+	Benchmark string
+	// MinGflops 只用於 green 排行榜，低於設定的門檻時以設定值為準
+	MinGflops float64
 }
 
 // PaginatedScoresResponse contains the paginated scores response
@@ -35,20 +201,133 @@ type PaginatedScoresResponse struct {
 	Offset       int32      `json:"offset"`
 }
 
+// PaginatedSystemsResponse contains one page of registered systems
+type PaginatedSystemsResponse struct {
+	Systems      []db.System `json:"systems"`
+	HasMore      bool        `json:"has_more"`
+	TotalRecords int64       `json:"total_records"`
+	Limit        int32       `json:"limit"`
+	Offset       int32       `json:"offset"`
+}
+
+// TeamLeaderboardResponse contains one page of the team leaderboard
+type TeamLeaderboardResponse struct {
+	Teams        []db.ListTeamLeaderboardRow `json:"teams"`
+	HasMore      bool                        `json:"has_more"`
+	TotalRecords int64                       `json:"total_records"`
+	Limit        int32                       `json:"limit"`
+	Offset       int32                       `json:"offset"`
+}
+
 // Service 定義了業務邏輯的介面
 type Service interface {
 	CreateScore(ctx context.Context, arg CreateScoreParams) (*db.Score, error)
+	UploadScores(ctx context.Context, arg UploadScoresParams) ([]db.Score, error)
 	ListScores(ctx context.Context, limit int32, offset int32) ([]db.Score, error)
 	ListScoresWithPagination(ctx context.Context, params ListScoresParams) (*PaginatedScoresResponse, error)
+	CreateUser(ctx context.Context, arg CreateUserParams) (*db.User, error)
+	AuthenticateUser(ctx context.Context, username string, password string) (*db.User, error)
+	EnsureUser(ctx context.Context, username string) (*db.User, error)
+	EnsureOIDCUser(ctx context.Context, arg EnsureOIDCUserParams) (*db.User, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (*db.Session, error)
+	RotateSession(ctx context.Context, arg RotateSessionParams) (*db.Session, error)
+	EndSession(ctx context.Context, sessionID uuid.UUID, username string) error
+	RevokeToken(ctx context.Context, payload *token.Payload) error
+	RevokeUserTokens(ctx context.Context, username string, retainUntil time.Time) error
+	GetUser(ctx context.Context, username string) (*db.User, error)
+	UpdateUserRoles(ctx context.Context, username string, roles []string) (*db.User, error)
+	GrantRole(ctx context.Context, username string, role string) error
+	DeleteScore(ctx context.Context, id uuid.UUID) error
+	DisqualifyScore(ctx context.Context, arg DisqualifyScoreParams) (*db.Score, error)
+	ListReviewQueue(ctx context.Context, limit int32, offset int32) (*PaginatedScoresResponse, error)
+	ReviewScore(ctx context.Context, arg ReviewScoreParams) (*db.Score, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (string, *db.ApiKey, error)
+	ListAPIKeys(ctx context.Context, username string) ([]db.ApiKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID, username string) error
+	VerifyAPIKey(ctx context.Context, key string) (*token.Payload, error)
+	CreateSigningKey(ctx context.Context, username string, name string) (string, *db.SigningKey, error)
+	ListSigningKeys(ctx context.Context, username string) ([]db.SigningKey, error)
+	RevokeSigningKey(ctx context.Context, id uuid.UUID, username string) error
+	ResolveSigningKey(ctx context.Context, keyID string) ([]byte, *token.Payload, error)
+	CheckLoginLockout(ctx context.Context, username string, clientIP string) (time.Time, error)
+	RecordLoginFailure(ctx context.Context, username string, clientIP string) error
+	RecordLoginSuccess(ctx context.Context, username string) error
+	UnlockUser(ctx context.Context, username string, unlockedBy string) error
+	ListAuditEvents(ctx context.Context, limit int32, offset int32) ([]db.AuditEvent, error)
+	EnrollTOTP(ctx context.Context, username string) (*TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, username string, code string) ([]string, error)
+	VerifyMFA(ctx context.Context, username string, code string) error
+	DisableTOTP(ctx context.Context, username string, code string) error
+	ResetMFA(ctx context.Context, username string, resetBy string) error
+	CreateSSHKey(ctx context.Context, arg CreateSSHKeyParams) (*db.SshKey, error)
+	ListSSHKeys(ctx context.Context, username string) ([]db.SshKey, error)
+	DeleteSSHKey(ctx context.Context, id uuid.UUID, username string) error
+	CreateSSHKeyChallenge(ctx context.Context, id uuid.UUID, username string) (*SSHKeyChallenge, error)
+	VerifySSHKey(ctx context.Context, id uuid.UUID, username string, signature string) (*db.SshKey, error)
+	RemoveLinuxAccount(ctx context.Context, linuxUsername string, removedBy string) error
+	CreateTeam(ctx context.Context, name string, owner string) (*db.Team, error)
+	GetTeam(ctx context.Context, id uuid.UUID) (*db.Team, error)
+	ListTeamMembers(ctx context.Context, id uuid.UUID) ([]db.TeamMember, error)
+	ListUserTeams(ctx context.Context, username string) ([]db.ListUserTeamsRow, error)
+	DeleteTeam(ctx context.Context, id uuid.UUID, deletedBy string) error
+	CreateTeamInvitation(ctx context.Context, arg CreateTeamInvitationParams) (string, *db.TeamInvitation, error)
+	ListTeamInvitations(ctx context.Context, teamID uuid.UUID, username string) ([]db.TeamInvitation, error)
+	RevokeTeamInvitation(ctx context.Context, teamID uuid.UUID, id uuid.UUID, revokedBy string) error
+	JoinTeam(ctx context.Context, code string, username string) (*db.TeamMember, error)
+	UpdateTeamMemberRole(ctx context.Context, arg UpdateTeamMemberRoleParams) (*db.TeamMember, error)
+	RemoveTeamMember(ctx context.Context, teamID uuid.UUID, username string, removedBy string) error
+	ListTeamLeaderboard(ctx context.Context, params ListScoresParams) (*TeamLeaderboardResponse, error)
+	ListGreenLeaderboard(ctx context.Context, params ListScoresParams) (*PaginatedScoresResponse, error)
+	IssueRunNonce(ctx context.Context, username string) (*db.RunNonce, error)
+	CreateSystem(ctx context.Context, createdBy string, arg SystemParams) (*db.System, error)
+	GetSystem(ctx context.Context, id uuid.UUID) (*db.System, error)
+	ListSystems(ctx context.Context, limit int32, offset int32) (*PaginatedSystemsResponse, error)
+	UpdateSystem(ctx context.Context, id uuid.UUID, username string, arg SystemParams) (*db.System, error)
+	DeleteSystem(ctx context.Context, id uuid.UUID, username string) error
 }
 
 // Ensure implementation (編譯時期檢查，確保 HPLService 有實作 Service)
 // var _ Service = (*HPLService)(nil)
 
 type HPLService struct {
-	store db.Querier
+	store       db.Store
+	revocations token.RevocationStore
+	lockout     LockoutPolicy
+
+	requireVerifiedLinuxUsername bool
+
+	runNonceTTL      time.Duration
+	competitionStart time.Time
+	competitionEnd   time.Time
+
+	gflopsTolerance  float64
+	reviewEfficiency float64
+	greenMinGflops   float64
 }
 
-func NewService(store db.Querier) *HPLService {
-	return &HPLService{store: store}
+// Option 調整 HPLService 的選用設定
+type Option func(*HPLService)
+
+// RequireVerifiedLinuxUsername 拒絕 linux_username 尚未以 SSH 金鑰驗證的成績；
+// 預設只在成績上標記 linux_username_verified
+func RequireVerifiedLinuxUsername() Option {
+	return func(s *HPLService) {
+		s.requireVerifiedLinuxUsername = true
+	}
+}
+
+func NewService(store db.Store, revocations token.RevocationStore, opts ...Option) *HPLService {
+	s := &HPLService{
+		store:       store,
+		revocations: revocations,
+		lockout:     DefaultLockoutPolicy,
+		runNonceTTL: DefaultRunNonceTTL,
+
+		gflopsTolerance:  DefaultGflopsTolerance,
+		reviewEfficiency: DefaultReviewEfficiency,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+)
+
+var (
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrSessionRevoked     = errors.New("session has been revoked")
+	ErrSessionExpired     = errors.New("session has expired")
+	ErrSessionMismatch    = errors.New("session does not belong to user")
+	ErrRefreshTokenReused = errors.New("refresh token has already been used")
+)
+
+func (s *HPLService) CreateSession(ctx context.Context, arg CreateSessionParams) (*db.Session, error) {
+	familyID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.store.CreateSession(ctx, db.CreateSessionParams{
+		ID:        pgtype.UUID{Bytes: arg.ID, Valid: true},
+		FamilyID:  pgtype.UUID{Bytes: familyID, Valid: true},
+		Username:  arg.Username,
+		UserAgent: arg.UserAgent,
+		ClientIp:  arg.ClientIP,
+		ExpiresAt: arg.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RotateSession exchanges the refresh token session OldID for a new session in
+// the same family. Presenting a refresh token that was already rotated is
+// treated as theft: the whole family is revoked and ErrRefreshTokenReused is
+// returned.
+func (s *HPLService) RotateSession(ctx context.Context, arg RotateSessionParams) (*db.Session, error) {
+	oldID := pgtype.UUID{Bytes: arg.OldID, Valid: true}
+
+	session, err := s.store.GetSession(ctx, oldID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	if session.Username != arg.Username {
+		return nil, ErrSessionMismatch
+	}
+	if session.IsRevoked {
+		return nil, ErrSessionRevoked
+	}
+	if session.RotatedAt.Valid {
+		return nil, s.revokeReusedFamily(ctx, session.FamilyID)
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return nil, ErrSessionExpired
+	}
+
+	result, err := s.store.RotateSessionTx(ctx, db.RotateSessionTxParams{
+		OldSessionID: oldID,
+		NewSession: db.CreateSessionParams{
+			ID:        pgtype.UUID{Bytes: arg.NewID, Valid: true},
+			FamilyID:  session.FamilyID,
+			Username:  arg.Username,
+			UserAgent: arg.UserAgent,
+			ClientIp:  arg.ClientIP,
+			ExpiresAt: arg.ExpiresAt,
+		},
+	})
+	if err != nil {
+		// 另一個請求搶先使用了同一個 Refresh Token
+		if errors.Is(err, db.ErrSessionAlreadyRotated) {
+			return nil, s.revokeReusedFamily(ctx, session.FamilyID)
+		}
+		return nil, err
+	}
+	return &result.Session, nil
+}
+
+func (s *HPLService) revokeReusedFamily(ctx context.Context, familyID pgtype.UUID) error {
+	if err := s.store.RevokeSessionFamily(ctx, familyID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
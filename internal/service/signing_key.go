@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/token"
+)
+
+const (
+	signingKeySecretPrefix = "hpls_"
+	signingKeySecretBytes  = 32
+)
+
+var ErrSigningKeyNotFound = errors.New("signing key not found")
+
+// CreateSigningKey generates a new HMAC signing key for username. The secret
+// is returned only here; clients sign submissions with it and send the key
+// id alongside.
+func (s *HPLService) CreateSigningKey(ctx context.Context, username string, name string) (string, *db.SigningKey, error) {
+	random := make([]byte, signingKeySecretBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", nil, err
+	}
+	secret := signingKeySecretPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	signingKey, err := s.store.CreateSigningKey(ctx, db.CreateSigningKeyParams{
+		Username: username,
+		Name:     name,
+		Secret:   secret,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return secret, &signingKey, nil
+}
+
+func (s *HPLService) ListSigningKeys(ctx context.Context, username string) ([]db.SigningKey, error) {
+	return s.store.ListSigningKeys(ctx, username)
+}
+
+// RevokeSigningKey revokes one of username's signing keys
+func (s *HPLService) RevokeSigningKey(ctx context.Context, id uuid.UUID, username string) error {
+	rows, err := s.store.RevokeSigningKey(ctx, db.RevokeSigningKeyParams{
+		ID:       pgtype.UUID{Bytes: id, Valid: true},
+		Username: username,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrSigningKeyNotFound
+	}
+	return nil
+}
+
+// ResolveSigningKey implements middleware.SigningKeyResolver. Unknown and
+// revoked keys yield token.ErrInvalidToken.
+func (s *HPLService) ResolveSigningKey(ctx context.Context, keyID string) ([]byte, *token.Payload, error) {
+	id, err := uuid.Parse(keyID)
+	if err != nil {
+		return nil, nil, token.ErrInvalidToken
+	}
+
+	signingKey, err := s.store.GetActiveSigningKey(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, token.ErrInvalidToken
+		}
+		return nil, nil, err
+	}
+
+	// 簽章金鑰只用於送出成績
+	payload := &token.Payload{
+		ID:        signingKey.ID.Bytes,
+		Username:  signingKey.Username,
+		TokenType: token.TokenTypeSigningKey,
+		Scopes:    []string{token.ScopeScoresSubmit},
+		IssuedAt:  signingKey.CreatedAt,
+	}
+	return []byte(signingKey.Secret), payload, nil
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	"github.com/kdotwei/hpl-scoreboard/internal/sshsig"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// SSHSignatureNamespace 是 ssh-keygen -Y sign -n 必須帶入的 namespace
+	SSHSignatureNamespace = "hpl-scoreboard"
+
+	sshKeyChallengeTTL   = 10 * time.Minute
+	sshKeyChallengeBytes = 24
+	minRSAKeyBits        = 2048
+)
+
+var (
+	ErrSSHKeyNotFound           = errors.New("ssh key not found")
+	ErrSSHKeyExists             = errors.New("ssh key already registered")
+	ErrInvalidSSHKey            = errors.New("invalid ssh public key")
+	ErrInvalidSSHSignature      = errors.New("invalid ssh signature")
+	ErrSSHKeyChallengeInvalid   = errors.New("no active ssh key challenge")
+	ErrInvalidLinuxUsername     = errors.New("invalid linux username")
+	ErrLinuxAccountTaken        = errors.New("linux username is bound to another user")
+	ErrLinuxAccountNotFound     = errors.New("linux account not found")
+	ErrLinuxUsernameNotVerified = errors.New("linux username is not verified for this user")
+)
+
+// linuxUsernamePattern 與 useradd 的預設規則相近；challenge 以 ':' 分隔，因此不允許 ':'
+var linuxUsernamePattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,31}$`)
+
+// SSHKeyChallenge is the text a user signs with `ssh-keygen -Y sign` to prove
+// they hold the private key
+type SSHKeyChallenge struct {
+	Challenge string
+	Namespace string
+	ExpiresAt time.Time
+}
+
+// parseSSHPublicKey 接受 authorized_keys 格式的單行公鑰，拒絕 DSA 與過短的 RSA 金鑰
+func parseSSHPublicKey(line string) (ssh.PublicKey, error) {
+	publicKey, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(line))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSSHKey, err)
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, fmt.Errorf("%w: expected a single key", ErrInvalidSSHKey)
+	}
+
+	switch publicKey.Type() {
+	case ssh.KeyAlgoDSA:
+		return nil, fmt.Errorf("%w: DSA keys are not accepted", ErrInvalidSSHKey)
+	case ssh.KeyAlgoRSA:
+		cryptoKey, ok := publicKey.(ssh.CryptoPublicKey)
+		if !ok {
+			return nil, ErrInvalidSSHKey
+		}
+		rsaKey, ok := cryptoKey.CryptoPublicKey().(*rsa.PublicKey)
+		if !ok || rsaKey.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("%w: RSA keys must be at least %d bits", ErrInvalidSSHKey, minRSAKeyBits)
+		}
+	}
+	return publicKey, nil
+}
+
+// CreateSSHKey registers an SSH public key that claims arg.LinuxUsername. The
+// claim only counts once the key has answered a challenge.
+func (s *HPLService) CreateSSHKey(ctx context.Context, arg CreateSSHKeyParams) (*db.SshKey, error) {
+	if !linuxUsernamePattern.MatchString(arg.LinuxUsername) {
+		return nil, ErrInvalidLinuxUsername
+	}
+
+	publicKey, err := parseSSHPublicKey(arg.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// 去掉註解，只保存 "type base64"
+	sshKey, err := s.store.CreateSSHKey(ctx, db.CreateSSHKeyParams{
+		Username:      arg.Username,
+		LinuxUsername: arg.LinuxUsername,
+		PublicKey:     strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+		Fingerprint:   ssh.FingerprintSHA256(publicKey),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, ErrSSHKeyExists
+		}
+		return nil, err
+	}
+	return &sshKey, nil
+}
+
+func (s *HPLService) ListSSHKeys(ctx context.Context, username string) ([]db.SshKey, error) {
+	return s.store.ListSSHKeys(ctx, username)
+}
+
+// DeleteSSHKey removes one of username's SSH keys. Linux accounts already
+// verified with it stay bound.
+func (s *HPLService) DeleteSSHKey(ctx context.Context, id uuid.UUID, username string) error {
+	rows, err := s.store.DeleteSSHKey(ctx, db.DeleteSSHKeyParams{
+		ID:       pgtype.UUID{Bytes: id, Valid: true},
+		Username: username,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrSSHKeyNotFound
+	}
+	return nil
+}
+
+// CreateSSHKeyChallenge issues a fresh single-use challenge for one of
+// username's SSH keys, replacing any previous one
+func (s *HPLService) CreateSSHKeyChallenge(ctx context.Context, id uuid.UUID, username string) (*SSHKeyChallenge, error) {
+	sshKey, err := s.store.GetSSHKey(ctx, db.GetSSHKeyParams{
+		ID:       pgtype.UUID{Bytes: id, Valid: true},
+		Username: username,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSSHKeyNotFound
+		}
+		return nil, err
+	}
+
+	nonce := make([]byte, sshKeyChallengeBytes)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	// 訊息帶入帳號與叢集帳號，簽署者可以看出自己授權的是哪一組綁定
+	challenge := &SSHKeyChallenge{
+		Challenge: strings.Join([]string{
+			SSHSignatureNamespace,
+			username,
+			sshKey.LinuxUsername,
+			base64.RawURLEncoding.EncodeToString(nonce),
+		}, ":"),
+		Namespace: SSHSignatureNamespace,
+		ExpiresAt: time.Now().Add(sshKeyChallengeTTL),
+	}
+
+	rows, err := s.store.SetSSHKeyChallenge(ctx, db.SetSSHKeyChallengeParams{
+		ID:                 sshKey.ID,
+		Username:           username,
+		Challenge:          challenge.Challenge,
+		ChallengeExpiresAt: pgtype.Timestamptz{Time: challenge.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, ErrSSHKeyNotFound
+	}
+	return challenge, nil
+}
+
+// VerifySSHKey checks an armored `ssh-keygen -Y sign` signature over the
+// key's current challenge. On success the key is marked verified and its
+// linux username is bound to username.
+func (s *HPLService) VerifySSHKey(ctx context.Context, id uuid.UUID, username string, signature string) (*db.SshKey, error) {
+	sshKey, err := s.store.GetSSHKey(ctx, db.GetSSHKeyParams{
+		ID:       pgtype.UUID{Bytes: id, Valid: true},
+		Username: username,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSSHKeyNotFound
+		}
+		return nil, err
+	}
+	if sshKey.Challenge == "" || !sshKey.ChallengeExpiresAt.Valid || time.Now().After(sshKey.ChallengeExpiresAt.Time) {
+		return nil, ErrSSHKeyChallengeInvalid
+	}
+
+	registered, _, _, _, err := ssh.ParseAuthorizedKey([]byte(sshKey.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+
+	// echo 會多帶一個換行，兩種寫法都接受
+	var signer ssh.PublicKey
+	for _, message := range []string{sshKey.Challenge, sshKey.Challenge + "\n"} {
+		signer, err = sshsig.Verify([]byte(signature), []byte(message), SSHSignatureNamespace)
+		if err == nil || errors.Is(err, sshsig.ErrMalformedSignature) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSSHSignature, err)
+	}
+	if !bytes.Equal(signer.Marshal(), registered.Marshal()) {
+		return nil, fmt.Errorf("%w: signed with a different key", ErrInvalidSSHSignature)
+	}
+
+	result, err := s.store.VerifySSHKeyTx(ctx, db.VerifySSHKeyTxParams{
+		ID:        sshKey.ID,
+		Username:  username,
+		Challenge: sshKey.Challenge,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrSSHKeyChallengeInvalid):
+			return nil, ErrSSHKeyChallengeInvalid
+		case errors.Is(err, db.ErrLinuxAccountTaken):
+			return nil, ErrLinuxAccountTaken
+		}
+		return nil, err
+	}
+	return &result.SSHKey, nil
+}
+
+// RemoveLinuxAccount releases a linux username so that another user can
+// verify it, and records who did it
+func (s *HPLService) RemoveLinuxAccount(ctx context.Context, linuxUsername string, removedBy string) error {
+	rows, err := s.store.DeleteLinuxAccount(ctx, linuxUsername)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrLinuxAccountNotFound
+	}
+
+	_, err = s.store.CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		Event:   AuditEventLinuxAccountRemoved,
+		Actor:   removedBy,
+		Subject: linuxUsername,
+	})
+	return err
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	db_mocks "github.com/kdotwei/hpl-scoreboard/internal/db/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func newSSHSigner(t *testing.T) ssh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	return signer
+}
+
+// signSSHChallenge 依 PROTOCOL.sshsig 產生與 ssh-keygen -Y sign 相同格式的簽章
+func signSSHChallenge(t *testing.T, signer ssh.Signer, message string) string {
+	digest := sha512.Sum512([]byte(message))
+	data := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      []byte
+		HashAlgorithm string
+		Hash          []byte
+	}{SSHSignatureNamespace, nil, "sha512", digest[:]})...)
+
+	signature, err := signer.Sign(rand.Reader, data)
+	require.NoError(t, err)
+
+	blob := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      []byte
+		HashAlgorithm string
+		Signature     []byte
+	}{1, signer.PublicKey().Marshal(), SSHSignatureNamespace, nil, "sha512", ssh.Marshal(signature)})...)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "SSH SIGNATURE", Bytes: blob}))
+}
+
+func TestVerifySSHKey(t *testing.T) {
+	id := uuid.New()
+	signer := newSSHSigner(t)
+	otherSigner := newSSHSigner(t)
+
+	challenge := "hpl-scoreboard:agent-lead:lead:bm9uY2U"
+	sshKey := db.SshKey{
+		ID:                 pgtype.UUID{Bytes: id, Valid: true},
+		Username:           "agent-lead",
+		LinuxUsername:      "lead",
+		PublicKey:          string(ssh.MarshalAuthorizedKey(signer.PublicKey())),
+		Challenge:          challenge,
+		ChallengeExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+	}
+	getArg := db.GetSSHKeyParams{ID: sshKey.ID, Username: "agent-lead"}
+	verifyArg := db.VerifySSHKeyTxParams{ID: sshKey.ID, Username: "agent-lead", Challenge: challenge}
+	verified := sshKey
+	verified.VerifiedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name      string
+		signature string
+		setupMock func(*db_mocks.Store)
+		wantErr   error
+	}{
+		{
+			name:      "signed challenge",
+			signature: signSSHChallenge(t, signer, challenge),
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetSSHKey", mock.Anything, getArg).Return(sshKey, nil)
+				store.On("VerifySSHKeyTx", mock.Anything, verifyArg).Return(db.VerifySSHKeyTxResult{SSHKey: verified}, nil)
+			},
+		},
+		{
+			// echo "$challenge" | ssh-keygen -Y sign 會簽到結尾的換行
+			name:      "signed challenge with trailing newline",
+			signature: signSSHChallenge(t, signer, challenge+"\n"),
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetSSHKey", mock.Anything, getArg).Return(sshKey, nil)
+				store.On("VerifySSHKeyTx", mock.Anything, verifyArg).Return(db.VerifySSHKeyTxResult{SSHKey: verified}, nil)
+			},
+		},
+		{
+			name:      "signed challenge with two trailing newlines",
+			signature: signSSHChallenge(t, signer, challenge+"\n\n"),
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetSSHKey", mock.Anything, getArg).Return(sshKey, nil)
+			},
+			wantErr: ErrInvalidSSHSignature,
+		},
+		{
+			name:      "signed another message",
+			signature: signSSHChallenge(t, signer, "hpl-scoreboard:agent-lead:lead:b3RoZXI"),
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetSSHKey", mock.Anything, getArg).Return(sshKey, nil)
+			},
+			wantErr: ErrInvalidSSHSignature,
+		},
+		{
+			name:      "signed with a different key",
+			signature: signSSHChallenge(t, otherSigner, challenge),
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetSSHKey", mock.Anything, getArg).Return(sshKey, nil)
+			},
+			wantErr: ErrInvalidSSHSignature,
+		},
+		{
+			name:      "malformed signature",
+			signature: "not a signature",
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetSSHKey", mock.Anything, getArg).Return(sshKey, nil)
+			},
+			wantErr: ErrInvalidSSHSignature,
+		},
+		{
+			name:      "key not found",
+			signature: signSSHChallenge(t, signer, challenge),
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetSSHKey", mock.Anything, getArg).Return(db.SshKey{}, pgx.ErrNoRows)
+			},
+			wantErr: ErrSSHKeyNotFound,
+		},
+		{
+			name:      "no challenge",
+			signature: signSSHChallenge(t, signer, challenge),
+			setupMock: func(store *db_mocks.Store) {
+				missing := sshKey
+				missing.Challenge = ""
+				missing.ChallengeExpiresAt = pgtype.Timestamptz{}
+				store.On("GetSSHKey", mock.Anything, getArg).Return(missing, nil)
+			},
+			wantErr: ErrSSHKeyChallengeInvalid,
+		},
+		{
+			name:      "expired challenge",
+			signature: signSSHChallenge(t, signer, challenge),
+			setupMock: func(store *db_mocks.Store) {
+				expired := sshKey
+				expired.ChallengeExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Second), Valid: true}
+				store.On("GetSSHKey", mock.Anything, getArg).Return(expired, nil)
+			},
+			wantErr: ErrSSHKeyChallengeInvalid,
+		},
+		{
+			// 驗證期間另一個請求已經用掉這個 challenge
+			name:      "challenge consumed concurrently",
+			signature: signSSHChallenge(t, signer, challenge),
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetSSHKey", mock.Anything, getArg).Return(sshKey, nil)
+				store.On("VerifySSHKeyTx", mock.Anything, verifyArg).Return(db.VerifySSHKeyTxResult{}, db.ErrSSHKeyChallengeInvalid)
+			},
+			wantErr: ErrSSHKeyChallengeInvalid,
+		},
+		{
+			name:      "linux account bound to another user",
+			signature: signSSHChallenge(t, signer, challenge),
+			setupMock: func(store *db_mocks.Store) {
+				store.On("GetSSHKey", mock.Anything, getArg).Return(sshKey, nil)
+				store.On("VerifySSHKeyTx", mock.Anything, verifyArg).Return(db.VerifySSHKeyTxResult{}, db.ErrLinuxAccountTaken)
+			},
+			wantErr: ErrLinuxAccountTaken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := new(db_mocks.Store)
+			tc.setupMock(store)
+			s := NewService(store, nil)
+
+			key, err := s.VerifySSHKey(context.Background(), id, "agent-lead", tc.signature)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, key)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, key.VerifiedAt.Valid)
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+)
+
+const foreignKeyViolation = "23503"
+
+var (
+	ErrSystemNotFound   = errors.New("system not found")
+	ErrSystemNameTaken  = errors.New("system name already exists")
+	ErrNotSystemOwner   = errors.New("only the user who registered the system can change it")
+	ErrSystemInUse      = errors.New("system has scores and cannot be deleted")
+	ErrSystemNodesInUse = errors.New("system has scores on more nodes than requested")
+	ErrInvalidSystem    = errors.New("invalid system")
+	ErrSystemRequired   = errors.New("system_id is required")
+)
+
+// validateSystem 確認計算 Rpeak 需要的欄位皆為正數
+func validateSystem(arg SystemParams) error {
+	switch {
+	case arg.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidSystem)
+	case arg.Nodes <= 0 || arg.Nodes > maxRanks:
+		return fmt.Errorf("%w: nodes must be between 1 and %d", ErrInvalidSystem, maxRanks)
+	case arg.CPUModel == "":
+		return fmt.Errorf("%w: cpu_model is required", ErrInvalidSystem)
+	case arg.CoresPerNode <= 0 || arg.CoresPerNode > maxRanks:
+		return fmt.Errorf("%w: cores_per_node must be between 1 and %d", ErrInvalidSystem, maxRanks)
+	case !isFinite(arg.ClockGHz) || arg.ClockGHz <= 0:
+		return fmt.Errorf("%w: clock_ghz must be positive", ErrInvalidSystem)
+	case arg.FlopsPerCycle <= 0 || arg.FlopsPerCycle > 1024:
+		return fmt.Errorf("%w: flops_per_cycle must be between 1 and 1024", ErrInvalidSystem)
+	case !isFinite(arg.AcceleratorGflopsPerNode) || arg.AcceleratorGflopsPerNode < 0:
+		return fmt.Errorf("%w: accelerator_gflops_per_node must not be negative", ErrInvalidSystem)
+	}
+	return nil
+}
+
+// CreateSystem registers the hardware a score can be submitted for. Rpeak is
+// computed by the database from the node count and per-node specs.
+func (s *HPLService) CreateSystem(ctx context.Context, createdBy string, arg SystemParams) (*db.System, error) {
+	if err := validateSystem(arg); err != nil {
+		return nil, err
+	}
+
+	system, err := s.store.CreateSystem(ctx, db.CreateSystemParams{
+		Name:                     arg.Name,
+		Nodes:                    int32(arg.Nodes),
+		CpuModel:                 arg.CPUModel,
+		CoresPerNode:             int32(arg.CoresPerNode),
+		ClockGhz:                 arg.ClockGHz,
+		FlopsPerCycle:            int32(arg.FlopsPerCycle),
+		Accelerators:             arg.Accelerators,
+		AcceleratorGflopsPerNode: arg.AcceleratorGflopsPerNode,
+		Interconnect:             arg.Interconnect,
+		Mpi:                      arg.MPI,
+		Blas:                     arg.BLAS,
+		CreatedBy:                createdBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, ErrSystemNameTaken
+		}
+		return nil, err
+	}
+	return &system, nil
+}
+
+func (s *HPLService) GetSystem(ctx context.Context, id uuid.UUID) (*db.System, error) {
+	system, err := s.store.GetSystem(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSystemNotFound
+		}
+		return nil, err
+	}
+	return &system, nil
+}
+
+func (s *HPLService) ListSystems(ctx context.Context, limit int32, offset int32) (*PaginatedSystemsResponse, error) {
+	systems, err := s.store.ListSystems(ctx, db.ListSystemsParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalRecords, err := s.store.CountSystems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedSystemsResponse{
+		Systems:      systems,
+		HasMore:      int64(offset+int32(len(systems))) < totalRecords,
+		TotalRecords: totalRecords,
+		Limit:        limit,
+		Offset:       offset,
+	}, nil
+}
+
+// UpdateSystem replaces the specs of a system registered by username. The
+// Rpeak and efficiency of its scores follow the new specs and their review
+// status is re-evaluated (see rereviewScore). The node count cannot drop below
+// that of any score on the system (ErrSystemNodesInUse).
+func (s *HPLService) UpdateSystem(ctx context.Context, id uuid.UUID, username string, arg SystemParams) (*db.System, error) {
+	if err := validateSystem(arg); err != nil {
+		return nil, err
+	}
+	if err := s.requireSystemOwner(ctx, id, username); err != nil {
+		return nil, err
+	}
+
+	system, err := s.store.UpdateSystemTx(ctx, db.UpdateSystemTxParams{
+		UpdateSystemParams: db.UpdateSystemParams{
+			ID:                       pgtype.UUID{Bytes: id, Valid: true},
+			Name:                     arg.Name,
+			Nodes:                    int32(arg.Nodes),
+			CpuModel:                 arg.CPUModel,
+			CoresPerNode:             int32(arg.CoresPerNode),
+			ClockGhz:                 arg.ClockGHz,
+			FlopsPerCycle:            int32(arg.FlopsPerCycle),
+			Accelerators:             arg.Accelerators,
+			AcceleratorGflopsPerNode: arg.AcceleratorGflopsPerNode,
+			Interconnect:             arg.Interconnect,
+			Mpi:                      arg.MPI,
+			Blas:                     arg.BLAS,
+		},
+		ReviewScore: s.rereviewScore(arg.Name),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, ErrSystemNotFound
+		case errors.Is(err, db.ErrSystemNodesInUse):
+			return nil, ErrSystemNodesInUse
+		case errors.As(err, &pgErr) && pgErr.Code == uniqueViolation:
+			return nil, ErrSystemNameTaken
+		}
+		return nil, err
+	}
+	return &system, nil
+}
+
+// DeleteSystem removes a system registered by username. Systems that scores
+// refer to cannot be deleted (ErrSystemInUse).
+func (s *HPLService) DeleteSystem(ctx context.Context, id uuid.UUID, username string) error {
+	if err := s.requireSystemOwner(ctx, id, username); err != nil {
+		return err
+	}
+
+	rows, err := s.store.DeleteSystem(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return ErrSystemInUse
+		}
+		return err
+	}
+	if rows == 0 {
+		return ErrSystemNotFound
+	}
+	return nil
+}
+
+func (s *HPLService) requireSystemOwner(ctx context.Context, id uuid.UUID, username string) error {
+	system, err := s.GetSystem(ctx, id)
+	if err != nil {
+		return err
+	}
+	if system.CreatedBy != username {
+		return ErrNotSystemOwner
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+)
+
+var (
+	ErrTeamNotFound           = errors.New("team not found")
+	ErrTeamNameTaken          = errors.New("team name already exists")
+	ErrNotTeamMember          = errors.New("user is not a member of the team")
+	ErrNotTeamOwner           = errors.New("only team owners can do this")
+	ErrTeamMemberNotFound     = errors.New("team member not found")
+	ErrLastTeamOwner          = errors.New("a team must keep at least one owner")
+	ErrInvalidTeamRole        = errors.New("invalid team role")
+	ErrInvalidTeamInvitation  = errors.New("invitation code is invalid or expired")
+	ErrAlreadyTeamMember      = errors.New("user is already a member of the team")
+	ErrTeamInvitationNotFound = errors.New("team invitation not found")
+)
+
+// CreateTeam creates a team owned by owner
+func (s *HPLService) CreateTeam(ctx context.Context, name string, owner string) (*db.Team, error) {
+	result, err := s.store.CreateTeamTx(ctx, db.CreateTeamTxParams{
+		Name:  name,
+		Owner: owner,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, ErrTeamNameTaken
+		}
+		return nil, err
+	}
+	return &result.Team, nil
+}
+
+func (s *HPLService) GetTeam(ctx context.Context, id uuid.UUID) (*db.Team, error) {
+	team, err := s.store.GetTeam(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (s *HPLService) ListTeamMembers(ctx context.Context, id uuid.UUID) ([]db.TeamMember, error) {
+	return s.store.ListTeamMembers(ctx, pgtype.UUID{Bytes: id, Valid: true})
+}
+
+// ListUserTeams lists the teams username belongs to along with their role
+func (s *HPLService) ListUserTeams(ctx context.Context, username string) ([]db.ListUserTeamsRow, error) {
+	return s.store.ListUserTeams(ctx, username)
+}
+
+// DeleteTeam disbands a team. Its scores are kept but no longer belong to a team.
+func (s *HPLService) DeleteTeam(ctx context.Context, id uuid.UUID, deletedBy string) error {
+	if err := s.requireTeamOwner(ctx, id, deletedBy); err != nil {
+		return err
+	}
+
+	rows, err := s.store.DeleteTeam(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrTeamNotFound
+	}
+	return nil
+}
+
+// CreateTeamInvitation lets an owner invite others to the team. The code is
+// returned only here; the database keeps its hash.
+func (s *HPLService) CreateTeamInvitation(ctx context.Context, arg CreateTeamInvitationParams) (string, *db.TeamInvitation, error) {
+	if err := s.requireTeamOwner(ctx, arg.TeamID, arg.CreatedBy); err != nil {
+		return "", nil, err
+	}
+
+	code, hashedCode, err := generateGroupedCode()
+	if err != nil {
+		return "", nil, err
+	}
+
+	invitation, err := s.store.CreateTeamInvitation(ctx, db.CreateTeamInvitationParams{
+		TeamID:     pgtype.UUID{Bytes: arg.TeamID, Valid: true},
+		HashedCode: hashedCode,
+		CreatedBy:  arg.CreatedBy,
+		MaxUses:    arg.MaxUses,
+		ExpiresAt:  arg.ExpiresAt,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return code, &invitation, nil
+}
+
+func (s *HPLService) ListTeamInvitations(ctx context.Context, teamID uuid.UUID, username string) ([]db.TeamInvitation, error) {
+	if err := s.requireTeamOwner(ctx, teamID, username); err != nil {
+		return nil, err
+	}
+	return s.store.ListTeamInvitations(ctx, pgtype.UUID{Bytes: teamID, Valid: true})
+}
+
+func (s *HPLService) RevokeTeamInvitation(ctx context.Context, teamID uuid.UUID, id uuid.UUID, revokedBy string) error {
+	if err := s.requireTeamOwner(ctx, teamID, revokedBy); err != nil {
+		return err
+	}
+
+	rows, err := s.store.RevokeTeamInvitation(ctx, db.RevokeTeamInvitationParams{
+		ID:     pgtype.UUID{Bytes: id, Valid: true},
+		TeamID: pgtype.UUID{Bytes: teamID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrTeamInvitationNotFound
+	}
+	return nil
+}
+
+// JoinTeam adds username to the team the invitation code belongs to
+func (s *HPLService) JoinTeam(ctx context.Context, code string, username string) (*db.TeamMember, error) {
+	member, err := s.store.JoinTeamTx(ctx, db.JoinTeamTxParams{
+		HashedCode: hashGroupedCode(code),
+		Username:   username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrTeamInvitationInvalid) {
+			return nil, ErrInvalidTeamInvitation
+		}
+		if errors.Is(err, db.ErrAlreadyTeamMember) {
+			return nil, ErrAlreadyTeamMember
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// UpdateTeamMemberRole lets an owner promote or demote a member
+func (s *HPLService) UpdateTeamMemberRole(ctx context.Context, arg UpdateTeamMemberRoleParams) (*db.TeamMember, error) {
+	if arg.Role != db.TeamRoleOwner && arg.Role != db.TeamRoleMember {
+		return nil, ErrInvalidTeamRole
+	}
+	if err := s.requireTeamOwner(ctx, arg.TeamID, arg.UpdatedBy); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.store.UpdateTeamMemberRoleTx(ctx, db.UpdateTeamMemberRoleParams{
+		TeamID:   pgtype.UUID{Bytes: arg.TeamID, Valid: true},
+		Username: arg.Username,
+		Role:     arg.Role,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, ErrTeamMemberNotFound
+		case errors.Is(err, db.ErrLastTeamOwner):
+			return nil, ErrLastTeamOwner
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// RemoveTeamMember removes username from the team. Owners can remove anyone;
+// members can only leave themselves.
+func (s *HPLService) RemoveTeamMember(ctx context.Context, teamID uuid.UUID, username string, removedBy string) error {
+	if username != removedBy {
+		if err := s.requireTeamOwner(ctx, teamID, removedBy); err != nil {
+			return err
+		}
+	}
+
+	rows, err := s.store.RemoveTeamMemberTx(ctx, db.RemoveTeamMemberParams{
+		TeamID:   pgtype.UUID{Bytes: teamID, Valid: true},
+		Username: username,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrLastTeamOwner) {
+			return ErrLastTeamOwner
+		}
+		return err
+	}
+	if rows == 0 {
+		return ErrTeamMemberNotFound
+	}
+	return nil
+}
+
+// ListTeamLeaderboard ranks teams by their best non-disqualified score
+func (s *HPLService) ListTeamLeaderboard(ctx context.Context, params ListScoresParams) (*TeamLeaderboardResponse, error) {
+	teams, err := s.store.ListTeamLeaderboard(ctx, db.ListTeamLeaderboardParams{
+		ResidualFilter: params.Residual,
+		Limit:          params.Limit,
+		Offset:         params.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalRecords, err := s.store.CountLeaderboardTeams(ctx, params.Residual)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TeamLeaderboardResponse{
+		Teams:        teams,
+		HasMore:      int64(params.Offset+int32(len(teams))) < totalRecords,
+		TotalRecords: totalRecords,
+		Limit:        params.Limit,
+		Offset:       params.Offset,
+	}, nil
+}
+
+func (s *HPLService) getTeamMember(ctx context.Context, teamID uuid.UUID, username string) (*db.TeamMember, error) {
+	member, err := s.store.GetTeamMember(ctx, db.GetTeamMemberParams{
+		TeamID:   pgtype.UUID{Bytes: teamID, Valid: true},
+		Username: username,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTeamMemberNotFound
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// requireTeamMember 確認 username 屬於該隊伍，送出隊伍成績時使用
+func (s *HPLService) requireTeamMember(ctx context.Context, teamID uuid.UUID, username string) error {
+	if _, err := s.getTeamMember(ctx, teamID, username); err != nil {
+		if errors.Is(err, ErrTeamMemberNotFound) {
+			return ErrNotTeamMember
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *HPLService) requireTeamOwner(ctx context.Context, teamID uuid.UUID, username string) error {
+	member, err := s.getTeamMember(ctx, teamID, username)
+	if err != nil {
+		if errors.Is(err, ErrTeamMemberNotFound) {
+			return ErrNotTeamMember
+		}
+		return err
+	}
+	if member.Role != db.TeamRoleOwner {
+		return ErrNotTeamOwner
+	}
+	return nil
+}
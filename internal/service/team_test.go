@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+	db_mocks "github.com/kdotwei/hpl-scoreboard/internal/db/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// expectTeamMember 設定 GetTeamMember 的回傳；role 為空字串表示不是隊伍成員
+func expectTeamMember(store *db_mocks.Store, teamID uuid.UUID, username string, role string) {
+	arg := db.GetTeamMemberParams{TeamID: pgtype.UUID{Bytes: teamID, Valid: true}, Username: username}
+	if role == "" {
+		store.On("GetTeamMember", mock.Anything, arg).Return(db.TeamMember{}, pgx.ErrNoRows)
+		return
+	}
+	store.On("GetTeamMember", mock.Anything, arg).Return(db.TeamMember{TeamID: arg.TeamID, Username: username, Role: role}, nil)
+}
+
+func TestTeamOwnerOnly(t *testing.T) {
+	teamID := uuid.New()
+
+	// 每個操作在擁有者檢查失敗時都不能再碰資料庫
+	operations := map[string]func(s *HPLService, caller string) error{
+		"delete team": func(s *HPLService, caller string) error {
+			return s.DeleteTeam(context.Background(), teamID, caller)
+		},
+		"create invitation": func(s *HPLService, caller string) error {
+			_, _, err := s.CreateTeamInvitation(context.Background(), CreateTeamInvitationParams{
+				TeamID:    teamID,
+				CreatedBy: caller,
+				MaxUses:   5,
+				ExpiresAt: time.Now().Add(time.Hour),
+			})
+			return err
+		},
+		"list invitations": func(s *HPLService, caller string) error {
+			_, err := s.ListTeamInvitations(context.Background(), teamID, caller)
+			return err
+		},
+		"revoke invitation": func(s *HPLService, caller string) error {
+			return s.RevokeTeamInvitation(context.Background(), teamID, uuid.New(), caller)
+		},
+		"update role": func(s *HPLService, caller string) error {
+			_, err := s.UpdateTeamMemberRole(context.Background(), UpdateTeamMemberRoleParams{
+				TeamID:    teamID,
+				Username:  "teammate",
+				Role:      db.TeamRoleOwner,
+				UpdatedBy: caller,
+			})
+			return err
+		},
+		"remove another member": func(s *HPLService, caller string) error {
+			return s.RemoveTeamMember(context.Background(), teamID, "teammate", caller)
+		},
+	}
+
+	callers := []struct {
+		name    string
+		role    string
+		wantErr error
+	}{
+		{name: "member", role: db.TeamRoleMember, wantErr: ErrNotTeamOwner},
+		{name: "outsider", wantErr: ErrNotTeamMember},
+	}
+
+	for name, operation := range operations {
+		for _, caller := range callers {
+			t.Run(name+"/"+caller.name, func(t *testing.T) {
+				store := new(db_mocks.Store)
+				expectTeamMember(store, teamID, "agent-lead", caller.role)
+				s := NewService(store, nil)
+
+				err := operation(s, "agent-lead")
+				assert.ErrorIs(t, err, caller.wantErr)
+				store.AssertExpectations(t)
+			})
+		}
+	}
+}
+
+func TestUpdateTeamMemberRole(t *testing.T) {
+	teamID := uuid.New()
+	updateArg := func(role string) db.UpdateTeamMemberRoleParams {
+		return db.UpdateTeamMemberRoleParams{
+			TeamID:   pgtype.UUID{Bytes: teamID, Valid: true},
+			Username: "teammate",
+			Role:     role,
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		role      string
+		setupMock func(*db_mocks.Store)
+		wantErr   error
+	}{
+		{
+			name: "promote",
+			role: db.TeamRoleOwner,
+			setupMock: func(store *db_mocks.Store) {
+				expectTeamMember(store, teamID, "agent-lead", db.TeamRoleOwner)
+				store.On("UpdateTeamMemberRoleTx", mock.Anything, updateArg(db.TeamRoleOwner)).
+					Return(db.TeamMember{Username: "teammate", Role: db.TeamRoleOwner}, nil)
+			},
+		},
+		{
+			name:      "invalid role",
+			role:      "captain",
+			setupMock: func(*db_mocks.Store) {},
+			wantErr:   ErrInvalidTeamRole,
+		},
+		{
+			name: "demote the last owner",
+			role: db.TeamRoleMember,
+			setupMock: func(store *db_mocks.Store) {
+				expectTeamMember(store, teamID, "agent-lead", db.TeamRoleOwner)
+				store.On("UpdateTeamMemberRoleTx", mock.Anything, updateArg(db.TeamRoleMember)).
+					Return(db.TeamMember{}, db.ErrLastTeamOwner)
+			},
+			wantErr: ErrLastTeamOwner,
+		},
+		{
+			name: "member not found",
+			role: db.TeamRoleMember,
+			setupMock: func(store *db_mocks.Store) {
+				expectTeamMember(store, teamID, "agent-lead", db.TeamRoleOwner)
+				store.On("UpdateTeamMemberRoleTx", mock.Anything, updateArg(db.TeamRoleMember)).
+					Return(db.TeamMember{}, pgx.ErrNoRows)
+			},
+			wantErr: ErrTeamMemberNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := new(db_mocks.Store)
+			tc.setupMock(store)
+			s := NewService(store, nil)
+
+			member, err := s.UpdateTeamMemberRole(context.Background(), UpdateTeamMemberRoleParams{
+				TeamID:    teamID,
+				Username:  "teammate",
+				Role:      tc.role,
+				UpdatedBy: "agent-lead",
+			})
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, member)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.role, member.Role)
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRemoveTeamMember(t *testing.T) {
+	teamID := uuid.New()
+	removeArg := func(username string) db.RemoveTeamMemberParams {
+		return db.RemoveTeamMemberParams{TeamID: pgtype.UUID{Bytes: teamID, Valid: true}, Username: username}
+	}
+
+	testCases := []struct {
+		name      string
+		username  string
+		removedBy string
+		setupMock func(*db_mocks.Store)
+		wantErr   error
+	}{
+		{
+			// 成員離開隊伍不需要擁有者權限
+			name:      "member leaves",
+			username:  "teammate",
+			removedBy: "teammate",
+			setupMock: func(store *db_mocks.Store) {
+				store.On("RemoveTeamMemberTx", mock.Anything, removeArg("teammate")).Return(int64(1), nil)
+			},
+		},
+		{
+			name:      "last owner leaves",
+			username:  "agent-lead",
+			removedBy: "agent-lead",
+			setupMock: func(store *db_mocks.Store) {
+				store.On("RemoveTeamMemberTx", mock.Anything, removeArg("agent-lead")).Return(int64(0), db.ErrLastTeamOwner)
+			},
+			wantErr: ErrLastTeamOwner,
+		},
+		{
+			name:      "owner removes a member",
+			username:  "teammate",
+			removedBy: "agent-lead",
+			setupMock: func(store *db_mocks.Store) {
+				expectTeamMember(store, teamID, "agent-lead", db.TeamRoleOwner)
+				store.On("RemoveTeamMemberTx", mock.Anything, removeArg("teammate")).Return(int64(1), nil)
+			},
+		},
+		{
+			name:      "owner removes someone outside the team",
+			username:  "stranger",
+			removedBy: "agent-lead",
+			setupMock: func(store *db_mocks.Store) {
+				expectTeamMember(store, teamID, "agent-lead", db.TeamRoleOwner)
+				store.On("RemoveTeamMemberTx", mock.Anything, removeArg("stranger")).Return(int64(0), nil)
+			},
+			wantErr: ErrTeamMemberNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := new(db_mocks.Store)
+			tc.setupMock(store)
+			s := NewService(store, nil)
+
+			err := s.RemoveTeamMember(context.Background(), teamID, tc.username, tc.removedBy)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateTeamInvitation(t *testing.T) {
+	teamID := uuid.New()
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	store := new(db_mocks.Store)
+	expectTeamMember(store, teamID, "agent-lead", db.TeamRoleOwner)
+	var stored db.CreateTeamInvitationParams
+	store.On("CreateTeamInvitation", mock.Anything, mock.AnythingOfType("db.CreateTeamInvitationParams")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(db.CreateTeamInvitationParams) }).
+		Return(db.TeamInvitation{MaxUses: 5, ExpiresAt: expiresAt}, nil)
+	s := NewService(store, nil)
+
+	code, invitation, err := s.CreateTeamInvitation(context.Background(), CreateTeamInvitationParams{
+		TeamID:    teamID,
+		CreatedBy: "agent-lead",
+		MaxUses:   5,
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, invitation)
+
+	// 資料庫只存雜湊，且與 JoinTeam 比對時使用的雜湊一致
+	assert.Regexp(t, `^[A-Z2-7]{4}(-[A-Z2-7]{4}){3}$`, code)
+	assert.Equal(t, hashGroupedCode(code), stored.HashedCode)
+	assert.Equal(t, pgtype.UUID{Bytes: teamID, Valid: true}, stored.TeamID)
+	assert.Equal(t, int32(5), stored.MaxUses)
+	assert.Equal(t, expiresAt, stored.ExpiresAt)
+	store.AssertExpectations(t)
+}
+
+func TestJoinTeam(t *testing.T) {
+	const code = "abcd-efgh-ijkl-mnop"
+	joinArg := db.JoinTeamTxParams{HashedCode: hashGroupedCode("ABCD-EFGH-IJKL-MNOP"), Username: "teammate"}
+
+	testCases := []struct {
+		name    string
+		txErr   error
+		wantErr error
+	}{
+		{name: "joined"},
+		{
+			// 過期、撤銷或用完的邀請在 UseTeamInvitation 中都找不到
+			name:    "expired or used up invitation",
+			txErr:   db.ErrTeamInvitationInvalid,
+			wantErr: ErrInvalidTeamInvitation,
+		},
+		{name: "already a member", txErr: db.ErrAlreadyTeamMember, wantErr: ErrAlreadyTeamMember},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := new(db_mocks.Store)
+			store.On("JoinTeamTx", mock.Anything, joinArg).
+				Return(db.TeamMember{Username: "teammate", Role: db.TeamRoleMember}, tc.txErr)
+			s := NewService(store, nil)
+
+			member, err := s.JoinTeam(context.Background(), code, "teammate")
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, member)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, db.TeamRoleMember, member.Role)
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateScoresTeamMembership(t *testing.T) {
+	teamID := uuid.New()
+	systemID := uuid.New()
+	nonce := "hplr_0123456789abcdef0123456789abcdef"
+	arg := CreateScoreParams{
+		UserID:        "agent-lead",
+		Benchmark:     BenchmarkHPL,
+		Gflops:        ExpectedGflops(40000, 1800),
+		N:             40000,
+		NB:            192,
+		P:             2,
+		Q:             4,
+		Ranks:         8,
+		Nodes:         2,
+		RanksPerNode:  4,
+		ExecutionTime: 1800,
+		RunNonce:      nonce,
+		SystemID:      systemID,
+		TeamID:        teamID,
+		LinuxUsername: "lead",
+	}
+
+	testCases := []struct {
+		name    string
+		role    string
+		wantErr error
+	}{
+		{name: "member", role: db.TeamRoleMember},
+		{name: "owner", role: db.TeamRoleOwner},
+		{name: "not a member", wantErr: ErrNotTeamMember},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := new(db_mocks.Store)
+			store.On("GetRunNonce", mock.Anything, nonce).Return(db.RunNonce{
+				Nonce:     nonce,
+				Username:  "agent-lead",
+				IssuedAt:  time.Now().Add(-time.Hour),
+				ExpiresAt: time.Now().Add(time.Hour),
+			}, nil)
+			store.On("GetSystem", mock.Anything, pgtype.UUID{Bytes: systemID, Valid: true}).Return(db.System{
+				ID:              pgtype.UUID{Bytes: systemID, Valid: true},
+				Name:            "cluster",
+				Nodes:           2,
+				NodeRpeakGflops: 1000,
+			}, nil)
+			expectTeamMember(store, teamID, "agent-lead", tc.role)
+			if tc.wantErr == nil {
+				store.On("IsLinuxAccountVerified", mock.Anything, mock.Anything).Return(true, nil)
+				store.On("CreateScoresTx", mock.Anything, mock.MatchedBy(func(arg db.CreateScoresTxParams) bool {
+					return len(arg.Scores) == 1 && arg.Scores[0].TeamID == pgtype.UUID{Bytes: teamID, Valid: true}
+				})).Return([]db.Score{{UserID: "agent-lead"}}, nil)
+			}
+			s := NewService(store, nil)
+
+			scores, err := s.createScores(context.Background(), []CreateScoreParams{arg})
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, scores)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, scores, 1)
+			}
+			store.AssertExpectations(t)
+		})
+	}
+}
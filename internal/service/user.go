@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kdotwei/hpl-scoreboard/internal/db"
+)
+
+const uniqueViolation = "23505"
+
+// dummyHash is compared against when the user does not exist, so that
+// unknown usernames take as long to reject as wrong passwords.
+var (
+	dummyHash     string
+	dummyHashOnce sync.Once
+)
+
+var (
+	ErrUserNotFound      = errors.New("user not found")
+	ErrIncorrectPassword = errors.New("incorrect password")
+	ErrUsernameTaken     = errors.New("username already exists")
+)
+
+func (s *HPLService) CreateUser(ctx context.Context, arg CreateUserParams) (*db.User, error) {
+	hashedPassword, err := HashPassword(arg.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.CreateUser(ctx, db.CreateUserParams{
+		Username:       arg.Username,
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, ErrUsernameTaken
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// AuthenticateUser verifies the credentials and returns the matching user.
+// It returns ErrUserNotFound or ErrIncorrectPassword so callers can log the
+// difference, but both must be reported to clients the same way.
+func (s *HPLService) AuthenticateUser(ctx context.Context, username string, password string) (*db.User, error) {
+	user, err := s.store.GetUser(ctx, username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			dummyHashOnce.Do(func() {
+				dummyHash, _ = HashPassword("dummy-password")
+			})
+			_ = CheckPassword(password, dummyHash)
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if err := CheckPassword(password, user.HashedPassword); err != nil {
+		return nil, ErrIncorrectPassword
+	}
+	return &user, nil
+}
+
+// EnsureUser returns the local account of username, creating one without a
+// usable password if it does not exist yet. It is called after an external
+// Authenticator has verified the credentials.
+func (s *HPLService) EnsureUser(ctx context.Context, username string) (*db.User, error) {
+	user, err := s.store.GetUser(ctx, username)
+	if err == nil {
+		return &user, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	// 空的 hashed_password 不是合法的 bcrypt 雜湊，此帳號無法以本地密碼登入
+	user, err = s.store.CreateUser(ctx, db.CreateUserParams{
+		Username:       username,
+		HashedPassword: "",
+	})
+	if err != nil {
+		// 同一使用者同時登入時，另一個請求可能已經建立帳號
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			user, err = s.store.GetUser(ctx, username)
+			if err != nil {
+				return nil, err
+			}
+			return &user, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// EnsureOIDCUser returns the local account linked to the identity
+// (issuer, subject). On the first login a new account named after the
+// username claim is created and linked; it returns ErrUsernameTaken if that
+// name already belongs to another account, which is never taken over.
+func (s *HPLService) EnsureOIDCUser(ctx context.Context, arg EnsureOIDCUserParams) (*db.User, error) {
+	user, err := s.getOIDCUser(ctx, arg)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	created, err := s.store.CreateOIDCUserTx(ctx, db.CreateOIDCUserTxParams{
+		Issuer:   arg.Issuer,
+		Subject:  arg.Subject,
+		Username: arg.Username,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			// 同一身分同時登入時，另一個請求可能已經建立連結
+			user, getErr := s.getOIDCUser(ctx, arg)
+			if getErr == nil {
+				return user, nil
+			}
+			if errors.Is(getErr, pgx.ErrNoRows) {
+				return nil, ErrUsernameTaken
+			}
+			return nil, getErr
+		}
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (s *HPLService) getOIDCUser(ctx context.Context, arg EnsureOIDCUserParams) (*db.User, error) {
+	identity, err := s.store.GetOIDCIdentity(ctx, db.GetOIDCIdentityParams{
+		Issuer:  arg.Issuer,
+		Subject: arg.Subject,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.GetUser(ctx, identity.Username)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,157 @@
+package sshsig
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+const testNamespace = "hpl-scoreboard"
+
+// 以 OpenSSH 9 的 ssh-keygen 產生：
+// printf '%s' "hpl-scoreboard:alice:alice:fixture-nonce" | ssh-keygen -Y sign -f id_ed25519 -n hpl-scoreboard
+const (
+	fixtureMessage   = "hpl-scoreboard:alice:alice:fixture-nonce"
+	fixturePublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKt14EWX3KlFXIi4IyoE9ihJ0grptnTicGQ9pSij+9TO alice@cluster"
+	fixtureSignature = `-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAgq3XgRZfcqUVciLgjKgT2KEnSCu
+m2dOJwZD2lKKP71M4AAAAOaHBsLXNjb3JlYm9hcmQAAAAAAAAABnNoYTUxMgAAAFMAAAAL
+c3NoLWVkMjU1MTkAAABA+ahGi0cTHzlPIhixBJNMC7W1U7RnBL+lHMKpW9MQiZAoQc4xe1
+2g2MFVWFDhSpwHSth7Kagcda8UMQjSHVyQDg==
+-----END SSH SIGNATURE-----
+`
+)
+
+// sign 依 PROTOCOL.sshsig 產生與 ssh-keygen -Y sign 相同格式的簽章
+func sign(t *testing.T, signer ssh.Signer, message []byte, namespace string) []byte {
+	t.Helper()
+
+	digest := sha512.Sum512(message)
+	data := append([]byte(magicPreamble), ssh.Marshal(signedData{
+		Namespace:     namespace,
+		HashAlgorithm: "sha512",
+		Hash:          digest[:],
+	})...)
+
+	algorithmSigner, ok := signer.(ssh.AlgorithmSigner)
+	require.True(t, ok)
+	algorithm := ""
+	if signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		algorithm = ssh.KeyAlgoRSASHA512
+	}
+	signature, err := algorithmSigner.SignWithAlgorithm(rand.Reader, data, algorithm)
+	require.NoError(t, err)
+
+	blob := append([]byte(magicPreamble), ssh.Marshal(signatureBlob{
+		Version:       sigVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     namespace,
+		HashAlgorithm: "sha512",
+		Signature:     ssh.Marshal(signature),
+	})...)
+	return pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: blob})
+}
+
+func testSigners(t *testing.T) map[string]ssh.Signer {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	signers := map[string]ssh.Signer{}
+	for name, key := range map[string]any{"ed25519": ed25519Key, "ecdsa": ecdsaKey, "rsa": rsaKey} {
+		signer, err := ssh.NewSignerFromKey(key)
+		require.NoError(t, err)
+		signers[name] = signer
+	}
+	return signers
+}
+
+func TestVerifySSHKeygenFixture(t *testing.T) {
+	expected, _, _, _, err := ssh.ParseAuthorizedKey([]byte(fixturePublicKey))
+	require.NoError(t, err)
+
+	publicKey, err := Verify([]byte(fixtureSignature), []byte(fixtureMessage), testNamespace)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Marshal(), publicKey.Marshal())
+}
+
+func TestVerify(t *testing.T) {
+	message := []byte("hpl-scoreboard:bob:bob:nonce")
+
+	for name, signer := range testSigners(t) {
+		t.Run(name, func(t *testing.T) {
+			armored := sign(t, signer, message, testNamespace)
+
+			publicKey, err := Verify(armored, message, testNamespace)
+			require.NoError(t, err)
+			assert.Equal(t, signer.PublicKey().Marshal(), publicKey.Marshal())
+
+			_, err = Verify(armored, []byte("hpl-scoreboard:bob:bob:other"), testNamespace)
+			assert.ErrorIs(t, err, ErrInvalidSignature)
+
+			_, err = Verify(armored, message, "git")
+			assert.ErrorIs(t, err, ErrInvalidSignature)
+		})
+	}
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	testCases := []struct {
+		name      string
+		signature []byte
+	}{
+		{"empty", nil},
+		{"not armored", []byte("U1NIU0lH")},
+		{"wrong armor", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("SSHSIG")})},
+		{"missing preamble", pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: []byte("garbage")})},
+		{"truncated", pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: []byte("SSHSIG\x00\x00")})},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Verify(tc.signature, []byte(fixtureMessage), testNamespace)
+			assert.ErrorIs(t, err, ErrMalformedSignature)
+		})
+	}
+}
+
+func TestVerifyRejectsSHA1RSA(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(rsaKey)
+	require.NoError(t, err)
+
+	message := []byte(fixtureMessage)
+	digest := sha512.Sum512(message)
+	data := append([]byte(magicPreamble), ssh.Marshal(signedData{
+		Namespace:     testNamespace,
+		HashAlgorithm: "sha512",
+		Hash:          digest[:],
+	})...)
+	signature, err := signer.(ssh.AlgorithmSigner).SignWithAlgorithm(rand.Reader, data, ssh.KeyAlgoRSA)
+	require.NoError(t, err)
+
+	blob := append([]byte(magicPreamble), ssh.Marshal(signatureBlob{
+		Version:       sigVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     testNamespace,
+		HashAlgorithm: "sha512",
+		Signature:     ssh.Marshal(signature),
+	})...)
+	armored := pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: blob})
+
+	_, err = Verify(armored, message, testNamespace)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
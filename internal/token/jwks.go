@@ -0,0 +1,50 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JSONWebKeySet is a JWK Set as defined in RFC 7517
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey is a public signing key in JWK format (RFC 7517, RFC 8037)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// OKP (Ed25519)
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+}
+
+// NewJSONWebKey converts an Ed25519 or RSA public key into a JSONWebKey
+func NewJSONWebKey(keyID string, alg string, publicKey crypto.PublicKey) JSONWebKey {
+	key := JSONWebKey{
+		KeyID:     keyID,
+		Use:       "sig",
+		Algorithm: alg,
+	}
+
+	switch pub := publicKey.(type) {
+	case ed25519.PublicKey:
+		key.KeyType = "OKP"
+		key.Curve = "Ed25519"
+		key.X = base64.RawURLEncoding.EncodeToString(pub)
+	case *rsa.PublicKey:
+		key.KeyType = "RSA"
+		key.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		key.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	}
+
+	return key
+}
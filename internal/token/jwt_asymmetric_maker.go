@@ -0,0 +1,192 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const minRSAKeyBits = 2048
+
+// JWKSProvider is implemented by makers whose tokens can be verified with
+// published public keys
+type JWKSProvider interface {
+	JWKS() JSONWebKeySet
+}
+
+// AsymmetricJWTMaker is a JSON Web Token maker that signs with an Ed25519
+// (EdDSA) or RSA (RS256) private key and sets the kid header, so that other
+// services can verify tokens with the public key alone.
+type AsymmetricJWTMaker struct {
+	keyID      string
+	method     jwt.SigningMethod
+	privateKey crypto.Signer
+	publicKey  crypto.PublicKey
+}
+
+// NewAsymmetricJWTMaker creates a new AsymmetricJWTMaker.
+// The signing algorithm is chosen from the key type.
+func NewAsymmetricJWTMaker(keyID string, privateKey crypto.Signer) (Maker, error) {
+	if keyID == "" {
+		return nil, errors.New("key id must not be empty")
+	}
+
+	var method jwt.SigningMethod
+	switch key := privateKey.(type) {
+	case ed25519.PrivateKey:
+		method = jwt.SigningMethodEdDSA
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("invalid key size: RSA keys must be at least %d bits", minRSAKeyBits)
+		}
+		method = jwt.SigningMethodRS256
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", privateKey)
+	}
+
+	return &AsymmetricJWTMaker{
+		keyID:      keyID,
+		method:     method,
+		privateKey: privateKey,
+		publicKey:  privateKey.Public(),
+	}, nil
+}
+
+// ParsePrivateKeyPEM parses a PKCS#8 (or PKCS#1 RSA) PEM encoded private key
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
+
+// CreateToken creates a new token for a specific username, roles, MFA state and duration
+func (maker *AsymmetricJWTMaker) CreateToken(username string, roles []string, mfa bool, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	payload.Roles = roles
+	payload.MFA = mfa
+
+	return maker.signPayload(payload)
+}
+
+// CreateRefreshToken creates a new refresh token for a specific username, MFA state and duration
+func (maker *AsymmetricJWTMaker) CreateRefreshToken(username string, mfa bool, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	payload.TokenType = TokenTypeRefresh
+	payload.MFA = mfa
+
+	return maker.signPayload(payload)
+}
+
+// registeredPayload 在 Payload 之外寫入 RFC 7519 的 exp、iat、sub、jti，
+// 讓只認標準 claim 的 JWT 函式庫也會檢查期限
+type registeredPayload struct {
+	*Payload
+	jwt.RegisteredClaims
+}
+
+func newRegisteredPayload(payload *Payload) *registeredPayload {
+	return &registeredPayload{
+		Payload: payload,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   payload.Username,
+			ExpiresAt: jwt.NewNumericDate(payload.ExpiredAt),
+			IssuedAt:  jwt.NewNumericDate(payload.IssuedAt),
+			ID:        payload.ID.String(),
+		},
+	}
+}
+
+// Payload 與 RegisteredClaims 都實作 jwt.Claims，明確以 RegisteredClaims 為準
+
+func (claims *registeredPayload) GetExpirationTime() (*jwt.NumericDate, error) {
+	return claims.RegisteredClaims.GetExpirationTime()
+}
+
+func (claims *registeredPayload) GetIssuedAt() (*jwt.NumericDate, error) {
+	return claims.RegisteredClaims.GetIssuedAt()
+}
+
+func (claims *registeredPayload) GetNotBefore() (*jwt.NumericDate, error) {
+	return claims.RegisteredClaims.GetNotBefore()
+}
+
+func (claims *registeredPayload) GetIssuer() (string, error) {
+	return claims.RegisteredClaims.GetIssuer()
+}
+
+func (claims *registeredPayload) GetSubject() (string, error) {
+	return claims.RegisteredClaims.GetSubject()
+}
+
+func (claims *registeredPayload) GetAudience() (jwt.ClaimStrings, error) {
+	return claims.RegisteredClaims.GetAudience()
+}
+
+func (maker *AsymmetricJWTMaker) signPayload(payload *Payload) (string, *Payload, error) {
+	jwtToken := jwt.NewWithClaims(maker.method, newRegisteredPayload(payload))
+	jwtToken.Header["kid"] = maker.keyID
+	token, err := jwtToken.SignedString(maker.privateKey)
+	return token, payload, err
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *AsymmetricJWTMaker) VerifyToken(token string) (*Payload, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		// 演算法與 kid 都必須符合，避免 alg 混淆攻擊
+		if token.Method.Alg() != maker.method.Alg() {
+			return nil, ErrInvalidToken
+		}
+		if kid, _ := token.Header["kid"].(string); kid != maker.keyID {
+			return nil, ErrInvalidToken
+		}
+		return maker.publicKey, nil
+	}
+
+	claims := &registeredPayload{Payload: &Payload{}}
+	if _, err := jwt.ParseWithClaims(token, claims, keyFunc); err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	// 沒有 exp 的舊 Token 仍以 expired_at 判斷期限
+	if err := claims.Payload.Valid(); err != nil {
+		return nil, err
+	}
+
+	return claims.Payload, nil
+}
+
+// JWKS returns the public key as a JSON Web Key Set
+func (maker *AsymmetricJWTMaker) JWKS() JSONWebKeySet {
+	return JSONWebKeySet{Keys: []JSONWebKey{NewJSONWebKey(maker.keyID, maker.method.Alg(), maker.publicKey)}}
+}
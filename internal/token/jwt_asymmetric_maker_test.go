@@ -0,0 +1,175 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// publicKeyFromJWK 模擬外部服務 (例如成績入口網站) 從 JWKS 還原公鑰
+func publicKeyFromJWK(t *testing.T, key JSONWebKey) interface{} {
+	switch key.KeyType {
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		require.NoError(t, err)
+		return ed25519.PublicKey(x)
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		require.NoError(t, err)
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		require.NoError(t, err)
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	t.Fatalf("unexpected key type %q", key.KeyType)
+	return nil
+}
+
+func TestAsymmetricJWTMakerJWKS(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		privateKey crypto.Signer
+		alg        string
+	}{
+		{name: "EdDSA", privateKey: ed25519Key, alg: "EdDSA"},
+		{name: "RS256", privateKey: rsaKey, alg: "RS256"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			maker, err := NewAsymmetricJWTMaker("key-1", tc.privateKey)
+			require.NoError(t, err)
+
+			token, _, err := maker.CreateToken("test-user", nil, false, time.Minute)
+			require.NoError(t, err)
+
+			jwks := maker.(JWKSProvider).JWKS()
+			require.Len(t, jwks.Keys, 1)
+			require.Equal(t, "key-1", jwks.Keys[0].KeyID)
+			require.Equal(t, tc.alg, jwks.Keys[0].Algorithm)
+			require.Equal(t, "sig", jwks.Keys[0].Use)
+
+			// 只用 JWKS 中的公鑰就能驗證 Token
+			parsed, err := jwt.ParseWithClaims(token, &Payload{}, func(token *jwt.Token) (interface{}, error) {
+				require.Equal(t, "key-1", token.Header["kid"])
+				return publicKeyFromJWK(t, jwks.Keys[0]), nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, "test-user", parsed.Claims.(*Payload).Username)
+		})
+	}
+}
+
+func TestAsymmetricJWTMakerRegisteredClaims(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	maker, err := NewAsymmetricJWTMaker("key-1", privateKey)
+	require.NoError(t, err)
+	jwks := maker.(JWKSProvider).JWKS()
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		return publicKeyFromJWK(t, jwks.Keys[0]), nil
+	}
+
+	// 外部服務只用標準的 RegisteredClaims 解析
+	token, payload, err := maker.CreateToken("test-user", nil, false, time.Minute)
+	require.NoError(t, err)
+
+	claims := &jwt.RegisteredClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, keyFunc)
+	require.NoError(t, err)
+	require.Equal(t, "test-user", claims.Subject)
+	require.Equal(t, payload.ID.String(), claims.ID)
+	require.NotNil(t, claims.IssuedAt)
+	require.NotNil(t, claims.ExpiresAt)
+	require.WithinDuration(t, payload.ExpiredAt, claims.ExpiresAt.Time, time.Second)
+
+	// 過期的 Token 也會被標準驗證器拒絕
+	expiredToken, _, err := maker.CreateToken("test-user", nil, false, -time.Minute)
+	require.NoError(t, err)
+
+	_, err = jwt.ParseWithClaims(expiredToken, &jwt.RegisteredClaims{}, keyFunc)
+	require.ErrorIs(t, err, jwt.ErrTokenExpired)
+	_, err = jwt.Parse(expiredToken, keyFunc)
+	require.ErrorIs(t, err, jwt.ErrTokenExpired)
+
+	verified, err := maker.VerifyToken(expiredToken)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, verified)
+}
+
+func TestAsymmetricJWTMakerRejectsWrongKeyID(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	maker, err := NewAsymmetricJWTMaker("key-1", privateKey)
+	require.NoError(t, err)
+	otherMaker, err := NewAsymmetricJWTMaker("key-2", privateKey)
+	require.NoError(t, err)
+
+	token, _, err := otherMaker.CreateToken("test-user", nil, false, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestAsymmetricJWTMakerRejectsHMACWithPublicKey(t *testing.T) {
+	// alg 混淆攻擊：以公鑰當作 HMAC 密鑰簽署 Token
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	maker, err := NewAsymmetricJWTMaker("key-1", privateKey)
+	require.NoError(t, err)
+
+	payload, err := NewPayload("hacker", time.Minute)
+	require.NoError(t, err)
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	jwtToken.Header["kid"] = "key-1"
+	token, err := jwtToken.SignedString([]byte(privateKey.Public().(ed25519.PublicKey)))
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestNewAsymmetricJWTMakerRejectsSmallRSAKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	maker, err := NewAsymmetricJWTMaker("key-1", rsaKey)
+	require.Error(t, err)
+	require.Nil(t, maker)
+}
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	signer, err := ParsePrivateKeyPEM(pemData)
+	require.NoError(t, err)
+	require.Equal(t, privateKey, signer)
+
+	_, err = ParsePrivateKeyPEM([]byte("not a pem"))
+	require.Error(t, err)
+}
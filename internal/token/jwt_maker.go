@@ -13,26 +13,64 @@ const minSecretKeySize = 32
 
 // JWTMaker is a JSON Web Token maker
 type JWTMaker struct {
-	secretKey string
+	keyring *Keyring
 }
 
-// NewJWTMaker creates a new JWTMaker
+// NewJWTMaker creates a new JWTMaker with a single secret key
 func NewJWTMaker(secretKey string) (Maker, error) {
 	if len(secretKey) < minSecretKeySize {
 		return nil, fmt.Errorf("invalid key size: must be at least %d characters", minSecretKeySize)
 	}
-	return &JWTMaker{secretKey}, nil
+
+	keyring, err := NewKeyring(KeyringConfig{
+		CurrentKeyID: defaultKeyID,
+		Keys:         map[string]string{defaultKeyID: secretKey},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &JWTMaker{keyring}, nil
+}
+
+// NewJWTMakerWithKeyring creates a new JWTMaker that signs with the keyring's
+// current key and verifies with any key in it
+func NewJWTMakerWithKeyring(keyring *Keyring) (Maker, error) {
+	if keyring == nil {
+		return nil, errors.New("keyring must not be nil")
+	}
+	return &JWTMaker{keyring}, nil
 }
 
-// CreateToken creates a new token for a specific username and duration
-func (maker *JWTMaker) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
+// CreateToken creates a new token for a specific username, roles, MFA state and duration
+func (maker *JWTMaker) CreateToken(username string, roles []string, mfa bool, duration time.Duration) (string, *Payload, error) {
 	payload, err := NewPayload(username, duration)
 	if err != nil {
 		return "", payload, err
 	}
+	payload.Roles = roles
+	payload.MFA = mfa
+
+	return maker.signPayload(payload)
+}
+
+// CreateRefreshToken creates a new refresh token for a specific username, MFA state and duration
+func (maker *JWTMaker) CreateRefreshToken(username string, mfa bool, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	payload.TokenType = TokenTypeRefresh
+	payload.MFA = mfa
+
+	return maker.signPayload(payload)
+}
+
+func (maker *JWTMaker) signPayload(payload *Payload) (string, *Payload, error) {
+	keyID, secretKey := maker.keyring.signingKey()
 
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
-	token, err := jwtToken.SignedString([]byte(maker.secretKey))
+	jwtToken.Header["kid"] = keyID
+	token, err := jwtToken.SignedString(secretKey)
 	return token, payload, err
 }
 
@@ -43,7 +81,19 @@ func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
 		if !ok {
 			return nil, ErrInvalidToken
 		}
-		return []byte(maker.secretKey), nil
+
+		// 依 kid 挑選金鑰；沒有 kid 的舊 Token 以目前的金鑰驗證
+		keyID, ok := token.Header["kid"].(string)
+		if !ok {
+			_, secretKey := maker.keyring.signingKey()
+			return secretKey, nil
+		}
+
+		secretKey, ok := maker.keyring.verificationKey(keyID)
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+		return secretKey, nil
 	}
 
 	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
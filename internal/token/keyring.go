@@ -0,0 +1,123 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// defaultKeyID is the kid used when JWTMaker is created from a single secret key
+const defaultKeyID = "default"
+
+// KeyringConfig describes the HMAC keys of a Keyring.
+// It is also the JSON format of a keyring file.
+type KeyringConfig struct {
+	CurrentKeyID string            `json:"current_key_id"`
+	Keys         map[string]string `json:"keys"`
+}
+
+// Keyring holds the HMAC keys used by JWTMaker, indexed by kid.
+// New tokens are signed with the current key; every key in the ring is
+// accepted for verification, so tokens signed with an older key stay valid
+// until they expire or the key is removed from the ring.
+type Keyring struct {
+	mu           sync.RWMutex
+	currentKeyID string
+	keys         map[string][]byte
+}
+
+// NewKeyring creates a Keyring from config
+func NewKeyring(config KeyringConfig) (*Keyring, error) {
+	keyring := &Keyring{}
+	if err := keyring.Update(config); err != nil {
+		return nil, err
+	}
+	return keyring, nil
+}
+
+// Update atomically replaces every key in the ring.
+// The ring is left unchanged if config is invalid.
+func (keyring *Keyring) Update(config KeyringConfig) error {
+	if len(config.Keys) == 0 {
+		return errors.New("keyring must contain at least one key")
+	}
+	if _, ok := config.Keys[config.CurrentKeyID]; !ok {
+		return fmt.Errorf("current key %q is not in the keyring", config.CurrentKeyID)
+	}
+
+	keys := make(map[string][]byte, len(config.Keys))
+	for keyID, secretKey := range config.Keys {
+		if keyID == "" {
+			return errors.New("key id must not be empty")
+		}
+		if len(secretKey) < minSecretKeySize {
+			return fmt.Errorf("invalid key size for %q: must be at least %d characters", keyID, minSecretKeySize)
+		}
+		keys[keyID] = []byte(secretKey)
+	}
+
+	keyring.mu.Lock()
+	defer keyring.mu.Unlock()
+	keyring.currentKeyID = config.CurrentKeyID
+	keyring.keys = keys
+	return nil
+}
+
+// signingKey returns the kid and secret of the current key
+func (keyring *Keyring) signingKey() (string, []byte) {
+	keyring.mu.RLock()
+	defer keyring.mu.RUnlock()
+	return keyring.currentKeyID, keyring.keys[keyring.currentKeyID]
+}
+
+// verificationKey returns the secret for kid, if it is still in the ring
+func (keyring *Keyring) verificationKey(keyID string) ([]byte, bool) {
+	keyring.mu.RLock()
+	defer keyring.mu.RUnlock()
+	key, ok := keyring.keys[keyID]
+	return key, ok
+}
+
+// LoadKeyringFile reads a JSON encoded KeyringConfig from path
+func LoadKeyringFile(path string) (KeyringConfig, error) {
+	var config KeyringConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("invalid keyring file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ParseKeyringList parses a comma separated "kid:secret" list.
+// The first entry is the current signing key.
+func ParseKeyringList(list string) (KeyringConfig, error) {
+	config := KeyringConfig{Keys: make(map[string]string)}
+
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		keyID, secretKey, ok := strings.Cut(entry, ":")
+		if !ok {
+			return config, fmt.Errorf("invalid keyring entry %q: expected kid:secret", entry)
+		}
+		if _, exists := config.Keys[keyID]; exists {
+			return config, fmt.Errorf("duplicate key id %q", keyID)
+		}
+		if config.CurrentKeyID == "" {
+			config.CurrentKeyID = keyID
+		}
+		config.Keys[keyID] = secretKey
+	}
+
+	return config, nil
+}
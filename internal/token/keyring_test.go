@@ -0,0 +1,148 @@
+package token
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	oldSecretKey = "old-secret-key-0123456789abcdefgh"
+	newSecretKey = "new-secret-key-0123456789abcdefgh"
+)
+
+func TestJWTMakerKeyRotation(t *testing.T) {
+	keyring, err := NewKeyring(KeyringConfig{
+		CurrentKeyID: "2024-01",
+		Keys:         map[string]string{"2024-01": oldSecretKey},
+	})
+	require.NoError(t, err)
+
+	maker, err := NewJWTMakerWithKeyring(keyring)
+	require.NoError(t, err)
+
+	oldToken, _, err := maker.CreateToken("test-user", nil, false, time.Minute)
+	require.NoError(t, err)
+
+	// 1. 輪替：新增金鑰並設為目前金鑰，舊金鑰保留用於驗證
+	require.NoError(t, keyring.Update(KeyringConfig{
+		CurrentKeyID: "2024-06",
+		Keys:         map[string]string{"2024-01": oldSecretKey, "2024-06": newSecretKey},
+	}))
+
+	newToken, _, err := maker.CreateToken("test-user", nil, false, time.Minute)
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &Payload{})
+	require.NoError(t, err)
+	require.Equal(t, "2024-06", parsed.Header["kid"])
+
+	_, err = maker.VerifyToken(oldToken)
+	require.NoError(t, err, "tokens signed by the previous key must still verify")
+	_, err = maker.VerifyToken(newToken)
+	require.NoError(t, err)
+
+	// 2. 移除舊金鑰後，舊 Token 失效
+	require.NoError(t, keyring.Update(KeyringConfig{
+		CurrentKeyID: "2024-06",
+		Keys:         map[string]string{"2024-06": newSecretKey},
+	}))
+
+	payload, err := maker.VerifyToken(oldToken)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+	_, err = maker.VerifyToken(newToken)
+	require.NoError(t, err)
+}
+
+func TestJWTMakerUnknownKeyID(t *testing.T) {
+	maker, err := NewJWTMaker(oldSecretKey)
+	require.NoError(t, err)
+
+	// 以相同密鑰簽署但 kid 不在 keyring 中
+	payload, err := NewPayload("test-user", time.Minute)
+	require.NoError(t, err)
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	jwtToken.Header["kid"] = "unknown"
+	token, err := jwtToken.SignedString([]byte(oldSecretKey))
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestJWTMakerTokenWithoutKeyID(t *testing.T) {
+	maker, err := NewJWTMaker(oldSecretKey)
+	require.NoError(t, err)
+
+	// 加入 keyring 之前簽發的 Token 沒有 kid
+	payload, err := NewPayload("test-user", time.Minute)
+	require.NoError(t, err)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, payload).SignedString([]byte(oldSecretKey))
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, "test-user", payload.Username)
+}
+
+func TestKeyringUpdateRejectsInvalidConfig(t *testing.T) {
+	keyring, err := NewKeyring(KeyringConfig{
+		CurrentKeyID: "2024-01",
+		Keys:         map[string]string{"2024-01": oldSecretKey},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		config KeyringConfig
+	}{
+		{name: "empty keyring", config: KeyringConfig{CurrentKeyID: "2024-06"}},
+		{name: "missing current key", config: KeyringConfig{CurrentKeyID: "2024-06", Keys: map[string]string{"2024-01": oldSecretKey}}},
+		{name: "short key", config: KeyringConfig{CurrentKeyID: "2024-06", Keys: map[string]string{"2024-06": "short"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Error(t, keyring.Update(tc.config))
+
+			// 設定錯誤時保留原本的金鑰
+			keyID, secretKey := keyring.signingKey()
+			require.Equal(t, "2024-01", keyID)
+			require.Equal(t, []byte(oldSecretKey), secretKey)
+		})
+	}
+}
+
+func TestLoadKeyringFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	data := `{"current_key_id": "2024-06", "keys": {"2024-01": "` + oldSecretKey + `", "2024-06": "` + newSecretKey + `"}}`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	config, err := LoadKeyringFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "2024-06", config.CurrentKeyID)
+	require.Len(t, config.Keys, 2)
+
+	_, err = LoadKeyringFile(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestParseKeyringList(t *testing.T) {
+	config, err := ParseKeyringList("2024-06:" + newSecretKey + ", 2024-01:" + oldSecretKey)
+	require.NoError(t, err)
+	require.Equal(t, "2024-06", config.CurrentKeyID)
+	require.Equal(t, newSecretKey, config.Keys["2024-06"])
+	require.Equal(t, oldSecretKey, config.Keys["2024-01"])
+
+	_, err = ParseKeyringList("missing-separator")
+	require.Error(t, err)
+
+	_, err = ParseKeyringList("a:" + oldSecretKey + ",a:" + newSecretKey)
+	require.Error(t, err)
+}
@@ -4,6 +4,7 @@ import "time"
 
 // Maker is an interface for managing tokens
 type Maker interface {
-	CreateToken(username string, duration time.Duration) (string, *Payload, error)
+	CreateToken(username string, roles []string, mfa bool, duration time.Duration) (string, *Payload, error)
+	CreateRefreshToken(username string, mfa bool, duration time.Duration) (string, *Payload, error)
 	VerifyToken(token string) (*Payload, error)
 }
@@ -0,0 +1,164 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/stretchr/testify/require"
+)
+
+// testMakers 回傳所有 Maker 實作，共用的測試會對每一個都執行
+func testMakers(t *testing.T) map[string]Maker {
+	secretKey := "12345678901234567890123456789012"
+
+	jwtMaker, err := NewJWTMaker(secretKey)
+	require.NoError(t, err)
+
+	pasetoLocalMaker, err := NewPasetoLocalMaker(secretKey)
+	require.NoError(t, err)
+
+	pasetoPublicMaker, err := NewPasetoPublicMaker(paseto.NewV4AsymmetricSecretKey().ExportHex())
+	require.NoError(t, err)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	jwtEdDSAMaker, err := NewAsymmetricJWTMaker("ed25519-key", ed25519Key)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwtRS256Maker, err := NewAsymmetricJWTMaker("rsa-key", rsaKey)
+	require.NoError(t, err)
+
+	return map[string]Maker{
+		"jwt":           jwtMaker,
+		"jwt-eddsa":     jwtEdDSAMaker,
+		"jwt-rs256":     jwtRS256Maker,
+		"paseto-local":  pasetoLocalMaker,
+		"paseto-public": pasetoPublicMaker,
+	}
+}
+
+func TestMaker(t *testing.T) {
+	for name, maker := range testMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			username := "test-user"
+			roles := []string{RoleParticipant, RoleJudge}
+			duration := time.Minute
+
+			issuedAt := time.Now()
+			expiredAt := issuedAt.Add(duration)
+
+			// 1. 測試建立 Token
+			token, payload, err := maker.CreateToken(username, roles, false, duration)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+			require.NotEmpty(t, payload)
+
+			// 2. 測試驗證 Token
+			payload, err = maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.NotEmpty(t, payload)
+
+			require.NotZero(t, payload.ID)
+			require.Equal(t, username, payload.Username)
+			require.Equal(t, roles, payload.Roles)
+			require.True(t, payload.HasRole(RoleJudge))
+			require.False(t, payload.HasRole(RoleAdmin))
+			require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+			require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+		})
+	}
+}
+
+func TestExpiredToken(t *testing.T) {
+	for name, maker := range testMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			// 建立一個「負時間」的 Token (立刻過期)
+			token, payload, err := maker.CreateToken("test-user", nil, false, -time.Minute)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+			require.NotEmpty(t, payload)
+
+			// 驗證應該要失敗
+			payload, err = maker.VerifyToken(token)
+			require.Error(t, err)
+			require.EqualError(t, err, ErrExpiredToken.Error())
+			require.Nil(t, payload)
+		})
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	for name, maker := range testMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			accessToken, accessPayload, err := maker.CreateToken("test-user", nil, false, time.Minute)
+			require.NoError(t, err)
+			require.Equal(t, TokenTypeAccess, accessPayload.TokenType)
+
+			refreshToken, refreshPayload, err := maker.CreateRefreshToken("test-user", false, time.Hour)
+			require.NoError(t, err)
+			require.Equal(t, TokenTypeRefresh, refreshPayload.TokenType)
+			require.NotEqual(t, accessPayload.ID, refreshPayload.ID)
+
+			// Token 類型必須在驗證後保留
+			payload, err := maker.VerifyToken(accessToken)
+			require.NoError(t, err)
+			require.Equal(t, TokenTypeAccess, payload.TokenType)
+
+			payload, err = maker.VerifyToken(refreshToken)
+			require.NoError(t, err)
+			require.Equal(t, TokenTypeRefresh, payload.TokenType)
+			require.Equal(t, refreshPayload.ID, payload.ID)
+		})
+	}
+}
+
+func TestMFAClaim(t *testing.T) {
+	for name, maker := range testMakers(t) {
+		t.Run(name, func(t *testing.T) {
+			accessToken, _, err := maker.CreateToken("test-user", nil, true, time.Minute)
+			require.NoError(t, err)
+			refreshToken, _, err := maker.CreateRefreshToken("test-user", true, time.Hour)
+			require.NoError(t, err)
+			plainToken, _, err := maker.CreateToken("test-user", nil, false, time.Minute)
+			require.NoError(t, err)
+
+			// MFA 狀態必須在驗證後保留，Refresh Token 換發時才能延續
+			payload, err := maker.VerifyToken(accessToken)
+			require.NoError(t, err)
+			require.True(t, payload.MFA)
+
+			payload, err = maker.VerifyToken(refreshToken)
+			require.NoError(t, err)
+			require.True(t, payload.MFA)
+
+			payload, err = maker.VerifyToken(plainToken)
+			require.NoError(t, err)
+			require.False(t, payload.MFA)
+		})
+	}
+}
+
+func TestTokenFromOtherMakerIsInvalid(t *testing.T) {
+	makers := testMakers(t)
+	for name, maker := range makers {
+		token, _, err := maker.CreateToken("test-user", nil, false, time.Minute)
+		require.NoError(t, err)
+
+		for otherName, other := range makers {
+			if otherName == name {
+				continue
+			}
+			t.Run(name+"/"+otherName, func(t *testing.T) {
+				payload, err := other.VerifyToken(token)
+				require.EqualError(t, err, ErrInvalidToken.Error())
+				require.Nil(t, payload)
+			})
+		}
+	}
+}
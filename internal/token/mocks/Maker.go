@@ -15,9 +15,46 @@ type Maker struct {
 	mock.Mock
 }
 
-// CreateToken provides a mock function with given fields: username, duration
-func (_m *Maker) CreateToken(username string, duration time.Duration) (string, *token.Payload, error) {
-	ret := _m.Called(username, duration)
+// CreateRefreshToken provides a mock function with given fields: username, mfa, duration
+func (_m *Maker) CreateRefreshToken(username string, mfa bool, duration time.Duration) (string, *token.Payload, error) {
+	ret := _m.Called(username, mfa, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefreshToken")
+	}
+
+	var r0 string
+	var r1 *token.Payload
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, bool, time.Duration) (string, *token.Payload, error)); ok {
+		return rf(username, mfa, duration)
+	}
+	if rf, ok := ret.Get(0).(func(string, bool, time.Duration) string); ok {
+		r0 = rf(username, mfa, duration)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, bool, time.Duration) *token.Payload); ok {
+		r1 = rf(username, mfa, duration)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*token.Payload)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, bool, time.Duration) error); ok {
+		r2 = rf(username, mfa, duration)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateToken provides a mock function with given fields: username, roles, mfa, duration
+func (_m *Maker) CreateToken(username string, roles []string, mfa bool, duration time.Duration) (string, *token.Payload, error) {
+	ret := _m.Called(username, roles, mfa, duration)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateToken")
@@ -26,25 +63,25 @@ func (_m *Maker) CreateToken(username string, duration time.Duration) (string, *
 	var r0 string
 	var r1 *token.Payload
 	var r2 error
-	if rf, ok := ret.Get(0).(func(string, time.Duration) (string, *token.Payload, error)); ok {
-		return rf(username, duration)
+	if rf, ok := ret.Get(0).(func(string, []string, bool, time.Duration) (string, *token.Payload, error)); ok {
+		return rf(username, roles, mfa, duration)
 	}
-	if rf, ok := ret.Get(0).(func(string, time.Duration) string); ok {
-		r0 = rf(username, duration)
+	if rf, ok := ret.Get(0).(func(string, []string, bool, time.Duration) string); ok {
+		r0 = rf(username, roles, mfa, duration)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, time.Duration) *token.Payload); ok {
-		r1 = rf(username, duration)
+	if rf, ok := ret.Get(1).(func(string, []string, bool, time.Duration) *token.Payload); ok {
+		r1 = rf(username, roles, mfa, duration)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*token.Payload)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(string, time.Duration) error); ok {
-		r2 = rf(username, duration)
+	if rf, ok := ret.Get(2).(func(string, []string, bool, time.Duration) error); ok {
+		r2 = rf(username, roles, mfa, duration)
 	} else {
 		r2 = ret.Error(2)
 	}
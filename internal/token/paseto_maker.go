@@ -0,0 +1,116 @@
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+)
+
+const pasetoSymmetricKeySize = 32
+
+// PasetoMaker is a PASETO v4 token maker.
+// It produces v4.local (encrypted) or v4.public (Ed25519 signed) tokens
+// depending on the constructor used.
+type PasetoMaker struct {
+	public       bool
+	symmetricKey paseto.V4SymmetricKey
+	secretKey    paseto.V4AsymmetricSecretKey
+	publicKey    paseto.V4AsymmetricPublicKey
+}
+
+// NewPasetoLocalMaker creates a v4.local PasetoMaker from a 32 byte symmetric key
+func NewPasetoLocalMaker(symmetricKey string) (Maker, error) {
+	if len(symmetricKey) != pasetoSymmetricKeySize {
+		return nil, fmt.Errorf("invalid key size: must be exactly %d characters", pasetoSymmetricKeySize)
+	}
+
+	key, err := paseto.V4SymmetricKeyFromBytes([]byte(symmetricKey))
+	if err != nil {
+		return nil, err
+	}
+	return &PasetoMaker{symmetricKey: key}, nil
+}
+
+// NewPasetoPublicMaker creates a v4.public PasetoMaker from a hex encoded Ed25519 private key
+func NewPasetoPublicMaker(privateKeyHex string) (Maker, error) {
+	secretKey, err := paseto.NewV4AsymmetricSecretKeyFromHex(privateKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Ed25519 private key: %w", err)
+	}
+	return &PasetoMaker{
+		public:    true,
+		secretKey: secretKey,
+		publicKey: secretKey.Public(),
+	}, nil
+}
+
+// CreateToken creates a new token for a specific username, roles, MFA state and duration
+func (maker *PasetoMaker) CreateToken(username string, roles []string, mfa bool, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	payload.Roles = roles
+	payload.MFA = mfa
+
+	return maker.signPayload(payload)
+}
+
+// CreateRefreshToken creates a new refresh token for a specific username, MFA state and duration
+func (maker *PasetoMaker) CreateRefreshToken(username string, mfa bool, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	payload.TokenType = TokenTypeRefresh
+	payload.MFA = mfa
+
+	return maker.signPayload(payload)
+}
+
+func (maker *PasetoMaker) signPayload(payload *Payload) (string, *Payload, error) {
+	claims, err := json.Marshal(payload)
+	if err != nil {
+		return "", payload, err
+	}
+
+	pasetoToken, err := paseto.NewTokenFromClaimsJSON(claims, nil)
+	if err != nil {
+		return "", payload, err
+	}
+
+	if maker.public {
+		return pasetoToken.V4Sign(maker.secretKey, nil), payload, nil
+	}
+	return pasetoToken.V4Encrypt(maker.symmetricKey, nil), payload, nil
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
+	// 過期檢查交給 Payload.Valid，才能回傳 ErrExpiredToken
+	parser := paseto.NewParserWithoutExpiryCheck()
+
+	var pasetoToken *paseto.Token
+	var err error
+	if maker.public {
+		pasetoToken, err = parser.ParseV4Public(maker.publicKey, token, nil)
+	} else {
+		pasetoToken, err = parser.ParseV4Local(maker.symmetricKey, token, nil)
+	}
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{}
+	if err := json.Unmarshal(pasetoToken.ClaimsJSON(), payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if err := payload.Valid(); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,36 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPasetoLocalMakerInvalidKeySize(t *testing.T) {
+	maker, err := NewPasetoLocalMaker("too-short")
+	require.Error(t, err)
+	require.Nil(t, maker)
+}
+
+func TestNewPasetoPublicMakerInvalidKey(t *testing.T) {
+	maker, err := NewPasetoPublicMaker("not-hex")
+	require.Error(t, err)
+	require.Nil(t, maker)
+}
+
+func TestPasetoPublicTokenWrongKey(t *testing.T) {
+	maker, err := NewPasetoPublicMaker(paseto.NewV4AsymmetricSecretKey().ExportHex())
+	require.NoError(t, err)
+	otherMaker, err := NewPasetoPublicMaker(paseto.NewV4AsymmetricSecretKey().ExportHex())
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken("test-user", nil, false, time.Minute)
+	require.NoError(t, err)
+
+	// 使用其他金鑰簽署的 Token 必須被拒絕
+	payload, err := otherMaker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
@@ -14,11 +14,38 @@ var (
 	ErrInvalidToken = errors.New("token is invalid")
 )
 
+// TokenType distinguishes short-lived access tokens from refresh tokens
+type TokenType string
+
+const (
+	TokenTypeAccess     TokenType = "access"
+	TokenTypeRefresh    TokenType = "refresh"
+	TokenTypeAPIKey     TokenType = "api_key"
+	TokenTypeClientCert TokenType = "client_cert"
+	TokenTypeSigningKey TokenType = "signing_key"
+)
+
+// Roles carried in the roles claim
+const (
+	RoleParticipant = "participant"
+	RoleJudge       = "judge"
+	RoleAdmin       = "admin"
+)
+
+// Scopes that can be granted to an API key
+const (
+	ScopeScoresSubmit = "scores:submit"
+)
+
 // Payload contains the payload data of the token
 type Payload struct {
 	// ... (Structure definition remains)
 	ID        uuid.UUID `json:"id"`
 	Username  string    `json:"username"`
+	TokenType TokenType `json:"token_type"`
+	Roles     []string  `json:"roles,omitempty"`
+	Scopes    []string  `json:"scopes,omitempty"`
+	MFA       bool      `json:"mfa,omitempty"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
@@ -34,12 +61,33 @@ func NewPayload(username string, duration time.Duration) (*Payload, error) {
 	payload := &Payload{
 		ID:        tokenID,
 		Username:  username,
+		TokenType: TokenTypeAccess,
 		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
 	}
 	return payload, nil
 }
 
+// HasRole reports whether the payload carries role
+func (payload *Payload) HasRole(role string) bool {
+	for _, r := range payload.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the payload carries scope
+func (payload *Payload) HasScope(scope string) bool {
+	for _, s := range payload.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
 // Valid checks if the token payload is valid or not
 func (payload *Payload) Valid() error {
 	if time.Now().After(payload.ExpiredAt) {
@@ -0,0 +1,104 @@
+package token
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevocationStore records tokens that must be rejected before they expire
+type RevocationStore interface {
+	// RevokeToken revokes a single token by its Payload.ID
+	RevokeToken(ctx context.Context, id uuid.UUID, username string, expiresAt time.Time) error
+	// RevokeUserTokens revokes every token issued to username before revokedBefore.
+	// The entry may be discarded after expiresAt.
+	RevokeUserTokens(ctx context.Context, username string, revokedBefore time.Time, expiresAt time.Time) error
+	// IsRevoked reports whether the verified payload has been revoked
+	IsRevoked(ctx context.Context, payload *Payload) (bool, error)
+	// DeleteExpired removes entries that can no longer match a valid token
+	DeleteExpired(ctx context.Context) error
+}
+
+// StartRevocationCleanup calls DeleteExpired on every tick until ctx is done
+func StartRevocationCleanup(ctx context.Context, store RevocationStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := store.DeleteExpired(ctx); err != nil {
+					log.Printf("failed to delete expired token revocations: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+type userRevocation struct {
+	revokedBefore time.Time
+	expiresAt     time.Time
+}
+
+// MemoryRevocationStore is an in-process RevocationStore for single-instance deployments and tests
+type MemoryRevocationStore struct {
+	mu     sync.RWMutex
+	tokens map[uuid.UUID]time.Time
+	users  map[string]userRevocation
+}
+
+// NewMemoryRevocationStore creates an empty MemoryRevocationStore
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{
+		tokens: make(map[uuid.UUID]time.Time),
+		users:  make(map[string]userRevocation),
+	}
+}
+
+func (store *MemoryRevocationStore) RevokeToken(_ context.Context, id uuid.UUID, _ string, expiresAt time.Time) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.tokens[id] = expiresAt
+	return nil
+}
+
+func (store *MemoryRevocationStore) RevokeUserTokens(_ context.Context, username string, revokedBefore time.Time, expiresAt time.Time) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.users[username] = userRevocation{revokedBefore: revokedBefore, expiresAt: expiresAt}
+	return nil
+}
+
+func (store *MemoryRevocationStore) IsRevoked(_ context.Context, payload *Payload) (bool, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	if _, ok := store.tokens[payload.ID]; ok {
+		return true, nil
+	}
+	if revocation, ok := store.users[payload.Username]; ok && payload.IssuedAt.Before(revocation.revokedBefore) {
+		return true, nil
+	}
+	return false, nil
+}
+
+func (store *MemoryRevocationStore) DeleteExpired(_ context.Context) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	now := time.Now()
+	for id, expiresAt := range store.tokens {
+		if now.After(expiresAt) {
+			delete(store.tokens, id)
+		}
+	}
+	for username, revocation := range store.users {
+		if now.After(revocation.expiresAt) {
+			delete(store.users, username)
+		}
+	}
+	return nil
+}
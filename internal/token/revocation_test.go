@@ -0,0 +1,85 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRevocationStore_RevokeToken(t *testing.T) {
+	store := NewMemoryRevocationStore()
+	ctx := context.Background()
+
+	payload, err := NewPayload("test-user", time.Minute)
+	require.NoError(t, err)
+	other, err := NewPayload("test-user", time.Minute)
+	require.NoError(t, err)
+
+	revoked, err := store.IsRevoked(ctx, payload)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	require.NoError(t, store.RevokeToken(ctx, payload.ID, payload.Username, payload.ExpiredAt))
+
+	revoked, err = store.IsRevoked(ctx, payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	// 只撤銷指定的 Token
+	revoked, err = store.IsRevoked(ctx, other)
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
+
+func TestMemoryRevocationStore_RevokeUserTokens(t *testing.T) {
+	store := NewMemoryRevocationStore()
+	ctx := context.Background()
+
+	before, err := NewPayload("test-user", time.Minute)
+	require.NoError(t, err)
+	before.IssuedAt = time.Now().Add(-time.Second)
+	otherUser, err := NewPayload("other-user", time.Minute)
+	require.NoError(t, err)
+	otherUser.IssuedAt = before.IssuedAt
+
+	require.NoError(t, store.RevokeUserTokens(ctx, "test-user", time.Now(), time.Now().Add(time.Hour)))
+
+	after, err := NewPayload("test-user", time.Minute)
+	require.NoError(t, err)
+	after.IssuedAt = time.Now().Add(time.Second)
+
+	revoked, err := store.IsRevoked(ctx, before)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	// 撤銷之後重新登入取得的 Token 仍然有效
+	revoked, err = store.IsRevoked(ctx, after)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	revoked, err = store.IsRevoked(ctx, otherUser)
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
+
+func TestMemoryRevocationStore_DeleteExpired(t *testing.T) {
+	store := NewMemoryRevocationStore()
+	ctx := context.Background()
+
+	expired, err := NewPayload("test-user", -time.Minute)
+	require.NoError(t, err)
+	live, err := NewPayload("test-user", time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, store.RevokeToken(ctx, expired.ID, expired.Username, expired.ExpiredAt))
+	require.NoError(t, store.RevokeToken(ctx, live.ID, live.Username, live.ExpiredAt))
+	require.NoError(t, store.RevokeUserTokens(ctx, "old-user", time.Now(), time.Now().Add(-time.Minute)))
+
+	require.NoError(t, store.DeleteExpired(ctx))
+
+	require.NotContains(t, store.tokens, expired.ID)
+	require.Contains(t, store.tokens, live.ID)
+	require.NotContains(t, store.users, "old-user")
+}
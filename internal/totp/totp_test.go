@@ -0,0 +1,95 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 附錄 B 的 SHA-1 測試向量，取 8 位數結果的末 6 位
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" // "12345678901234567890"
+
+func TestCodeRFC6238Vectors(t *testing.T) {
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tc := range testCases {
+		code, err := Code(rfcSecret, Step(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tc.code, code, "T=%d", tc.unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+
+	testCases := []struct {
+		name   string
+		code   func() string
+		wantOK bool
+		step   int64
+	}{
+		{"current step", func() string { c, _ := Code(rfcSecret, current); return c }, true, current},
+		{"previous step", func() string { c, _ := Code(rfcSecret, current-1); return c }, true, current - 1},
+		{"next step", func() string { c, _ := Code(rfcSecret, current+1); return c }, true, current + 1},
+		{"outside skew", func() string { c, _ := Code(rfcSecret, current-2); return c }, false, 0},
+		{"wrong length", func() string { return "12345" }, false, 0},
+		{"wrong code", func() string { return "000000" }, false, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			step, ok := Validate(rfcSecret, tc.code(), now)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.step, step)
+		})
+	}
+}
+
+func TestValidateInvalidSecret(t *testing.T) {
+	_, ok := Validate("not base32!", "123456", time.Now())
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	other, err := GenerateSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret, other)
+
+	// 新產生的 secret 必須能計算出驗證碼
+	code, err := Code(secret, Step(time.Now()))
+	require.NoError(t, err)
+	_, ok := Validate(secret, code, time.Now())
+	require.True(t, ok)
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("HPL Scoreboard", "alice", rfcSecret)
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/HPL Scoreboard:alice", u.Path)
+	assert.Equal(t, rfcSecret, u.Query().Get("secret"))
+	assert.Equal(t, "HPL Scoreboard", u.Query().Get("issuer"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+	assert.Equal(t, "30", u.Query().Get("period"))
+}
@@ -0,0 +1,5 @@
+CREATE TABLE "users" (
+  "username" varchar PRIMARY KEY,
+  "hashed_password" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
@@ -0,0 +1,15 @@
+CREATE TABLE "sessions" (
+  "id" uuid PRIMARY KEY,
+  "family_id" uuid NOT NULL,
+  "username" varchar NOT NULL REFERENCES "users" ("username") ON DELETE CASCADE,
+  "user_agent" varchar NOT NULL DEFAULT '',
+  "client_ip" varchar NOT NULL DEFAULT '',
+  "is_revoked" boolean NOT NULL DEFAULT false,
+  "rotated_at" timestamptz,
+  "replaced_by" uuid,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "sessions" ("family_id");
+CREATE INDEX ON "sessions" ("username");
@@ -0,0 +1,18 @@
+CREATE TABLE "revoked_tokens" (
+  "id" uuid PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "revoked_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "revoked_tokens" ("expires_at");
+
+-- Every token issued to "username" before "revoked_before" is revoked.
+-- The row can be dropped once no such token can still be valid.
+CREATE TABLE "user_token_revocations" (
+  "username" varchar PRIMARY KEY,
+  "revoked_before" timestamptz NOT NULL,
+  "expires_at" timestamptz NOT NULL
+);
+
+CREATE INDEX ON "user_token_revocations" ("expires_at");
@@ -0,0 +1,4 @@
+ALTER TABLE "users" ADD COLUMN "roles" varchar[] NOT NULL DEFAULT '{participant}';
+
+ALTER TABLE "users" ADD CONSTRAINT "users_roles_check"
+  CHECK ("roles" <@ ARRAY['participant', 'judge', 'admin']::varchar[]);
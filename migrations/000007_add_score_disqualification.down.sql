@@ -0,0 +1,3 @@
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "disqualification_reason";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "disqualified_by";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "disqualified_at";
@@ -0,0 +1,3 @@
+ALTER TABLE "scores" ADD COLUMN "disqualified_at" timestamptz;
+ALTER TABLE "scores" ADD COLUMN "disqualified_by" varchar NOT NULL DEFAULT '';
+ALTER TABLE "scores" ADD COLUMN "disqualification_reason" varchar NOT NULL DEFAULT '';
@@ -0,0 +1,14 @@
+CREATE TABLE "api_keys" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "username" varchar NOT NULL REFERENCES "users" ("username") ON DELETE CASCADE,
+  "name" varchar NOT NULL,
+  "prefix" varchar NOT NULL,
+  "hashed_key" varchar UNIQUE NOT NULL,
+  "scopes" varchar[] NOT NULL,
+  "expires_at" timestamptz,
+  "last_used_at" timestamptz,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "api_keys" ("username");
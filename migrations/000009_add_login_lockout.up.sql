@@ -0,0 +1,21 @@
+CREATE TABLE "login_failures" (
+  "scope" varchar NOT NULL,
+  "subject" varchar NOT NULL,
+  "failures" int NOT NULL DEFAULT 0,
+  "last_failure_at" timestamptz NOT NULL DEFAULT (now()),
+  "locked_until" timestamptz,
+  PRIMARY KEY ("scope", "subject"),
+  CONSTRAINT "login_failures_scope_check" CHECK ("scope" IN ('account', 'ip'))
+);
+
+CREATE TABLE "audit_events" (
+  "id" bigserial PRIMARY KEY,
+  "event" varchar NOT NULL,
+  "actor" varchar NOT NULL DEFAULT '',
+  "subject" varchar NOT NULL DEFAULT '',
+  "client_ip" varchar NOT NULL DEFAULT '',
+  "details" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "audit_events" ("event", "created_at");
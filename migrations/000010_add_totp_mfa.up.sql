@@ -0,0 +1,13 @@
+ALTER TABLE "users" ADD COLUMN "totp_secret" varchar NOT NULL DEFAULT '';
+ALTER TABLE "users" ADD COLUMN "totp_enabled_at" timestamptz;
+ALTER TABLE "users" ADD COLUMN "totp_last_step" bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE "mfa_recovery_codes" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL REFERENCES "users" ("username") ON DELETE CASCADE,
+  "hashed_code" varchar NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "mfa_recovery_codes" ("username", "hashed_code");
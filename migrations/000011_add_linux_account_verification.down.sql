@@ -0,0 +1,4 @@
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "linux_username_verified";
+
+DROP TABLE IF EXISTS "linux_accounts";
+DROP TABLE IF EXISTS "ssh_keys";
@@ -0,0 +1,24 @@
+CREATE TABLE "ssh_keys" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "username" varchar NOT NULL REFERENCES "users" ("username") ON DELETE CASCADE,
+  "linux_username" varchar NOT NULL,
+  "public_key" varchar NOT NULL,
+  "fingerprint" varchar NOT NULL,
+  "challenge" varchar NOT NULL DEFAULT '',
+  "challenge_expires_at" timestamptz,
+  "verified_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "ssh_keys" ("username", "fingerprint");
+
+-- 每個叢集帳號只能綁定到一個計分板帳號，先完成驗證者取得
+CREATE TABLE "linux_accounts" (
+  "linux_username" varchar PRIMARY KEY,
+  "username" varchar NOT NULL REFERENCES "users" ("username") ON DELETE CASCADE,
+  "verified_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "linux_accounts" ("username");
+
+ALTER TABLE "scores" ADD COLUMN "linux_username_verified" boolean NOT NULL DEFAULT false;
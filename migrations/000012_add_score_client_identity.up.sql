@@ -0,0 +1,2 @@
+-- 以 mTLS 用戶端憑證送出的成績記錄憑證對應的身分，例如 "user:alice" 或 "system:cluster-a-node01"
+ALTER TABLE "scores" ADD COLUMN "client_identity" varchar NOT NULL DEFAULT '';
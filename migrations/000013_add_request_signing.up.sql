@@ -0,0 +1,21 @@
+-- HMAC 簽章需要原始的共享密鑰，因此 secret 無法像 API Key 一樣只存雜湊
+CREATE TABLE "signing_keys" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "username" varchar NOT NULL REFERENCES "users" ("username") ON DELETE CASCADE,
+  "name" varchar NOT NULL,
+  "secret" varchar NOT NULL,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "signing_keys" ("username");
+
+-- 用過的 nonce 保留到簽章時間戳記失效為止
+CREATE TABLE "request_nonces" (
+  "key_id" varchar NOT NULL,
+  "nonce" varchar NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  PRIMARY KEY ("key_id", "nonce")
+);
+
+CREATE INDEX ON "request_nonces" ("expires_at");
@@ -0,0 +1,5 @@
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "team_id";
+
+DROP TABLE IF EXISTS "team_invitations";
+DROP TABLE IF EXISTS "team_members";
+DROP TABLE IF EXISTS "teams";
@@ -0,0 +1,37 @@
+CREATE TABLE "teams" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "name" varchar UNIQUE NOT NULL,
+  "created_by" varchar NOT NULL REFERENCES "users" ("username"),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- role 為 owner 或 member，owner 可以邀請、移除成員及調整角色
+CREATE TABLE "team_members" (
+  "team_id" uuid NOT NULL REFERENCES "teams" ("id") ON DELETE CASCADE,
+  "username" varchar NOT NULL REFERENCES "users" ("username") ON DELETE CASCADE,
+  "role" varchar NOT NULL DEFAULT 'member',
+  "joined_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("team_id", "username")
+);
+
+CREATE INDEX ON "team_members" ("username");
+
+-- 邀請碼和 API Key 一樣只存 SHA-256 雜湊
+CREATE TABLE "team_invitations" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "team_id" uuid NOT NULL REFERENCES "teams" ("id") ON DELETE CASCADE,
+  "hashed_code" varchar UNIQUE NOT NULL,
+  "created_by" varchar NOT NULL REFERENCES "users" ("username") ON DELETE CASCADE,
+  "max_uses" integer NOT NULL,
+  "use_count" integer NOT NULL DEFAULT 0,
+  "expires_at" timestamptz NOT NULL,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "team_invitations" ("team_id");
+
+-- 解散隊伍時成績保留，只是不再屬於任何隊伍
+ALTER TABLE "scores" ADD COLUMN "team_id" uuid REFERENCES "teams" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "scores" ("team_id");
@@ -0,0 +1,3 @@
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "run_nonce";
+
+DROP TABLE IF EXISTS "run_nonces";
@@ -0,0 +1,12 @@
+-- 開始跑 HPL 前向伺服器取得的 nonce，嵌入輸出後隨成績送回，證明成績是在核發之後跑出來的
+CREATE TABLE "run_nonces" (
+  "nonce" varchar PRIMARY KEY,
+  "username" varchar NOT NULL REFERENCES "users" ("username") ON DELETE CASCADE,
+  "issued_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz
+);
+
+CREATE INDEX ON "run_nonces" ("username");
+
+ALTER TABLE "scores" ADD COLUMN "run_nonce" varchar NOT NULL DEFAULT '';
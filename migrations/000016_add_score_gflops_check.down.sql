@@ -0,0 +1,2 @@
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "gflops_flagged";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "expected_gflops";
@@ -0,0 +1,4 @@
+-- expected_gflops 由 n 與 execution_time 計算：(2/3·N³ + 2·N²) / time / 1e9
+-- gflops_flagged 標記回報值與預期值差距超過容許範圍的既有成績 (由 backfill-gflops 設定)
+ALTER TABLE "scores" ADD COLUMN "expected_gflops" double precision NOT NULL DEFAULT 0;
+ALTER TABLE "scores" ADD COLUMN "gflops_flagged" boolean NOT NULL DEFAULT false;
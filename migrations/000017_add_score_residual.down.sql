@@ -0,0 +1,4 @@
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "residual_passed";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "residual_threshold";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "residual";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "variant";
@@ -0,0 +1,7 @@
+-- variant 是 HPL 的 T/V 欄位，例如 WR11C2R4
+-- residual 為 scaled residual，residual_passed 為 residual < residual_threshold；
+-- 未回報 residual 的成績 (包含既有資料) 三個欄位皆為 NULL
+ALTER TABLE "scores" ADD COLUMN "variant" varchar NOT NULL DEFAULT '';
+ALTER TABLE "scores" ADD COLUMN "residual" double precision;
+ALTER TABLE "scores" ADD COLUMN "residual_threshold" double precision;
+ALTER TABLE "scores" ADD COLUMN "residual_passed" boolean;
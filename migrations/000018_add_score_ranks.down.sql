@@ -0,0 +1,5 @@
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "gflops_per_rank";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "gflops_per_node";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "ranks_per_node";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "nodes";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "ranks";
@@ -0,0 +1,7 @@
+-- ranks = p × q = nodes × ranks_per_node；既有成績為 0 代表未回報
+ALTER TABLE "scores" ADD COLUMN "ranks" integer NOT NULL DEFAULT 0;
+ALTER TABLE "scores" ADD COLUMN "nodes" integer NOT NULL DEFAULT 0;
+ALTER TABLE "scores" ADD COLUMN "ranks_per_node" integer NOT NULL DEFAULT 0;
+-- 未回報 nodes / ranks 的成績為 NULL
+ALTER TABLE "scores" ADD COLUMN "gflops_per_node" double precision GENERATED ALWAYS AS (gflops / NULLIF(nodes, 0)) STORED;
+ALTER TABLE "scores" ADD COLUMN "gflops_per_rank" double precision GENERATED ALWAYS AS (gflops / NULLIF(ranks, 0)) STORED;
@@ -0,0 +1,4 @@
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "efficiency";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "rpeak_gflops";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "system_id";
+DROP TABLE IF EXISTS "systems";
@@ -0,0 +1,28 @@
+-- 每個節點的理論峰值 = cores_per_node × clock_ghz × flops_per_cycle + accelerator_gflops_per_node，
+-- flops_per_cycle 為每個核心每個時脈的雙精度浮點運算數，例如 AVX-512 雙 FMA 為 32
+CREATE TABLE "systems" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "name" varchar UNIQUE NOT NULL,
+  "nodes" integer NOT NULL,
+  "cpu_model" varchar NOT NULL,
+  "cores_per_node" integer NOT NULL,
+  "clock_ghz" double precision NOT NULL,
+  "flops_per_cycle" integer NOT NULL,
+  "accelerators" varchar NOT NULL DEFAULT '',
+  "accelerator_gflops_per_node" double precision NOT NULL DEFAULT 0,
+  "interconnect" varchar NOT NULL DEFAULT '',
+  "mpi" varchar NOT NULL DEFAULT '',
+  "blas" varchar NOT NULL DEFAULT '',
+  "node_rpeak_gflops" double precision NOT NULL GENERATED ALWAYS AS (cores_per_node * clock_ghz * flops_per_cycle + accelerator_gflops_per_node) STORED,
+  "rpeak_gflops" double precision NOT NULL GENERATED ALWAYS AS (nodes * (cores_per_node * clock_ghz * flops_per_cycle + accelerator_gflops_per_node)) STORED,
+  "created_by" varchar NOT NULL REFERENCES "users" ("username"),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- 有成績的系統不能刪除；rpeak_gflops 為送出時依使用的節點數計算的峰值，系統規格修改時一併更新
+ALTER TABLE "scores" ADD COLUMN "system_id" uuid REFERENCES "systems" ("id") ON DELETE RESTRICT;
+ALTER TABLE "scores" ADD COLUMN "rpeak_gflops" double precision;
+ALTER TABLE "scores" ADD COLUMN "efficiency" double precision GENERATED ALWAYS AS (gflops / NULLIF(rpeak_gflops, 0)) STORED;
+
+CREATE INDEX ON "scores" ("system_id");
@@ -0,0 +1,4 @@
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "reviewed_at";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "reviewed_by";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "review_reason";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "review_status";
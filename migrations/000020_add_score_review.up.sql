@@ -0,0 +1,8 @@
+-- review_status：accepted (自動通過或審核通過)、pending (efficiency 超過門檻，等待審核)、rejected (審核未通過)
+-- 只有 accepted 的成績列入排行榜；review_reason 記錄每次決定的原因
+ALTER TABLE "scores" ADD COLUMN "review_status" varchar NOT NULL DEFAULT 'accepted';
+ALTER TABLE "scores" ADD COLUMN "review_reason" varchar NOT NULL DEFAULT '';
+ALTER TABLE "scores" ADD COLUMN "reviewed_by" varchar NOT NULL DEFAULT '';
+ALTER TABLE "scores" ADD COLUMN "reviewed_at" timestamptz;
+
+CREATE INDEX ON "scores" ("submitted_at") WHERE "review_status" = 'pending';
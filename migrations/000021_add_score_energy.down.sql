@@ -0,0 +1,3 @@
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "gflops_per_watt";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "energy_joules";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "avg_power_watts";
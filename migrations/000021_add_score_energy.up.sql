@@ -0,0 +1,6 @@
+-- 平均功耗與耗能皆為選填，未回報的成績為 NULL
+ALTER TABLE "scores" ADD COLUMN "avg_power_watts" double precision;
+ALTER TABLE "scores" ADD COLUMN "energy_joules" double precision;
+ALTER TABLE "scores" ADD COLUMN "gflops_per_watt" double precision GENERATED ALWAYS AS (gflops / NULLIF(avg_power_watts, 0)) STORED;
+
+CREATE INDEX ON "scores" ("gflops_per_watt" DESC) WHERE "gflops_per_watt" IS NOT NULL;
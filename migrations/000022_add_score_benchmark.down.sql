@@ -0,0 +1,10 @@
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "metric";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "mxp_precision";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "stream_triad_mbs";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "stream_add_mbs";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "stream_scale_mbs";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "stream_copy_mbs";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "hpcg_nz";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "hpcg_ny";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "hpcg_nx";
+ALTER TABLE "scores" DROP COLUMN IF EXISTS "benchmark";
@@ -0,0 +1,17 @@
+-- benchmark：hpl、hpl-mxp、hpcg 或 stream；既有成績皆為 hpl
+ALTER TABLE "scores" ADD COLUMN "benchmark" varchar NOT NULL DEFAULT 'hpl';
+-- HPCG 每個 rank 的 local grid，非 HPCG 成績為 0
+ALTER TABLE "scores" ADD COLUMN "hpcg_nx" integer NOT NULL DEFAULT 0;
+ALTER TABLE "scores" ADD COLUMN "hpcg_ny" integer NOT NULL DEFAULT 0;
+ALTER TABLE "scores" ADD COLUMN "hpcg_nz" integer NOT NULL DEFAULT 0;
+-- STREAM 四個 kernel 的頻寬 (MB/s)，非 STREAM 成績為 NULL
+ALTER TABLE "scores" ADD COLUMN "stream_copy_mbs" double precision;
+ALTER TABLE "scores" ADD COLUMN "stream_scale_mbs" double precision;
+ALTER TABLE "scores" ADD COLUMN "stream_add_mbs" double precision;
+ALTER TABLE "scores" ADD COLUMN "stream_triad_mbs" double precision;
+-- HPL-MxP 的 LU 分解精度，例如 fp16
+ALTER TABLE "scores" ADD COLUMN "mxp_precision" varchar NOT NULL DEFAULT '';
+-- 排行榜排名依據：STREAM 為 triad 頻寬，其他 benchmark 為 gflops
+ALTER TABLE "scores" ADD COLUMN "metric" double precision GENERATED ALWAYS AS (CASE WHEN benchmark = 'stream' THEN stream_triad_mbs ELSE gflops END) STORED;
+
+CREATE INDEX ON "scores" ("benchmark", "metric" DESC);
@@ -0,0 +1,10 @@
+-- 外部身分以 (issuer, subject) 對應本地帳號；preferred_username 可由使用者變更，不能作為識別
+CREATE TABLE "oidc_identities" (
+  "issuer" varchar NOT NULL,
+  "subject" varchar NOT NULL,
+  "username" varchar NOT NULL REFERENCES "users" ("username") ON DELETE CASCADE,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("issuer", "subject")
+);
+
+CREATE INDEX ON "oidc_identities" ("username");